@@ -0,0 +1,20 @@
+# The import spec consists of following keys:
+#
+# - id (Optional)                        : The operation id. Defaults to the `path`.
+# - method (Required)                    : The HTTP method of the operation.
+# - path (Required)                      : The path of the operation.
+# - query (Optional)                     : The query parameters.
+# - header (Optional)                    : The header.
+# - body (Optional)                      : The payload of the operation. This should be the same as the `body` in the config,
+#                                          otherwise the operation will be invoked again in the next apply.
+# - output (Optional)                    : The pre-known response of the operation, which is used to reconstruct the `output`,
+#                                          as the operation is not invoked during import.
+terraform import restful_operation.register_rp '{
+  "method": "POST",
+  "path": "/subscriptions/0-0-0-0/providers/Microsoft.ProviderHub/register",
+  "query": {"api-version": ["2014-04-01-preview"]},
+  "output": {
+    "namespace": "Microsoft.ProviderHub",
+    "registrationState": "Registered"
+  }
+}'
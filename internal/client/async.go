@@ -3,7 +3,9 @@ package client
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +43,23 @@ func (loc HeaderLocator) String() string {
 	return fmt.Sprintf(`header.%s`, string(loc))
 }
 
+// LinkLocator locates the target URL of the relation type in the `Link` header (RFC 5988).
+type LinkLocator string
+
+var _ ValueLocator = LinkLocator("")
+
+func (loc LinkLocator) LocateValueInResp(resp resty.Response) (string, bool) {
+	for _, v := range resp.Header().Values("Link") {
+		if target, ok := ParseLinkHeader(v)[strings.ToLower(string(loc))]; ok {
+			return target, true
+		}
+	}
+	return "", false
+}
+func (loc LinkLocator) String() string {
+	return fmt.Sprintf(`link.%s`, string(loc))
+}
+
 type BodyLocator string
 
 var _ ValueLocator = BodyLocator("")
@@ -69,6 +88,19 @@ type PollingStatus struct {
 	Success string
 }
 
+// PollCondition is an extra status condition that needs to be satisfied, together with the main status, for the polling to be done.
+type PollCondition struct {
+	StatusLocator ValueLocator
+	Status        PollingStatus
+}
+
+// PollAbortCondition aborts the polling once the value located in the polling response matches any of the values,
+// regardless of the status. The values are matched in the same way as the status sentinels (see matchStatus).
+type PollAbortCondition struct {
+	Locator ValueLocator
+	Values  []string
+}
+
 type PollOption struct {
 	// StatusLocator indicates where the polling status is located in the response of the polling requests.
 	StatusLocator ValueLocator
@@ -86,14 +118,36 @@ type PollOption struct {
 
 	// DefaultDelay specifies the interval between two pollings. The `Retry-After` in the response header takes higher precedence than this.
 	DefaultDelay time.Duration
+
+	// RetryStatusCodes specifies the status codes of the polling response that are regarded as transient, which keep the polling going.
+	// The polling fails once more than maxPollTransientRetries transient responses are returned in a row.
+	RetryStatusCodes []int64
+
+	// Conditions specifies the extra status conditions, which all need to be satisfied for the polling to be done.
+	Conditions []PollCondition
+
+	// AbortIf specifies the conditions that abort the polling with an error once any of them is matched.
+	AbortIf []PollAbortCondition
 }
 
 func NewPollableForPoll(resp resty.Response, opt PollOption) (*Pollable, error) {
 	p := Pollable{
-		DefaultDelay: opt.DefaultDelay,
-		Header:       opt.Header,
-		Query:        opt.Query,
+		DefaultDelay:     opt.DefaultDelay,
+		Header:           opt.Header,
+		Query:            opt.Query,
+		RetryStatusCodes: opt.RetryStatusCodes,
+	}
+
+	for i, cond := range opt.Conditions {
+		if cond.Status.Success == "" {
+			return nil, fmt.Errorf("Conditions[%d].Status.Success is required but not set", i)
+		}
+		if cond.StatusLocator == nil {
+			return nil, fmt.Errorf("Conditions[%d].StatusLocator is required but not set", i)
+		}
 	}
+	p.Conditions = opt.Conditions
+	p.AbortIf = opt.AbortIf
 
 	if opt.Status.Success == "" {
 		return nil, fmt.Errorf("Status.Success is required but not set")
@@ -145,6 +199,7 @@ func NewPollableForPrecheck(opt PollOption) (*Pollable, error) {
 	p := Pollable{
 		DefaultDelay: opt.DefaultDelay,
 		Header:       opt.Header,
+		AbortIf:      opt.AbortIf,
 	}
 
 	if opt.Status.Success == "" {
@@ -170,17 +225,28 @@ func NewPollableForPrecheck(opt PollOption) (*Pollable, error) {
 }
 
 type Pollable struct {
-	InitDelay     time.Duration
-	URL           string
-	Header        Header
-	Query         Query
-	Status        PollingStatus
-	StatusLocator ValueLocator
-	DefaultDelay  time.Duration
+	InitDelay        time.Duration
+	URL              string
+	Header           Header
+	Query            Query
+	Status           PollingStatus
+	StatusLocator    ValueLocator
+	DefaultDelay     time.Duration
+	RetryStatusCodes []int64
+	Conditions       []PollCondition
+	AbortIf          []PollAbortCondition
+	// GoneAsSuccess regards a 404 response as success, e.g. when confirming a deletion.
+	GoneAsSuccess bool
 }
 
+// maxPollTransientRetries is the maximum number of the transient polling responses in a row (see RetryStatusCodes), before the polling fails.
+const maxPollTransientRetries = 10
+
 func (f *Pollable) PollUntilDone(ctx context.Context, client *Client) error {
-	time.Sleep(f.InitDelay)
+	if err := sleep(ctx, f.InitDelay); err != nil {
+		return err
+	}
+	var transients int
 PollingLoop:
 	for {
 		// There is no need to retry here as resty client has embedded retry logic (by default 3 max retries).
@@ -190,37 +256,119 @@ PollingLoop:
 			return fmt.Errorf("polling %s: %v", f.URL, err)
 		}
 
+		if f.GoneAsSuccess && resp.StatusCode() == http.StatusNotFound {
+			return nil
+		}
+
+		// Keep polling in case the polling endpoint returns a transient error.
+		if slices.Contains(f.RetryStatusCodes, int64(resp.StatusCode())) {
+			transients++
+			if transients > maxPollTransientRetries {
+				return fmt.Errorf("polling returns %d for %d times in a row: %s", resp.StatusCode(), transients, string(resp.Body()))
+			}
+			d, err := f.delay(resp)
+			if err != nil {
+				return err
+			}
+			if err := sleep(ctx, d); err != nil {
+				return err
+			}
+			continue PollingLoop
+		}
+		transients = 0
+
+		conds := append([]PollCondition{{StatusLocator: f.StatusLocator, Status: f.Status}}, f.Conditions...)
+
 		// In case this is status_locator is not a code locator, then we shall firstly ensure the GET succeeded,
 		// to avoid the status retrieving error hides the actual GET error.
-		if _, ok := f.StatusLocator.(CodeLocator); !ok {
-			if !resp.IsSuccess() {
-				return fmt.Errorf("polling returns %d: %s", resp.StatusCode(), string(resp.Body()))
+		for _, cond := range conds {
+			if _, ok := cond.StatusLocator.(CodeLocator); !ok {
+				if !resp.IsSuccess() {
+					return fmt.Errorf("polling returns %d: %s", resp.StatusCode(), string(resp.Body()))
+				}
+				break
 			}
 		}
 
-		status, ok := f.StatusLocator.LocateValueInResp(*resp)
-		if !ok {
-			return fmt.Errorf("No status value found from %s", f.StatusLocator)
+		for _, abort := range f.AbortIf {
+			v, ok := abort.Locator.LocateValueInResp(*resp)
+			if !ok {
+				continue
+			}
+			if slices.ContainsFunc(abort.Values, func(sentinel string) bool { return matchStatus(abort.Locator, v, sentinel) }) {
+				return fmt.Errorf("polling aborted as %s is %q: %s", abort.Locator, v, string(resp.Body()))
+			}
 		}
-		// We tolerate case difference here to be pragmatic.
-		if strings.EqualFold(status, f.Status.Success) {
+
+		done := true
+		for _, cond := range conds {
+			ok, err := checkStatus(*resp, cond)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				done = false
+			}
+		}
+		if done {
 			return nil
 		}
-		for _, ps := range f.Status.Pending {
-			if strings.EqualFold(status, ps) {
-				dur := resp.Header().Get("Retry-After")
-				if dur == "" {
-					time.Sleep(f.DefaultDelay)
-					continue PollingLoop
-				}
-				d, err := time.ParseDuration(dur + "s")
-				if err != nil {
-					return fmt.Errorf("invalid Retry-After value in the initiated response: %s", dur)
-				}
-				time.Sleep(d)
-				continue PollingLoop
-			}
+		d, err := f.delay(resp)
+		if err != nil {
+			return err
+		}
+		if err := sleep(ctx, d); err != nil {
+			return err
 		}
-		return fmt.Errorf("Unexpected status %q. Full response: %v", status, string(resp.Body()))
 	}
 }
+
+// sleep waits for the duration, or returns the error of the context once it is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
+
+// checkStatus checks the status of the condition in the response, returns true if it is succeeded, false if it is pending.
+// An error is returned if the status is neither succeeded nor pending.
+// An exact match takes precedence over a status code pattern, e.g. a `202` is pending for the success `2xx` and the pending `202`.
+func checkStatus(resp resty.Response, cond PollCondition) (bool, error) {
+	status, ok := cond.StatusLocator.LocateValueInResp(resp)
+	if !ok {
+		return false, fmt.Errorf("No status value found from %s", cond.StatusLocator)
+	}
+	if strings.EqualFold(status, cond.Status.Success) {
+		return true, nil
+	}
+	for _, ps := range cond.Status.Pending {
+		if strings.EqualFold(status, ps) {
+			return false, nil
+		}
+	}
+	if matchStatus(cond.StatusLocator, status, cond.Status.Success) {
+		return true, nil
+	}
+	for _, ps := range cond.Status.Pending {
+		if matchStatus(cond.StatusLocator, status, ps) {
+			return false, nil
+		}
+	}
+	return false, fmt.Errorf("Unexpected status %q. Full response: %v", status, string(resp.Body()))
+}
+
+// delay returns the interval before the next polling, which is the `Retry-After` in the response header if any, otherwise the default delay.
+func (f *Pollable) delay(resp *resty.Response) (time.Duration, error) {
+	dur := resp.Header().Get("Retry-After")
+	if dur == "" {
+		return f.DefaultDelay, nil
+	}
+	d, err := time.ParseDuration(dur + "s")
+	if err != nil {
+		return 0, fmt.Errorf("invalid Retry-After value in the initiated response: %s", dur)
+	}
+	return d, nil
+}
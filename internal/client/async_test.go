@@ -0,0 +1,326 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollUntilDoneConditions(t *testing.T) {
+	cases := []struct {
+		name      string
+		responses []string
+		polls     int
+		err       bool
+	}{
+		{
+			name: "all succeeded",
+			responses: []string{
+				`{"provisioningState": "Succeeded", "operationState": "Succeeded"}`,
+			},
+			polls: 1,
+		},
+		{
+			name: "wait for all",
+			responses: []string{
+				`{"provisioningState": "Running", "operationState": "Running"}`,
+				`{"provisioningState": "Succeeded", "operationState": "Running"}`,
+				`{"provisioningState": "Succeeded", "operationState": "Succeeded"}`,
+			},
+			polls: 3,
+		},
+		{
+			name: "unexpected condition status",
+			responses: []string{
+				`{"provisioningState": "Succeeded", "operationState": "Failed"}`,
+			},
+			polls: 1,
+			err:   true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.responses[polls]))
+				polls++
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{})
+			require.NoError(t, err)
+
+			status := PollingStatus{Success: "Succeeded", Pending: []string{"Running"}}
+			p := Pollable{
+				URL:           srv.URL,
+				StatusLocator: BodyLocator("provisioningState"),
+				Status:        status,
+				Conditions: []PollCondition{
+					{StatusLocator: BodyLocator("operationState"), Status: status},
+				},
+			}
+			err = p.PollUntilDone(context.Background(), c)
+			if tt.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.polls, polls)
+		})
+	}
+}
+
+func TestPollUntilDoneGoneAsSuccess(t *testing.T) {
+	cases := []struct {
+		name          string
+		goneAsSuccess bool
+		polls         int
+		err           bool
+	}{
+		{
+			name:          "gone as success",
+			goneAsSuccess: true,
+			polls:         3,
+		},
+		{
+			name:  "gone as failure",
+			polls: 3,
+			err:   true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			responses := []string{
+				`{"state": "Deleting"}`,
+				`{"state": "Deleting"}`,
+			}
+			var polls int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				defer func() { polls++ }()
+				if polls >= len(responses) {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(responses[polls]))
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{})
+			require.NoError(t, err)
+
+			p := Pollable{
+				URL:           srv.URL,
+				StatusLocator: BodyLocator("state"),
+				Status:        PollingStatus{Success: "Deleted", Pending: []string{"Deleting"}},
+				GoneAsSuccess: tt.goneAsSuccess,
+			}
+			err = p.PollUntilDone(context.Background(), c)
+			if tt.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.polls, polls)
+		})
+	}
+}
+
+func TestPollUntilDoneAbortIf(t *testing.T) {
+	cases := []struct {
+		name      string
+		responses []string
+		polls     int
+		err       bool
+	}{
+		{
+			name: "not aborted",
+			responses: []string{
+				`{"status": "Running", "error": {"code": ""}}`,
+				`{"status": "Succeeded"}`,
+			},
+			polls: 2,
+		},
+		{
+			name: "aborted while pending",
+			responses: []string{
+				`{"status": "Running", "error": {"code": ""}}`,
+				`{"status": "Running", "error": {"code": "QuotaExceeded"}}`,
+				`{"status": "Failed", "error": {"code": "QuotaExceeded"}}`,
+			},
+			polls: 2,
+			err:   true,
+		},
+		{
+			name: "aborted case insensitively",
+			responses: []string{
+				`{"status": "Running", "error": {"code": "quotaexceeded"}}`,
+			},
+			polls: 1,
+			err:   true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				w.Write([]byte(tt.responses[polls]))
+				polls++
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{})
+			require.NoError(t, err)
+
+			p := Pollable{
+				URL:           srv.URL,
+				StatusLocator: BodyLocator("status"),
+				Status:        PollingStatus{Success: "Succeeded", Pending: []string{"Running"}},
+				AbortIf: []PollAbortCondition{
+					{Locator: BodyLocator("error.code"), Values: []string{"QuotaExceeded", "InternalError"}},
+				},
+			}
+			err = p.PollUntilDone(context.Background(), c)
+			if tt.err {
+				require.ErrorContains(t, err, "error.code")
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.polls, polls)
+		})
+	}
+}
+
+func TestPollUntilDoneAbortIfCodePattern(t *testing.T) {
+	codes := []int{http.StatusAccepted, http.StatusServiceUnavailable, http.StatusOK}
+	var polls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(codes[polls])
+		polls++
+	}))
+	defer srv.Close()
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{})
+	require.NoError(t, err)
+
+	p := Pollable{
+		URL:           srv.URL,
+		StatusLocator: CodeLocator{},
+		Status:        PollingStatus{Success: "200", Pending: []string{"202"}},
+		AbortIf: []PollAbortCondition{
+			{Locator: CodeLocator{}, Values: []string{"5xx"}},
+		},
+	}
+	require.ErrorContains(t, p.PollUntilDone(context.Background(), c), `"503"`)
+	require.Equal(t, 2, polls)
+}
+
+func TestPollUntilDoneRetryStatusCodes(t *testing.T) {
+	transients := func(n int) []int {
+		codes := make([]int, n)
+		for i := range codes {
+			codes[i] = http.StatusServiceUnavailable
+		}
+		return codes
+	}
+
+	cases := []struct {
+		name  string
+		codes []int
+		polls int
+		err   bool
+	}{
+		{
+			name:  "recover from transient errors",
+			codes: append(transients(maxPollTransientRetries), http.StatusOK),
+			polls: maxPollTransientRetries + 1,
+		},
+		{
+			name:  "transient errors not in a row",
+			codes: append(append(append(transients(maxPollTransientRetries), http.StatusAccepted), transients(maxPollTransientRetries)...), http.StatusOK),
+			polls: 2*maxPollTransientRetries + 2,
+		},
+		{
+			name:  "too many transient errors in a row",
+			codes: transients(maxPollTransientRetries + 1),
+			polls: maxPollTransientRetries + 1,
+			err:   true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.codes[polls])
+				polls++
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{})
+			require.NoError(t, err)
+
+			p := Pollable{
+				URL:              srv.URL,
+				StatusLocator:    CodeLocator{},
+				Status:           PollingStatus{Success: "200", Pending: []string{"202"}},
+				RetryStatusCodes: []int64{http.StatusServiceUnavailable},
+			}
+			err = p.PollUntilDone(context.Background(), c)
+			if tt.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.polls, polls)
+		})
+	}
+}
+
+func TestPollUntilDoneCanceled(t *testing.T) {
+	cases := []struct {
+		name string
+		code int
+	}{
+		{
+			name: "pending",
+			code: http.StatusAccepted,
+		},
+		{
+			name: "transient error",
+			code: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.code)
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{})
+			require.NoError(t, err)
+
+			p := Pollable{
+				URL:              srv.URL,
+				StatusLocator:    CodeLocator{},
+				Status:           PollingStatus{Success: "200", Pending: []string{"202"}},
+				RetryStatusCodes: []int64{http.StatusServiceUnavailable},
+				DefaultDelay:     time.Hour,
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+			require.ErrorIs(t, p.PollUntilDone(ctx, c), context.DeadlineExceeded)
+		})
+	}
+}
@@ -12,10 +12,25 @@ import (
 )
 
 type BuildOption struct {
-	Security      SecurityOption
-	CookieEnabled bool
-	TLSConfig     tls.Config
-	Retry         *RetryOption
+	Security            SecurityOption
+	CookieEnabled       bool
+	ForceHTTP1          bool
+	DisableKeepAlives   bool
+	MaxIdleConns        *int
+	MaxConnsPerHost     *int
+	IdleConnTimeout     *time.Duration
+	ExpectContinue      *ExpectContinueOption
+	Tracing             bool
+	ValuelessEmptyQuery bool
+	RepeatedHeaders     []string
+	RawPathJoin         bool
+	HARFile             string
+	HARBodies           bool
+	CircuitBreaker      *CircuitBreakerOption
+	TLSConfig           tls.Config
+	HostCertificates    map[string][]tls.Certificate
+	Retry               *RetryOption
+	RequestSigning      *RequestSigningOption
 }
 
 type SecurityOption interface {
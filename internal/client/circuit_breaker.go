@@ -0,0 +1,105 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned (wrapped) for the requests that are short-circuited by the circuit breaker.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitBreakerOption struct {
+	// Failures is the number of consecutive failures to a host, which opens the circuit.
+	Failures int
+	// Window is the amount of time, within which the consecutive failures are counted.
+	Window time.Duration
+	// Cooldown is the amount of time the circuit remains open.
+	Cooldown time.Duration
+}
+
+type circuitState struct {
+	failures     int
+	firstFailure time.Time
+	openUntil    time.Time
+}
+
+// circuitBreaker tracks the consecutive failures (i.e. connection errors and 5xx responses) per host.
+type circuitBreaker struct {
+	opt CircuitBreakerOption
+	now func() time.Time
+
+	mu    sync.Mutex
+	hosts map[string]*circuitState
+}
+
+func newCircuitBreaker(opt CircuitBreakerOption) *circuitBreaker {
+	return &circuitBreaker{
+		opt:   opt,
+		now:   time.Now,
+		hosts: map[string]*circuitState{},
+	}
+}
+
+// allow returns an error if the circuit of the host is open.
+func (b *circuitBreaker) allow(host string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	st, ok := b.hosts[host]
+	if !ok {
+		return nil
+	}
+	if now := b.now(); now.Before(st.openUntil) {
+		return fmt.Errorf("%w for host %q, after %d consecutive failures, retry after %s", ErrCircuitOpen, host, b.opt.Failures, st.openUntil.Sub(now).Round(time.Second))
+	}
+	return nil
+}
+
+// report records the outcome of a request to the host.
+func (b *circuitBreaker) report(host string, failed bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !failed {
+		delete(b.hosts, host)
+		return
+	}
+	st, ok := b.hosts[host]
+	if !ok {
+		st = &circuitState{}
+		b.hosts[host] = st
+	}
+	now := b.now()
+	if st.failures == 0 || now.Sub(st.firstFailure) > b.opt.Window {
+		st.failures = 0
+		st.firstFailure = now
+	}
+	st.failures++
+	if st.failures >= b.opt.Failures {
+		// Once the cooldown elapses, the next failure is counted from scratch.
+		st.failures = 0
+		st.openUntil = now.Add(b.opt.Cooldown)
+	}
+}
+
+// circuitBreakerTransport short-circuits the requests to the hosts whose circuit is open with an error, without sending them.
+type circuitBreakerTransport struct {
+	http.RoundTripper
+	breaker *circuitBreaker
+}
+
+func (t circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := strings.ToLower(req.URL.Host)
+	if err := t.breaker.allow(host); err != nil {
+		return nil, err
+	}
+	resp, err := t.RoundTripper.RoundTrip(req)
+	// The cancellation of the request is not a failure of the host.
+	if err != nil && req.Context().Err() != nil {
+		return resp, err
+	}
+	t.breaker.report(host, err != nil || resp.StatusCode >= http.StatusInternalServerError)
+	return resp, err
+}
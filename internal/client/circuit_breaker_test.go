@@ -0,0 +1,63 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	var calls int
+	status := http.StatusServiceUnavailable
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{
+		CircuitBreaker: &CircuitBreakerOption{Failures: 2, Window: time.Minute, Cooldown: time.Minute},
+		Retry:          &RetryOption{StatusCodes: []int64{http.StatusServiceUnavailable}, Count: 3},
+	})
+	require.NoError(t, err)
+
+	// The circuit opens after the 2nd failed attempt, the remaining retries fail fast.
+	_, err = c.Read(context.Background(), "/foo", ReadOption{})
+	require.Error(t, err)
+	require.True(t, errors.Is(err, ErrCircuitOpen))
+	require.Equal(t, 2, calls)
+
+	_, err = c.Read(context.Background(), "/foo", ReadOption{})
+	require.True(t, errors.Is(err, ErrCircuitOpen))
+	require.Equal(t, 2, calls)
+}
+
+func TestCircuitBreakerState(t *testing.T) {
+	now := time.Now()
+	b := newCircuitBreaker(CircuitBreakerOption{Failures: 2, Window: time.Minute, Cooldown: 30 * time.Second})
+	b.now = func() time.Time { return now }
+
+	// A success in between resets the count.
+	b.report("a", true)
+	b.report("a", false)
+	b.report("a", true)
+	require.NoError(t, b.allow("a"))
+
+	// The failures out of the window are not counted.
+	now = now.Add(2 * time.Minute)
+	b.report("a", true)
+	require.NoError(t, b.allow("a"))
+
+	b.report("a", true)
+	require.ErrorIs(t, b.allow("a"), ErrCircuitOpen)
+	// The other hosts are not affected.
+	require.NoError(t, b.allow("b"))
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, b.allow("a"))
+}
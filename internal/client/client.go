@@ -2,10 +2,16 @@ package client
 
 import (
 	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/cookiejar"
 	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-resty/resty/v2"
@@ -26,6 +32,21 @@ func (q Query) Clone() Query {
 	return Query(m)
 }
 
+// Dedup removes the duplicate values of each query parameter, while keeping the order of the values.
+func (q Query) Dedup() Query {
+	nq := Query{}
+	for k, vs := range q {
+		nvs := []string{}
+		for _, v := range vs {
+			if !slices.Contains(nvs, v) {
+				nvs = append(nvs, v)
+			}
+		}
+		nq[k] = nvs
+	}
+	return nq
+}
+
 func (q Query) TakeOrSelf(ctx context.Context, v types.Map) Query {
 	if len(v.Elements()) == 0 {
 		return q
@@ -39,7 +60,15 @@ func (q Query) TakeOrSelf(ctx context.Context, v types.Map) Query {
 		}
 		nq[k] = vs
 	}
-	return nq
+	return nq.Dedup()
+}
+
+// Without removes the keys specified in v.
+func (q Query) Without(ctx context.Context, v types.Set) Query {
+	for _, k := range v.Elements() {
+		delete(q, k.(types.String).ValueString())
+	}
+	return q
 }
 
 func (q Query) ToTFValue() types.Map {
@@ -69,6 +98,18 @@ func (h Header) TakeOrSelf(ctx context.Context, v types.Map) Header {
 	return nh
 }
 
+// Without removes the keys specified in v, which are case insensitive.
+func (h Header) Without(ctx context.Context, v types.Set) Header {
+	for _, k := range v.Elements() {
+		for hk := range h {
+			if strings.EqualFold(hk, k.(types.String).ValueString()) {
+				delete(h, hk)
+			}
+		}
+	}
+	return h
+}
+
 func (h Header) ToTFValue() types.Map {
 	var result types.Map
 	tfsdk.ValueFrom(context.Background(), h, types.MapType{ElemType: types.StringType}, &result)
@@ -77,6 +118,9 @@ func (h Header) ToTFValue() types.Map {
 
 type Client struct {
 	*resty.Client
+
+	// rawBaseURL is the base URL as is, which is only set when the paths are joined with it verbatim.
+	rawBaseURL string
 }
 
 func New(ctx context.Context, baseURL string, opt *BuildOption) (*Client, error) {
@@ -84,11 +128,82 @@ func New(ctx context.Context, baseURL string, opt *BuildOption) (*Client, error)
 		opt = &BuildOption{}
 	}
 
-	transport := http.DefaultTransport.(*http.Transport)
+	transport := http.DefaultTransport.(*http.Transport).Clone()
 	transport.TLSClientConfig = &opt.TLSConfig
+	if opt.ForceHTTP1 {
+		// A non-nil empty map disables HTTP/2, see the doc of http.Transport.
+		transport.ForceAttemptHTTP2 = false
+		transport.TLSNextProto = map[string]func(authority string, c *tls.Conn) http.RoundTripper{}
+	}
+	transport.DisableKeepAlives = opt.DisableKeepAlives
+	if opt.MaxIdleConns != nil {
+		transport.MaxIdleConns = *opt.MaxIdleConns
+	}
+	if opt.MaxConnsPerHost != nil {
+		transport.MaxConnsPerHost = *opt.MaxConnsPerHost
+	}
+	if opt.IdleConnTimeout != nil {
+		transport.IdleConnTimeout = *opt.IdleConnTimeout
+	}
 	httpClient := &http.Client{
 		Transport: transport,
 	}
+	if len(opt.HostCertificates) != 0 {
+		transport.TLSClientConfig = opt.TLSConfig.Clone()
+		transport.TLSClientConfig.GetClientCertificate = clientCertificateSelector(opt.TLSConfig.Certificates, opt.HostCertificates)
+		httpClient.Transport = hostContextTransport{RoundTripper: transport}
+	}
+	if opt.ExpectContinue != nil {
+		transport.ExpectContinueTimeout = opt.ExpectContinue.Timeout
+		httpClient.Transport = expectContinueTransport{
+			RoundTripper: httpClient.Transport,
+			minBodyBytes: opt.ExpectContinue.MinBodyBytes,
+		}
+	}
+	if opt.ValuelessEmptyQuery {
+		httpClient.Transport = valuelessQueryTransport{RoundTripper: httpClient.Transport}
+	}
+	if len(opt.RepeatedHeaders) != 0 {
+		httpClient.Transport = repeatedHeaderTransport{
+			RoundTripper: httpClient.Transport,
+			names:        opt.RepeatedHeaders,
+		}
+	}
+	if opt.HARFile != "" {
+		t := harTransport{
+			RoundTripper:  httpClient.Transport,
+			recorder:      harRecorderFor(opt.HARFile),
+			bodies:        opt.HARBodies,
+			redactHeaders: slices.Clone(harSensitiveHeaders),
+		}
+		if keys, ok := opt.Security.(APIKeyAuthOption); ok {
+			for _, key := range keys {
+				switch key.In {
+				case APIKeyAuthInHeader:
+					t.redactHeaders = append(t.redactHeaders, key.Name)
+				case APIKeyAuthInQuery:
+					t.redactQueries = append(t.redactQueries, key.Name)
+				}
+			}
+		}
+		httpClient.Transport = t
+	}
+	if opt.CircuitBreaker != nil {
+		httpClient.Transport = circuitBreakerTransport{
+			RoundTripper: httpClient.Transport,
+			breaker:      newCircuitBreaker(*opt.CircuitBreaker),
+		}
+	}
+	if opt.Tracing {
+		tp, err := otlpTracerProvider(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("building the OpenTelemetry tracer provider: %v", err)
+		}
+		httpClient.Transport = tracingTransport{
+			RoundTripper: httpClient.Transport,
+			tracer:       tp.Tracer(tracerName),
+		}
+	}
 	if opt.CookieEnabled {
 		cookieJar, _ := cookiejar.New(&cookiejar.Options{PublicSuffixList: publicsuffix.List})
 		httpClient.Jar = cookieJar
@@ -107,9 +222,122 @@ func New(ctx context.Context, baseURL string, opt *BuildOption) (*Client, error)
 		}
 	}
 
+	if opt.RequestSigning != nil {
+		client.SetPreRequestHook(opt.RequestSigning.preRequestHook)
+	}
+
 	client.SetBaseURL(baseURL)
 
-	return &Client{client}, nil
+	c := &Client{Client: client}
+	if opt.RawPathJoin {
+		c.rawBaseURL = baseURL
+		// Make the relative path absolute beforehand, so that resty won't normalize the slashes in between.
+		client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+			u, err := url.Parse(req.URL)
+			if err != nil {
+				return err
+			}
+			if !u.IsAbs() {
+				req.URL = baseURL + req.URL
+			}
+			return nil
+		})
+	}
+
+	return c, nil
+}
+
+// AbsoluteURL returns the absolute URL of the path, which is joined with the base URL in the same way as the requests are sent.
+func (c *Client) AbsoluteURL(path string) (string, error) {
+	u, err := url.Parse(path)
+	if err != nil {
+		return "", err
+	}
+	if u.IsAbs() {
+		return path, nil
+	}
+	if c.rawBaseURL != "" {
+		return c.rawBaseURL + path, nil
+	}
+	path = u.String()
+	if len(path) > 0 && path[0] != '/' {
+		path = "/" + path
+	}
+	return c.BaseURL + path, nil
+}
+
+type ExpectContinueOption struct {
+	// Timeout is the amount of time to wait for the server's first response headers after sending the request headers.
+	Timeout time.Duration
+	// MinBodyBytes is the body size, above which the "Expect: 100-continue" header is sent.
+	MinBodyBytes int64
+}
+
+// valuelessQueryTransport renders the query parameters whose value is empty as valueless flags, e.g. "?force" rather than "?force=".
+type valuelessQueryTransport struct {
+	http.RoundTripper
+}
+
+func (t valuelessQueryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.RawQuery == "" {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	req = req.Clone(req.Context())
+	req.URL.RawQuery = valuelessEmptyQuery(req.URL.RawQuery)
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// valuelessEmptyQuery removes the trailing "=" of the empty valued parameters in the encoded query.
+func valuelessEmptyQuery(rawQuery string) string {
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		params[i] = strings.TrimSuffix(param, "=")
+	}
+	return strings.Join(params, "&")
+}
+
+// repeatedHeaderTransport splits the comma separated value of the named headers, and sends them as repeated header lines.
+type repeatedHeaderTransport struct {
+	http.RoundTripper
+	names []string
+}
+
+func (t repeatedHeaderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var cloned bool
+	for _, name := range t.names {
+		vs := req.Header.Values(name)
+		if len(vs) != 1 || !strings.Contains(vs[0], ",") {
+			continue
+		}
+		if !cloned {
+			req = req.Clone(req.Context())
+			cloned = true
+		}
+		req.Header.Del(name)
+		for _, v := range strings.Split(vs[0], ",") {
+			req.Header.Add(name, strings.TrimSpace(v))
+		}
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// expectContinueTransport sends the "Expect: 100-continue" header for the requests whose body is larger than minBodyBytes,
+// or whose body size is unknown.
+type expectContinueTransport struct {
+	http.RoundTripper
+	minBodyBytes int64
+}
+
+func (t expectContinueTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return t.RoundTripper.RoundTrip(req)
+	}
+	// A zero length with a non-nil body means the length is unknown, see the doc of http.Request.ContentLength.
+	if req.ContentLength <= 0 || req.ContentLength > t.minBodyBytes {
+		req = req.Clone(req.Context())
+		req.Header.Set("Expect", "100-continue")
+	}
+	return t.RoundTripper.RoundTrip(req)
 }
 
 type RetryOption struct {
@@ -117,6 +345,8 @@ type RetryOption struct {
 	Count       int
 	WaitTime    time.Duration
 	MaxWaitTime time.Duration
+	// NoConnectionErrorRetry disables retrying on the errors returned without a response (e.g. connection reset, DNS failure).
+	NoConnectionErrorRetry bool
 }
 
 func setRetry(c *resty.Client, opt RetryOption) {
@@ -135,10 +365,15 @@ func setRetry(c *resty.Client, opt RetryOption) {
 		}
 		return d, nil
 	}
+	c.RetryHooks = []resty.OnRetryFunc{recordRetryAttempt}
 	c.RetryConditions = []resty.RetryConditionFunc{
 		func(r *resty.Response, err error) bool {
 			if err != nil {
-				return true
+				// Retrying won't help until the circuit breaker cools down.
+				if errors.Is(err, ErrCircuitOpen) {
+					return false
+				}
+				return !opt.NoConnectionErrorRetry
 			}
 
 			for _, ps := range opt.StatusCodes {
@@ -152,6 +387,47 @@ func setRetry(c *resty.Client, opt RetryOption) {
 	}
 }
 
+type retryAttemptsKey struct{}
+
+// recordRetryAttempt is the retry hook that records the outcome (i.e. the status code, or "error" if there is no response)
+// of each failed attempt in the request context.
+func recordRetryAttempt(resp *resty.Response, err error) {
+	if resp == nil || resp.Request == nil {
+		return
+	}
+	outcome := "error"
+	if err == nil {
+		outcome = strconv.Itoa(resp.StatusCode())
+	}
+	ctx := resp.Request.Context()
+	outcomes, _ := ctx.Value(retryAttemptsKey{}).([]string)
+	resp.Request.SetContext(context.WithValue(ctx, retryAttemptsKey{}, append(slices.Clip(outcomes), outcome)))
+}
+
+// RetrySummary returns the number of attempts and the per-attempt outcomes of the request, e.g. " after 3 attempts (503, error, 503)",
+// or an empty string if the request is not retried.
+func RetrySummary(resp *resty.Response) string {
+	if resp == nil || resp.Request == nil || resp.Request.Attempt <= 1 {
+		return ""
+	}
+	outcomes, _ := resp.Request.Context().Value(retryAttemptsKey{}).([]string)
+	// The last attempt isn't recorded if it doesn't meet the retry condition.
+	if len(outcomes) < resp.Request.Attempt && resp.RawResponse != nil {
+		outcomes = append(slices.Clip(outcomes), strconv.Itoa(resp.StatusCode()))
+	}
+	return fmt.Sprintf(" after %d attempts (%s)", resp.Request.Attempt, strings.Join(outcomes, ", "))
+}
+
+// withRetrySummary appends the retry summary to the error, if any.
+func withRetrySummary(resp *resty.Response, err error) (*resty.Response, error) {
+	if err != nil {
+		if summary := RetrySummary(resp); summary != "" {
+			err = fmt.Errorf("%w%s", err, summary)
+		}
+	}
+	return resp, err
+}
+
 // SetLoggerContext sets the ctx to the internal resty logger, as the tflog requires the current ctx.
 // This needs to be called at the start of each CRUD function.
 func (c *Client) SetLoggerContext(ctx context.Context) {
@@ -165,18 +441,21 @@ type CreateOption struct {
 }
 
 func (c *Client) Create(ctx context.Context, path string, body string, opt CreateOption) (*resty.Response, error) {
-	req := c.R().SetContext(ctx).SetBody(body)
+	req := c.R().SetContext(ctx)
 	req.SetQueryParamsFromValues(url.Values(opt.Query))
 	req.SetHeaders(opt.Header)
-	req = req.SetHeader("Content-Type", "application/json")
+	if body != "" {
+		req = req.SetHeader("Content-Type", "application/json")
+		req.SetBody(body)
+	}
 
 	switch opt.Method {
 	case "POST":
-		return req.Post(path)
+		return withRetrySummary(req.Post(path))
 	case "PUT":
-		return req.Put(path)
+		return withRetrySummary(req.Put(path))
 	case "PATCH":
-		return req.Patch(path)
+		return withRetrySummary(req.Patch(path))
 	default:
 		return nil, fmt.Errorf("unknown create method: %s", opt.Method)
 	}
@@ -192,7 +471,7 @@ func (c *Client) Read(ctx context.Context, path string, opt ReadOption) (*resty.
 	req.SetQueryParamsFromValues(url.Values(opt.Query))
 	req.SetHeaders(opt.Header)
 
-	return req.Get(path)
+	return withRetrySummary(req.Get(path))
 }
 
 type UpdateOption struct {
@@ -203,18 +482,30 @@ type UpdateOption struct {
 }
 
 func (c *Client) Update(ctx context.Context, path string, body string, opt UpdateOption) (*resty.Response, error) {
-	req := c.R().SetContext(ctx).SetBody(body)
+	req := c.R().SetContext(ctx)
 	req.SetQueryParamsFromValues(url.Values(opt.Query))
 	req.SetHeaders(opt.Header)
-	req = req.SetHeader("Content-Type", "application/json")
+	if body != "" {
+		// The content type can be replaced by the opt.Header if defined, e.g. for servers that don't recognize
+		// the merge patch content type.
+		if req.Header.Get("Content-Type") == "" {
+			contentType := "application/json"
+			if opt.Method == "PATCH" && !opt.MergePatchDisabled {
+				// See RFC 7386.
+				contentType = "application/merge-patch+json"
+			}
+			req = req.SetHeader("Content-Type", contentType)
+		}
+		req.SetBody(body)
+	}
 
 	switch opt.Method {
 	case "PATCH":
-		return req.Patch(path)
+		return withRetrySummary(req.Patch(path))
 	case "PUT":
-		return req.Put(path)
+		return withRetrySummary(req.Put(path))
 	case "POST":
-		return req.Post(path)
+		return withRetrySummary(req.Post(path))
 	default:
 		return nil, fmt.Errorf("unknown update method: %s", opt.Method)
 	}
@@ -237,13 +528,13 @@ func (c *Client) Delete(ctx context.Context, path string, body string, opt Delet
 
 	switch opt.Method {
 	case "POST":
-		return req.Post(path)
+		return withRetrySummary(req.Post(path))
 	case "PATCH":
-		return req.Patch(path)
+		return withRetrySummary(req.Patch(path))
 	case "PUT":
-		return req.Put(path)
+		return withRetrySummary(req.Put(path))
 	case "DELETE":
-		return req.Delete(path)
+		return withRetrySummary(req.Delete(path))
 	default:
 		return nil, fmt.Errorf("unknown delete method: %s", opt.Method)
 	}
@@ -253,18 +544,25 @@ type OperationOption struct {
 	Method string
 	Query  Query
 	Header Header
+	// RawBody is sent as is instead of the body, if not empty.
+	RawBody string
 }
 
 func (c *Client) Operation(ctx context.Context, path string, body basetypes.DynamicValue, opt OperationOption) (*resty.Response, error) {
 	req := c.R().SetContext(ctx)
 	req.SetQueryParamsFromValues(url.Values(opt.Query))
 
-	// By default set the content-type to application/json
-	// This can be replaced by the opt.Header if defined.
-	req = req.SetHeader("Content-Type", "application/json")
 	req.SetHeaders(opt.Header)
 
-	if !body.IsNull() {
+	// No payload is sent if the body is null, while an empty object is sent as is.
+	if opt.RawBody != "" {
+		req.SetBody(opt.RawBody)
+	} else if !body.IsNull() {
+		// By default set the content-type to application/json
+		// This can be replaced by the opt.Header if defined.
+		if req.Header.Get("Content-Type") == "" {
+			req = req.SetHeader("Content-Type", "application/json")
+		}
 		switch req.Header.Get("Content-Type") {
 		case "application/json":
 			b, err := dynamic.ToJSON(body)
@@ -292,17 +590,76 @@ func (c *Client) Operation(ctx context.Context, path string, body basetypes.Dyna
 
 	switch opt.Method {
 	case "GET":
-		return req.Get(path)
+		return withRetrySummary(req.Get(path))
 	case "POST":
-		return req.Post(path)
+		return withRetrySummary(req.Post(path))
 	case "PUT":
-		return req.Put(path)
+		return withRetrySummary(req.Put(path))
 	case "PATCH":
-		return req.Patch(path)
+		return withRetrySummary(req.Patch(path))
 	case "DELETE":
-		return req.Delete(path)
+		return withRetrySummary(req.Delete(path))
 	default:
-		return nil, fmt.Errorf("unknown create method: %s", opt.Method)
+		// The non-standard methods, e.g. the WebDAV `PROPFIND`.
+		return withRetrySummary(req.Execute(opt.Method, path))
+	}
+}
+
+// OperationWithFile is similar to Operation, while the content of the file is sent as the raw request body, with the specified content type.
+// The content is read into memory (resty buffers the reader body anyway), so that it is resent on retries and can be read for signing.
+func (c *Client) OperationWithFile(ctx context.Context, path string, file string, contentType string, opt OperationOption) (*resty.Response, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("reading the body file: %v", err)
+	}
+
+	req := c.R().SetContext(ctx)
+	req.SetQueryParamsFromValues(url.Values(opt.Query))
+	req.SetHeaders(opt.Header)
+	req.SetHeader("Content-Type", contentType)
+	req.SetBody(b)
+
+	return withRetrySummary(req.Execute(opt.Method, path))
+}
+
+// OperationWithRetry is similar to Operation, while it retries the operation on error or the specified status codes of the retry option,
+// in capped exponential backoff. The `Retry-After` in the response header takes higher precedence than the backoff.
+// This is on top of the retry of the client, if any.
+func (c *Client) OperationWithRetry(ctx context.Context, path string, body basetypes.DynamicValue, opt OperationOption, retry *RetryOption) (*resty.Response, error) {
+	if retry == nil {
+		return c.Operation(ctx, path, body, opt)
+	}
+	wait := retry.WaitTime
+	for attempt := 0; ; attempt++ {
+		resp, err := c.Operation(ctx, path, body, opt)
+		if attempt >= retry.Count || (err != nil && retry.NoConnectionErrorRetry) {
+			return resp, err
+		}
+		if err == nil && !slices.Contains(retry.StatusCodes, int64(resp.StatusCode())) {
+			return resp, nil
+		}
+
+		d := wait
+		if err == nil {
+			if dur := resp.Header().Get("Retry-After"); dur != "" {
+				if rd, perr := time.ParseDuration(dur + "s"); perr == nil {
+					d = rd
+				}
+			}
+		}
+		select {
+		case <-ctx.Done():
+			if err != nil {
+				return resp, err
+			}
+			return resp, ctx.Err()
+		case <-time.After(d):
+		}
+
+		wait *= 2
+		if retry.MaxWaitTime != 0 && wait > retry.MaxWaitTime {
+			wait = retry.MaxWaitTime
+		}
 	}
 }
 
@@ -313,18 +670,22 @@ type ReadOptionDS struct {
 	Header Header
 }
 
-func (c *Client) ReadDS(ctx context.Context, path string, opt ReadOptionDS) (*resty.Response, error) {
+func (c *Client) ReadDS(ctx context.Context, path string, body string, opt ReadOptionDS) (*resty.Response, error) {
 	req := c.R().SetContext(ctx)
+	if body != "" {
+		req = req.SetHeader("Content-Type", "application/json")
+		req.SetBody(body)
+	}
 	req.SetQueryParamsFromValues(url.Values(opt.Query))
 	req.SetHeaders(opt.Header)
 
 	switch opt.Method {
 	case "", "GET":
-		return req.Get(path)
+		return withRetrySummary(req.Get(path))
 	case "POST":
-		return req.Post(path)
+		return withRetrySummary(req.Post(path))
 	case "HEAD":
-		return req.Head(path)
+		return withRetrySummary(req.Head(path))
 	default:
 		return nil, fmt.Errorf("unknown read (ds) method: %s", opt.Method)
 	}
@@ -0,0 +1,758 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryTakeOrSelf(t *testing.T) {
+	mustQueryMap := func(m map[string][]string) types.Map {
+		elems := map[string]attr.Value{}
+		for k, vs := range m {
+			var l []attr.Value
+			for _, v := range vs {
+				l = append(l, types.StringValue(v))
+			}
+			elems[k] = types.ListValueMust(types.StringType, l)
+		}
+		return types.MapValueMust(types.ListType{ElemType: types.StringType}, elems)
+	}
+
+	cases := []struct {
+		name   string
+		base   Query
+		input  types.Map
+		expect string
+	}{
+		{
+			name:   "empty input takes self",
+			base:   Query{"tag": []string{"b", "a"}},
+			input:  types.MapNull(types.ListType{ElemType: types.StringType}),
+			expect: "tag=b&tag=a",
+		},
+		{
+			name:   "values keep the config order",
+			base:   Query{"foo": []string{"bar"}},
+			input:  mustQueryMap(map[string][]string{"tag": {"b", "a", "c"}, "api-version": {"1"}}),
+			expect: "api-version=1&tag=b&tag=a&tag=c",
+		},
+		{
+			name:   "duplicate values are removed",
+			input:  mustQueryMap(map[string][]string{"tag": {"a", "b", "a", "b"}}),
+			expect: "tag=a&tag=b",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// Repeat to ensure the result is stable
+			for i := 0; i < 10; i++ {
+				actual := tt.base.Clone().TakeOrSelf(context.Background(), tt.input)
+				require.Equal(t, tt.expect, url.Values(actual).Encode())
+			}
+		})
+	}
+}
+
+func TestWithout(t *testing.T) {
+	keys := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("x-tenant"), types.StringValue("api-version")})
+
+	h := Header{"X-Tenant": "foo", "Accept": "application/json"}.Without(context.Background(), keys)
+	require.Equal(t, Header{"Accept": "application/json"}, h)
+
+	q := Query{"api-version": {"1"}, "tag": {"a"}}.Without(context.Background(), keys)
+	require.Equal(t, Query{"tag": {"a"}}, q)
+
+	h = Header{"X-Tenant": "foo"}.Without(context.Background(), types.SetNull(types.StringType))
+	require.Equal(t, Header{"X-Tenant": "foo"}, h)
+}
+
+func TestOperationBody(t *testing.T) {
+	cases := []struct {
+		name              string
+		body              types.Dynamic
+		rawBody           string
+		header            Header
+		expectBody        string
+		expectContentType string
+	}{
+		{
+			name:              "null body",
+			body:              types.DynamicNull(),
+			expectBody:        "",
+			expectContentType: "",
+		},
+		{
+			name:              "empty object",
+			body:              types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{}, map[string]attr.Value{})),
+			expectBody:        "{}",
+			expectContentType: "application/json",
+		},
+		{
+			name:              "form",
+			body:              types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{"grant_type": types.StringType}, map[string]attr.Value{"grant_type": types.StringValue("client_credentials")})),
+			header:            Header{"Content-Type": "application/x-www-form-urlencoded"},
+			expectBody:        "grant_type=client_credentials",
+			expectContentType: "application/x-www-form-urlencoded",
+		},
+		{
+			name:              "raw body",
+			body:              types.DynamicNull(),
+			rawBody:           "grant_type=client_credentials&scope=a+b",
+			header:            Header{"Content-Type": "application/x-www-form-urlencoded"},
+			expectBody:        "grant_type=client_credentials&scope=a+b",
+			expectContentType: "application/x-www-form-urlencoded",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var body, contentType string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				body = string(b)
+				contentType = r.Header.Get("Content-Type")
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{})
+			require.NoError(t, err)
+
+			_, err = c.Operation(context.Background(), "/foo", tt.body, OperationOption{Method: "PUT", Header: tt.header, RawBody: tt.rawBody})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectBody, body)
+			require.Equal(t, tt.expectContentType, contentType)
+		})
+	}
+}
+
+func TestUpdateContentType(t *testing.T) {
+	cases := []struct {
+		name              string
+		opt               UpdateOption
+		expectContentType string
+	}{
+		{
+			name:              "put",
+			opt:               UpdateOption{Method: "PUT"},
+			expectContentType: "application/json",
+		},
+		{
+			name:              "merge patch",
+			opt:               UpdateOption{Method: "PATCH"},
+			expectContentType: "application/merge-patch+json",
+		},
+		{
+			name:              "merge patch disabled",
+			opt:               UpdateOption{Method: "PATCH", MergePatchDisabled: true},
+			expectContentType: "application/json",
+		},
+		{
+			name:              "overridden by header",
+			opt:               UpdateOption{Method: "PATCH", Header: Header{"content-type": "application/json"}},
+			expectContentType: "application/json",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var contentType string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				contentType = r.Header.Get("Content-Type")
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{})
+			require.NoError(t, err)
+
+			_, err = c.Update(context.Background(), "/foo", `{"a":1}`, tt.opt)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectContentType, contentType)
+		})
+	}
+}
+
+func TestOperationWithRetry(t *testing.T) {
+	var count int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		if count < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer srv.Close()
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{})
+	require.NoError(t, err)
+
+	resp, err := c.OperationWithRetry(context.Background(), "/foo", types.DynamicNull(), OperationOption{Method: "POST"}, &RetryOption{
+		StatusCodes: []int64{http.StatusServiceUnavailable},
+		Count:       1,
+		WaitTime:    time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, resp.StatusCode())
+	require.Equal(t, 2, count)
+
+	count = 0
+	resp, err = c.OperationWithRetry(context.Background(), "/foo", types.DynamicNull(), OperationOption{Method: "POST"}, &RetryOption{
+		StatusCodes: []int64{http.StatusServiceUnavailable},
+		Count:       3,
+		WaitTime:    time.Millisecond,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, 3, count)
+}
+
+func TestRetryOnConnectionError(t *testing.T) {
+	cases := []struct {
+		name          string
+		noRetry       bool
+		expectAttempt int
+	}{
+		{
+			name:          "retry on connection error",
+			expectAttempt: 3,
+		},
+		{
+			name:          "no retry on connection error",
+			noRetry:       true,
+			expectAttempt: 1,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempt int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt++
+				// Close the connection without a response.
+				conn, _, err := w.(http.Hijacker).Hijack()
+				require.NoError(t, err)
+				conn.Close()
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{
+				Retry: &RetryOption{
+					Count:                  2,
+					WaitTime:               time.Millisecond,
+					MaxWaitTime:            time.Millisecond,
+					NoConnectionErrorRetry: tt.noRetry,
+				},
+			})
+			require.NoError(t, err)
+
+			_, err = c.Read(context.Background(), "/foo", ReadOption{})
+			require.Error(t, err)
+			require.Equal(t, tt.expectAttempt, attempt)
+		})
+	}
+}
+
+func TestRetrySummary(t *testing.T) {
+	cases := []struct {
+		name     string
+		statuses []int
+		expect   string
+	}{
+		{
+			name:     "no retry",
+			statuses: []int{http.StatusOK},
+			expect:   "",
+		},
+		{
+			name:     "succeeded after retries",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK},
+			expect:   " after 3 attempts (503, 429, 200)",
+		},
+		{
+			name:     "retries exhausted",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expect:   " after 3 attempts (503, 503, 503)",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempt int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statuses[attempt])
+				attempt++
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{
+				Retry: &RetryOption{
+					StatusCodes: []int64{http.StatusServiceUnavailable, http.StatusTooManyRequests},
+					Count:       2,
+					WaitTime:    time.Millisecond,
+					MaxWaitTime: time.Millisecond,
+				},
+			})
+			require.NoError(t, err)
+
+			resp, err := c.Read(context.Background(), "/foo", ReadOption{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, RetrySummary(resp))
+		})
+	}
+}
+
+func TestRetrySummaryOnConnectionError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, _, err := w.(http.Hijacker).Hijack()
+		require.NoError(t, err)
+		conn.Close()
+	}))
+	defer srv.Close()
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{
+		Retry: &RetryOption{
+			Count:       2,
+			WaitTime:    time.Millisecond,
+			MaxWaitTime: time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = c.Read(context.Background(), "/foo", ReadOption{})
+	require.ErrorContains(t, err, " after 3 attempts (error, error, error)")
+}
+
+func TestOperationWithFile(t *testing.T) {
+	content := []byte{0x89, 'P', 'N', 'G', 0x00, 0xff}
+	file := filepath.Join(t.TempDir(), "blob")
+	require.NoError(t, os.WriteFile(file, content, 0644))
+
+	var (
+		gotBody        []byte
+		gotContentType string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotContentType = r.Header.Get("Content-Type")
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{})
+	require.NoError(t, err)
+
+	resp, err := c.OperationWithFile(context.Background(), "/blobs/foo", file, "image/png", OperationOption{Method: "PUT"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode())
+	require.Equal(t, content, gotBody)
+	require.Equal(t, "image/png", gotContentType)
+
+	_, err = c.OperationWithFile(context.Background(), "/blobs/foo", filepath.Join(t.TempDir(), "not-exist"), "image/png", OperationOption{Method: "PUT"})
+	require.Error(t, err)
+}
+
+func TestOperationWithFileRetry(t *testing.T) {
+	content := []byte("file content")
+	file := filepath.Join(t.TempDir(), "blob")
+	require.NoError(t, os.WriteFile(file, content, 0644))
+
+	var gotBodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		gotBodies = append(gotBodies, string(b))
+		if len(gotBodies) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{
+		Retry: &RetryOption{
+			StatusCodes: []int64{http.StatusServiceUnavailable},
+			Count:       3,
+			WaitTime:    time.Millisecond,
+			MaxWaitTime: time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+
+	resp, err := c.OperationWithFile(context.Background(), "/blobs/foo", file, "text/plain", OperationOption{Method: "PUT"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, resp.StatusCode())
+	require.Equal(t, []string{string(content), string(content), string(content)}, gotBodies)
+}
+
+func TestOperationCustomMethod(t *testing.T) {
+	var gotMethod string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		w.WriteHeader(http.StatusMultiStatus)
+	}))
+	defer srv.Close()
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{})
+	require.NoError(t, err)
+
+	resp, err := c.Operation(context.Background(), "/dav/foo", types.DynamicNull(), OperationOption{Method: "PROPFIND"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusMultiStatus, resp.StatusCode())
+	require.Equal(t, "PROPFIND", gotMethod)
+}
+
+func TestExpectContinue(t *testing.T) {
+	cases := []struct {
+		name         string
+		body         string
+		minBodyBytes int64
+		expect       string
+	}{
+		{
+			name:   "no body",
+			expect: "",
+		},
+		{
+			name:         "body larger than the threshold",
+			body:         `{"name": "foo"}`,
+			minBodyBytes: 10,
+			expect:       "100-continue",
+		},
+		{
+			name:         "body not larger than the threshold",
+			body:         `{"name": "foo"}`,
+			minBodyBytes: 1024,
+			expect:       "",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				gotExpect string
+				gotBody   []byte
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotExpect = r.Header.Get("Expect")
+				gotBody, _ = io.ReadAll(r.Body)
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{
+				ExpectContinue: &ExpectContinueOption{
+					Timeout:      time.Second,
+					MinBodyBytes: tt.minBodyBytes,
+				},
+			})
+			require.NoError(t, err)
+
+			_, err = c.Update(context.Background(), "/foo", tt.body, UpdateOption{Method: "PUT"})
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, gotExpect)
+			require.Equal(t, tt.body, string(gotBody))
+		})
+	}
+}
+
+func TestReadDSBody(t *testing.T) {
+	cases := []struct {
+		name              string
+		body              string
+		expectContentType string
+	}{
+		{
+			name: "no body",
+		},
+		{
+			name:              "with body",
+			body:              `{"name": "foo"}`,
+			expectContentType: "application/json",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				gotContentType string
+				gotBody        []byte
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotContentType = r.Header.Get("Content-Type")
+				gotBody, _ = io.ReadAll(r.Body)
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, nil)
+			require.NoError(t, err)
+
+			_, err = c.ReadDS(context.Background(), "/foo", tt.body, ReadOptionDS{Method: "POST"})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectContentType, gotContentType)
+			require.Equal(t, tt.body, string(gotBody))
+		})
+	}
+}
+
+func TestForceHTTP1(t *testing.T) {
+	cases := []struct {
+		name        string
+		forceHTTP1  bool
+		expectProto int
+	}{
+		{
+			name:        "default",
+			expectProto: 2,
+		},
+		{
+			name:        "force http1",
+			forceHTTP1:  true,
+			expectProto: 1,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotProto int
+			srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotProto = r.ProtoMajor
+			}))
+			srv.EnableHTTP2 = true
+			srv.StartTLS()
+			defer srv.Close()
+
+			pool := x509.NewCertPool()
+			pool.AddCert(srv.Certificate())
+			c, err := New(context.Background(), srv.URL, &BuildOption{
+				ForceHTTP1: tt.forceHTTP1,
+				TLSConfig:  tls.Config{RootCAs: pool},
+			})
+			require.NoError(t, err)
+
+			_, err = c.Read(context.Background(), "/foo", ReadOption{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectProto, gotProto)
+		})
+	}
+}
+
+func TestDisableKeepAlives(t *testing.T) {
+	cases := []struct {
+		name              string
+		disableKeepAlives bool
+	}{
+		{
+			name: "default",
+		},
+		{
+			name:              "disable keep alives",
+			disableKeepAlives: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotClose bool
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotClose = r.Close
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{DisableKeepAlives: tt.disableKeepAlives})
+			require.NoError(t, err)
+
+			_, err = c.Read(context.Background(), "/foo", ReadOption{})
+			require.NoError(t, err)
+			require.Equal(t, tt.disableKeepAlives, gotClose)
+		})
+	}
+}
+
+func TestConnectionPool(t *testing.T) {
+	def := http.DefaultTransport.(*http.Transport)
+	maxIdleConns, maxConnsPerHost, idleConnTimeout := 0, 10, 30*time.Second
+
+	cases := []struct {
+		name                  string
+		opt                   BuildOption
+		expectMaxIdleConns    int
+		expectMaxConnsPerHost int
+		expectIdleConnTimeout time.Duration
+	}{
+		{
+			name:                  "default",
+			expectMaxIdleConns:    def.MaxIdleConns,
+			expectMaxConnsPerHost: def.MaxConnsPerHost,
+			expectIdleConnTimeout: def.IdleConnTimeout,
+		},
+		{
+			name: "tuned",
+			opt: BuildOption{
+				MaxIdleConns:    &maxIdleConns,
+				MaxConnsPerHost: &maxConnsPerHost,
+				IdleConnTimeout: &idleConnTimeout,
+			},
+			expectMaxIdleConns:    0,
+			expectMaxConnsPerHost: 10,
+			expectIdleConnTimeout: 30 * time.Second,
+		},
+	}
+
+	for i := range cases {
+		tt := &cases[i]
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := New(context.Background(), "http://localhost", &tt.opt)
+			require.NoError(t, err)
+			transport := c.GetClient().Transport.(*http.Transport)
+			require.Equal(t, tt.expectMaxIdleConns, transport.MaxIdleConns)
+			require.Equal(t, tt.expectMaxConnsPerHost, transport.MaxConnsPerHost)
+			require.Equal(t, tt.expectIdleConnTimeout, transport.IdleConnTimeout)
+		})
+	}
+}
+
+func TestValuelessEmptyQuery(t *testing.T) {
+	cases := []struct {
+		name      string
+		valueless bool
+		expect    string
+	}{
+		{
+			name:   "empty value kept",
+			expect: "api-version=1&force=",
+		},
+		{
+			name:      "empty value as flag",
+			valueless: true,
+			expect:    "api-version=1&force",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotQuery string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotQuery = r.URL.RawQuery
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{ValuelessEmptyQuery: tt.valueless})
+			require.NoError(t, err)
+
+			_, err = c.Delete(context.Background(), "/foo", "", DeleteOption{
+				Method: "DELETE",
+				Query:  Query{"api-version": []string{"1"}, "force": []string{""}},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, gotQuery)
+		})
+	}
+}
+
+func TestRepeatedHeaders(t *testing.T) {
+	cases := []struct {
+		name   string
+		names  []string
+		expect []string
+	}{
+		{
+			name:   "not repeated",
+			expect: []string{"a, b"},
+		},
+		{
+			name:   "repeated",
+			names:  []string{"X-Forwarded-For"},
+			expect: []string{"a", "b"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotHeader []string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotHeader = r.Header.Values("X-Forwarded-For")
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL, &BuildOption{RepeatedHeaders: tt.names})
+			require.NoError(t, err)
+
+			_, err = c.Read(context.Background(), "/foo", ReadOption{
+				Header: Header{"X-Forwarded-For": "a, b"},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, gotHeader)
+		})
+	}
+}
+
+func TestRawPathJoin(t *testing.T) {
+	cases := []struct {
+		name   string
+		base   string
+		path   string
+		raw    bool
+		expect string
+	}{
+		{
+			name:   "smart with double slashes",
+			base:   "/api/",
+			path:   "/foos",
+			expect: "/api/foos",
+		},
+		{
+			name:   "raw with double slashes",
+			base:   "/api/",
+			path:   "/foos",
+			raw:    true,
+			expect: "/api//foos",
+		},
+		{
+			name:   "smart with trailing slash",
+			base:   "/api/foos/",
+			path:   "",
+			expect: "/api/foos",
+		},
+		{
+			name:   "raw with trailing slash",
+			base:   "/api/foos/",
+			path:   "",
+			raw:    true,
+			expect: "/api/foos/",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotPath string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+			}))
+			defer srv.Close()
+
+			c, err := New(context.Background(), srv.URL+tt.base, &BuildOption{RawPathJoin: tt.raw})
+			require.NoError(t, err)
+
+			_, err = c.Read(context.Background(), tt.path, ReadOption{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, gotPath)
+
+			u, err := c.AbsoluteURL(tt.path)
+			require.NoError(t, err)
+			require.Equal(t, srv.URL+tt.expect, u)
+		})
+	}
+}
@@ -0,0 +1,295 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const harRedacted = "REDACTED"
+
+// harSensitiveHeaders are the headers that are always redacted in the HAR file.
+var harSensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+var (
+	harRecordersMu sync.Mutex
+	harRecorders   = map[string]*harRecorder{}
+)
+
+// harRecorderFor returns the recorder of the HAR file, which is shared by all the clients writing to the same file.
+func harRecorderFor(path string) *harRecorder {
+	harRecordersMu.Lock()
+	defer harRecordersMu.Unlock()
+	if r, ok := harRecorders[path]; ok {
+		return r
+	}
+	r := &harRecorder{path: path}
+	harRecorders[path] = r
+	return r
+}
+
+// harRecorder records the API calls in the HAR (HTTP Archive) format. As there is no hook to flush the file when the
+// provider exits, the file is kept valid after each entry: the entry is written in place of the closing of the entries,
+// which is then appended again.
+type harRecorder struct {
+	mu   sync.Mutex
+	path string
+	f    *os.File
+	// off is the offset of the closing of the entries, where the next entry is written.
+	off     int64
+	entries int
+}
+
+const harClosing = "\n  ]\n}}\n"
+
+func (r *harRecorder) record(entry harEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.f == nil {
+		f, err := os.OpenFile(r.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(harCreator{Name: "terraform-provider-restful"})
+		if err != nil {
+			f.Close()
+			return err
+		}
+		header := `{"log": {"version": "1.2", "creator": ` + string(b) + `, "entries": [`
+		if _, err := f.WriteString(header + harClosing); err != nil {
+			f.Close()
+			return err
+		}
+		r.f = f
+		r.off = int64(len(header))
+	}
+
+	b, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	sep := "\n    "
+	if r.entries != 0 {
+		sep = "," + sep
+	}
+	chunk := sep + string(b)
+	if _, err := r.f.WriteAt([]byte(chunk+harClosing), r.off); err != nil {
+		return err
+	}
+	r.off += int64(len(chunk))
+	r.entries++
+	return nil
+}
+
+type harFile struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+}
+
+type harRequest struct {
+	Method      string       `json:"method"`
+	URL         string       `json:"url"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []harNameVal `json:"cookies"`
+	Headers     []harNameVal `json:"headers"`
+	QueryString []harNameVal `json:"queryString"`
+	PostData    *harPostData `json:"postData,omitempty"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int          `json:"status"`
+	StatusText  string       `json:"statusText"`
+	HTTPVersion string       `json:"httpVersion"`
+	Cookies     []harNameVal `json:"cookies"`
+	Headers     []harNameVal `json:"headers"`
+	Content     harContent   `json:"content"`
+	RedirectURL string       `json:"redirectURL"`
+	HeadersSize int          `json:"headersSize"`
+	BodySize    int          `json:"bodySize"`
+	Comment     string       `json:"comment,omitempty"`
+}
+
+type harNameVal struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// harTransport records each HTTP request (including each retry) and its response to the HAR file, with the sensitive
+// headers and query parameters redacted. The bodies are redacted as well, unless bodies is set, as they can carry the
+// secrets, e.g. the login requests and the tokens.
+type harTransport struct {
+	http.RoundTripper
+	recorder *harRecorder
+	bodies   bool
+	// redactHeaders and redactQueries are the names of the headers and query parameters to redact, e.g. the API keys.
+	redactHeaders []string
+	redactQueries []string
+}
+
+func (t harTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			reqBody, _ = io.ReadAll(body)
+			body.Close()
+		}
+	}
+
+	start := time.Now()
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		t.record(req, reqBody, start, nil, nil, err)
+		return resp, err
+	}
+
+	respBody, rerr := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+	t.record(req, reqBody, start, resp, respBody, rerr)
+	return resp, nil
+}
+
+func (t harTransport) record(req *http.Request, reqBody []byte, start time.Time, resp *http.Response, respBody []byte, err error) {
+	elapsed := float64(time.Since(start).Microseconds()) / 1000
+
+	u := *req.URL
+	query := u.Query()
+	for _, name := range t.redactQueries {
+		if query.Has(name) {
+			query.Set(name, harRedacted)
+		}
+	}
+	u.RawQuery = query.Encode()
+
+	entry := harEntry{
+		StartedDateTime: start.Format(time.RFC3339Nano),
+		Time:            elapsed,
+		Request: harRequest{
+			Method:      req.Method,
+			URL:         u.String(),
+			HTTPVersion: req.Proto,
+			Cookies:     []harNameVal{},
+			Headers:     t.headers(req.Header),
+			QueryString: harQueryString(query),
+			HeadersSize: -1,
+			BodySize:    len(reqBody),
+		},
+		Response: harResponse{
+			Cookies:     []harNameVal{},
+			Headers:     []harNameVal{},
+			HeadersSize: -1,
+			BodySize:    -1,
+		},
+		Timings: harTimings{Wait: elapsed},
+	}
+	if req.GetBody != nil {
+		entry.Request.PostData = &harPostData{
+			MimeType: req.Header.Get("Content-Type"),
+			Text:     t.body(reqBody),
+		}
+	}
+	if resp != nil {
+		entry.Response.Status = resp.StatusCode
+		entry.Response.StatusText = http.StatusText(resp.StatusCode)
+		entry.Response.HTTPVersion = resp.Proto
+		entry.Response.Headers = t.headers(resp.Header)
+		entry.Response.Content = harContent{
+			Size:     len(respBody),
+			MimeType: resp.Header.Get("Content-Type"),
+			Text:     t.body(respBody),
+		}
+		entry.Response.BodySize = len(respBody)
+	}
+	if err != nil {
+		entry.Response.Comment = err.Error()
+	}
+
+	// Recording is best effort, it shall never fail the API call.
+	_ = t.recorder.record(entry)
+}
+
+func (t harTransport) body(b []byte) string {
+	if !t.bodies && len(b) != 0 {
+		return harRedacted
+	}
+	return string(b)
+}
+
+func (t harTransport) headers(header http.Header) []harNameVal {
+	out := []harNameVal{}
+	for _, name := range sortedKeys(header) {
+		redacted := slices.ContainsFunc(t.redactHeaders, func(s string) bool { return strings.EqualFold(name, s) })
+		for _, v := range header[name] {
+			if redacted {
+				v = harRedacted
+			}
+			out = append(out, harNameVal{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+func harQueryString(query url.Values) []harNameVal {
+	out := []harNameVal{}
+	for _, name := range sortedKeys(query) {
+		for _, v := range query[name] {
+			out = append(out, harNameVal{Name: name, Value: v})
+		}
+	}
+	return out
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
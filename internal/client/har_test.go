@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHARFile(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"1"}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "calls.har")
+	c, err := New(context.Background(), srv.URL, &BuildOption{
+		HARFile:   path,
+		HARBodies: true,
+		Security: APIKeyAuthOption{
+			{Name: "X-Api-Key", In: APIKeyAuthInHeader, Value: "secret"},
+			{Name: "key", In: APIKeyAuthInQuery, Value: "secret"},
+		},
+	})
+	require.NoError(t, err)
+
+	resp, err := c.Create(context.Background(), "/foos", `{"name":"foo"}`, CreateOption{
+		Method: "POST",
+		Query:  Query{"api-version": []string{"1"}},
+		Header: Header{"Authorization": "Bearer secret"},
+	})
+	require.NoError(t, err)
+	// The response body is still readable after being recorded.
+	require.Equal(t, `{"id":"1"}`, string(resp.Body()))
+
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(b), "secret")
+
+	var har harFile
+	require.NoError(t, json.Unmarshal(b, &har))
+	require.Len(t, har.Log.Entries, 1)
+	entry := har.Log.Entries[0]
+	require.Equal(t, "POST", entry.Request.Method)
+	require.Equal(t, srv.URL+"/foos?api-version=1&key=REDACTED", entry.Request.URL)
+	require.Contains(t, entry.Request.Headers, harNameVal{Name: "Authorization", Value: harRedacted})
+	require.Contains(t, entry.Request.Headers, harNameVal{Name: "X-Api-Key", Value: harRedacted})
+	require.JSONEq(t, `{"name":"foo"}`, entry.Request.PostData.Text)
+	require.Equal(t, http.StatusCreated, entry.Response.Status)
+	require.Contains(t, entry.Response.Headers, harNameVal{Name: "Set-Cookie", Value: harRedacted})
+	require.Equal(t, `{"id":"1"}`, entry.Response.Content.Text)
+}
+
+func TestHARFileRedactBodies(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"token":"secret"}`))
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "calls.har")
+	c, err := New(context.Background(), srv.URL, &BuildOption{HARFile: path})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		_, err = c.Create(context.Background(), "/login", `{"password":"secret"}`, CreateOption{Method: "POST"})
+		require.NoError(t, err)
+
+		// The file is valid after each entry.
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.NotContains(t, string(b), "secret")
+
+		var har harFile
+		require.NoError(t, json.Unmarshal(b, &har))
+		require.Equal(t, "1.2", har.Log.Version)
+		require.Equal(t, "terraform-provider-restful", har.Log.Creator.Name)
+		require.Len(t, har.Log.Entries, i+1)
+		entry := har.Log.Entries[i]
+		require.Equal(t, harRedacted, entry.Request.PostData.Text)
+		require.Equal(t, harRedacted, entry.Response.Content.Text)
+		require.Equal(t, len(`{"token":"secret"}`), entry.Response.Content.Size)
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"strings"
+)
+
+type hostContextKey struct{}
+
+// hostContextTransport records the host of the request in the request context, which is then passed to the TLS
+// handshake, so that the client certificate can be selected by host.
+type hostContextTransport struct {
+	http.RoundTripper
+}
+
+func (t hostContextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := context.WithValue(req.Context(), hostContextKey{}, strings.ToLower(req.URL.Hostname()))
+	return t.RoundTripper.RoundTrip(req.WithContext(ctx))
+}
+
+// clientCertificateSelector returns the callback of tls.Config.GetClientCertificate, which presents the certificates
+// associated with the (lower cased) host of the request in the first place, then falls back to the certificates that are not bound
+// to any host. Among the candidates, the first one that is supported by the server's certificate request is chosen,
+// or the first one if none is supported, in the same way as tls.Config.Certificates.
+func clientCertificateSelector(certs []tls.Certificate, hostCerts map[string][]tls.Certificate) func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+	return func(info *tls.CertificateRequestInfo) (*tls.Certificate, error) {
+		candidates := certs
+		if host, ok := info.Context().Value(hostContextKey{}).(string); ok {
+			if hcerts, ok := hostCerts[host]; ok {
+				candidates = hcerts
+			}
+		}
+		if len(candidates) == 0 {
+			// No certificate is sent to the server.
+			return &tls.Certificate{}, nil
+		}
+		for i := range candidates {
+			if err := info.SupportsCertificate(&candidates[i]); err == nil {
+				return &candidates[i], nil
+			}
+		}
+		return &candidates[0], nil
+	}
+}
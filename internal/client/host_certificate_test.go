@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestHostCertificates(t *testing.T) {
+	newCert := func(cn string) tls.Certificate {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		tpl := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: cn},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, tpl, tpl, &key.PublicKey, key)
+		require.NoError(t, err)
+		return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) == 0 {
+			w.Write([]byte("none"))
+			return
+		}
+		w.Write([]byte(r.TLS.PeerCertificates[0].Subject.CommonName))
+	}))
+	srv.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	srv.StartTLS()
+	defer srv.Close()
+
+	ipURL := srv.URL
+	hostURL := strings.Replace(srv.URL, "127.0.0.1", "localhost", 1)
+
+	cases := []struct {
+		name       string
+		certs      []tls.Certificate
+		hostCerts  map[string][]tls.Certificate
+		expectIP   string
+		expectHost string
+	}{
+		{
+			name:       "host certificate with default certificate",
+			certs:      []tls.Certificate{newCert("default")},
+			hostCerts:  map[string][]tls.Certificate{"localhost": {newCert("localhost")}},
+			expectIP:   "default",
+			expectHost: "localhost",
+		},
+		{
+			name:       "host certificate only",
+			hostCerts:  map[string][]tls.Certificate{"localhost": {newCert("localhost")}},
+			expectIP:   "none",
+			expectHost: "localhost",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			opt := &BuildOption{
+				TLSConfig: tls.Config{
+					InsecureSkipVerify: true,
+					Certificates:       tt.certs,
+				},
+				HostCertificates: tt.hostCerts,
+			}
+			c, err := New(context.Background(), ipURL, opt)
+			require.NoError(t, err)
+
+			resp, err := c.Read(context.Background(), ipURL+"/foo", ReadOption{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectIP, string(resp.Body()))
+
+			resp, err = c.Read(context.Background(), hostURL+"/foo", ReadOption{})
+			require.NoError(t, err)
+			require.Equal(t, tt.expectHost, string(resp.Body()))
+		})
+	}
+}
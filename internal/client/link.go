@@ -0,0 +1,49 @@
+package client
+
+import "strings"
+
+// ParseLinkHeader parses the value of the `Link` header defined in RFC 5988, returns the target URLs keyed by the (lower cased) relation types.
+// For a relation type that appears multiple times, the first one wins.
+func ParseLinkHeader(v string) map[string]string {
+	out := map[string]string{}
+	for {
+		start := strings.IndexByte(v, '<')
+		if start == -1 {
+			return out
+		}
+		end := strings.IndexByte(v[start:], '>')
+		if end == -1 {
+			return out
+		}
+		target := v[start+1 : start+end]
+		v = v[start+end+1:]
+
+		// The params last until the next link, which starts with a "<" outside of the quotes.
+		var inQuote bool
+		i := 0
+		for ; i < len(v); i++ {
+			if v[i] == '"' {
+				inQuote = !inQuote
+			}
+			if v[i] == '<' && !inQuote {
+				break
+			}
+		}
+		params := strings.TrimSuffix(strings.TrimSpace(v[:i]), ",")
+		v = v[i:]
+
+		for _, param := range strings.Split(params, ";") {
+			k, pv, ok := strings.Cut(param, "=")
+			if !ok || !strings.EqualFold(strings.TrimSpace(k), "rel") {
+				continue
+			}
+			pv = strings.Trim(strings.TrimSpace(pv), `"`)
+			for _, rel := range strings.Fields(pv) {
+				rel = strings.ToLower(rel)
+				if _, ok := out[rel]; !ok {
+					out[rel] = target
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect map[string]string
+	}{
+		{
+			name:   "empty",
+			input:  "",
+			expect: map[string]string{},
+		},
+		{
+			name:  "single",
+			input: `<https://api.example.com/items?page=2>; rel="next"`,
+			expect: map[string]string{
+				"next": "https://api.example.com/items?page=2",
+			},
+		},
+		{
+			name:  "multiple",
+			input: `<https://api.example.com/items?page=2>; rel="next", <https://api.example.com/items?page=5>; rel="last"`,
+			expect: map[string]string{
+				"next": "https://api.example.com/items?page=2",
+				"last": "https://api.example.com/items?page=5",
+			},
+		},
+		{
+			name:  "multiple relation types and extra params",
+			input: `<https://api.example.com/items?a=1,2>; title="a, <b>; c"; REL="Next Last", </items?page=1>;rel=first`,
+			expect: map[string]string{
+				"next":  "https://api.example.com/items?a=1,2",
+				"last":  "https://api.example.com/items?a=1,2",
+				"first": "/items?page=1",
+			},
+		},
+		{
+			name:  "first wins",
+			input: `</a>; rel="next", </b>; rel="next"`,
+			expect: map[string]string{
+				"next": "/a",
+			},
+		},
+		{
+			name:   "no rel",
+			input:  `</a>; title="a"`,
+			expect: map[string]string{},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, ParseLinkHeader(tt.input))
+		})
+	}
+}
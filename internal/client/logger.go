@@ -14,8 +14,29 @@ type tflogger struct {
 
 var _ resty.Logger = tflogger{}
 
+type logLevelKey struct{}
+
+// WithLogLevel returns a context, with which the debug logs of the client (e.g. the request and response dumps) are
+// emitted at the specified level instead. The level is one of "INFO", "WARN" and "ERROR", otherwise it is ignored.
+func WithLogLevel(ctx context.Context, level string) context.Context {
+	if level == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, logLevelKey{}, level)
+}
+
 func (t tflogger) Debugf(format string, v ...interface{}) {
-	tflog.Debug(t.ctx, fmt.Sprintf(format, v...))
+	msg := fmt.Sprintf(format, v...)
+	switch t.ctx.Value(logLevelKey{}) {
+	case "INFO":
+		tflog.Info(t.ctx, msg)
+	case "WARN":
+		tflog.Warn(t.ctx, msg)
+	case "ERROR":
+		tflog.Error(t.ctx, msg)
+	default:
+		tflog.Debug(t.ctx, msg)
+	}
 }
 
 func (t tflogger) Warnf(format string, v ...interface{}) {
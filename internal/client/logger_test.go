@@ -0,0 +1,47 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerWithLogLevel(t *testing.T) {
+	cases := []struct {
+		name   string
+		level  string
+		expect string
+	}{
+		{
+			name:   "default",
+			expect: "debug",
+		},
+		{
+			name:   "info",
+			level:  "INFO",
+			expect: "info",
+		},
+		{
+			name:   "error",
+			level:  "ERROR",
+			expect: "error",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			ctx := tflogtest.RootLogger(context.Background(), &buf)
+			tflogger{ctx: WithLogLevel(ctx, tt.level)}.Debugf("request %s", "dump")
+
+			entries, err := tflogtest.MultilineJSONDecode(&buf)
+			require.NoError(t, err)
+			require.Len(t, entries, 1)
+			require.Equal(t, tt.expect, entries[0]["@level"])
+			require.Equal(t, "request dump", entries[0]["@message"])
+		})
+	}
+}
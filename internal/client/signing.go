@@ -0,0 +1,84 @@
+package client
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const (
+	SigningAlgorithmHMACSHA256 = "HMAC-SHA256"
+	SigningAlgorithmHMACSHA512 = "HMAC-SHA512"
+)
+
+// RequestSigningOption configures signing each request with an HMAC signature of the request body.
+type RequestSigningOption struct {
+	// Algorithm is one of SigningAlgorithmHMACSHA256 and SigningAlgorithmHMACSHA512.
+	Algorithm string
+	Secret    string
+	// HeaderName is the name of the header that contains the hex encoded signature.
+	HeaderName string
+	// IncludeTimestamp makes the signed message to be "<timestamp>.<body>", where the timestamp is the Unix time in second,
+	// which is also sent in the TimestampHeader.
+	IncludeTimestamp bool
+	TimestampHeader  string
+}
+
+// Sign returns the hex encoded signature of the body, together with the timestamp (if included).
+func (opt RequestSigningOption) Sign(body []byte, timestamp string) (string, error) {
+	var h func() hash.Hash
+	switch opt.Algorithm {
+	case SigningAlgorithmHMACSHA256:
+		h = sha256.New
+	case SigningAlgorithmHMACSHA512:
+		h = sha512.New
+	default:
+		return "", fmt.Errorf("unknown signing algorithm %q", opt.Algorithm)
+	}
+	mac := hmac.New(h, []byte(opt.Secret))
+	if opt.IncludeTimestamp {
+		mac.Write([]byte(timestamp + "."))
+	}
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// preRequestHook signs the request after its body is finalized. It is invoked for every request, including the retries.
+// The body is read from the request itself rather than its GetBody, as resty's GetBody returns a corrupted copy on retries.
+func (opt RequestSigningOption) preRequestHook(_ *resty.Client, req *http.Request) error {
+	var body []byte
+	if req.Body != nil && req.Body != http.NoBody {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return fmt.Errorf("reading the request body for signing: %v", err)
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	var timestamp string
+	if opt.IncludeTimestamp {
+		timestamp = strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(opt.TimestampHeader, timestamp)
+	}
+	sig, err := opt.Sign(body, timestamp)
+	if err != nil {
+		return err
+	}
+	req.Header.Set(opt.HeaderName, sig)
+	return nil
+}
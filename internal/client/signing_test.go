@@ -0,0 +1,137 @@
+package client
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestSigningSign(t *testing.T) {
+	cases := []struct {
+		name      string
+		opt       RequestSigningOption
+		body      string
+		timestamp string
+		expect    string
+		err       bool
+	}{
+		{
+			name:   "sha256",
+			opt:    RequestSigningOption{Algorithm: SigningAlgorithmHMACSHA256, Secret: "key"},
+			body:   "The quick brown fox jumps over the lazy dog",
+			expect: "f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8",
+		},
+		{
+			name:   "sha512",
+			opt:    RequestSigningOption{Algorithm: SigningAlgorithmHMACSHA512, Secret: "key"},
+			body:   "The quick brown fox jumps over the lazy dog",
+			expect: "b42af09057bac1e2d41708e48a902e09b5ff7f12ab428a4fe86653c73dd248fb82f948a549f7b791a5b41915ee4d1ec3935357e4e2317250d0372afa2ebeeb3a",
+		},
+		{
+			name:      "sha256 with timestamp",
+			opt:       RequestSigningOption{Algorithm: SigningAlgorithmHMACSHA256, Secret: "key", IncludeTimestamp: true},
+			body:      "dog",
+			timestamp: "1700000000",
+			expect:    "2013f4fc8d5c3dbdcca0095bfe9fc1aec390a2e1573ed21fae3620e9f4f4cee7",
+		},
+		{
+			name: "unknown algorithm",
+			opt:  RequestSigningOption{Algorithm: "foo", Secret: "key"},
+			err:  true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := tt.opt.Sign([]byte(tt.body), tt.timestamp)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestRequestSigningHook(t *testing.T) {
+	opt := &RequestSigningOption{
+		Algorithm:        SigningAlgorithmHMACSHA256,
+		Secret:           "secret",
+		HeaderName:       "X-Signature",
+		IncludeTimestamp: true,
+		TimestampHeader:  "X-Timestamp",
+	}
+
+	var body, sig, ts string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body = string(b)
+		sig = r.Header.Get("X-Signature")
+		ts = r.Header.Get("X-Timestamp")
+	}))
+	defer srv.Close()
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{RequestSigning: opt})
+	require.NoError(t, err)
+
+	for _, v := range []string{"a", "b"} {
+		reqBody := types.DynamicValue(types.ObjectValueMust(map[string]attr.Type{"v": types.StringType}, map[string]attr.Value{"v": types.StringValue(v)}))
+		_, err = c.Operation(context.Background(), "/foo", reqBody, OperationOption{Method: "POST"})
+		require.NoError(t, err)
+		require.NotEmpty(t, ts)
+		expect, err := opt.Sign([]byte(body), ts)
+		require.NoError(t, err)
+		require.Equal(t, expect, sig)
+	}
+}
+
+func TestRequestSigningHookWithFileRetry(t *testing.T) {
+	opt := &RequestSigningOption{
+		Algorithm:  SigningAlgorithmHMACSHA256,
+		Secret:     "secret",
+		HeaderName: "X-Signature",
+	}
+
+	content := []byte("file content")
+	file := filepath.Join(t.TempDir(), "blob")
+	require.NoError(t, os.WriteFile(file, content, 0644))
+
+	var bodies, sigs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		sigs = append(sigs, r.Header.Get("X-Signature"))
+		if len(bodies) < 2 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{
+		RequestSigning: opt,
+		Retry: &RetryOption{
+			StatusCodes: []int64{http.StatusServiceUnavailable},
+			Count:       1,
+			WaitTime:    time.Millisecond,
+			MaxWaitTime: time.Millisecond,
+		},
+	})
+	require.NoError(t, err)
+
+	_, err = c.OperationWithFile(context.Background(), "/blobs/foo", file, "text/plain", OperationOption{Method: "PUT"})
+	require.NoError(t, err)
+	expect, err := opt.Sign(content, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{string(content), string(content)}, bodies)
+	require.Equal(t, []string{expect, expect}, sigs)
+}
@@ -0,0 +1,120 @@
+package client
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// StatusCodeMatcher tells whether an HTTP status code matches.
+type StatusCodeMatcher func(code int) bool
+
+// ParseStatusCodePattern parses the HTTP status code pattern, which is in one of the following forms:
+//
+//   - An exact code, e.g. `200`
+//   - A wildcard, where the trailing digits are replaced by `x`, e.g. `2xx`, `20x`
+//   - An inclusive range, e.g. `200-299`
+//   - A comparison, e.g. `>=500`, `>499`, `<=299`, `<300`
+func ParseStatusCodePattern(pattern string) (StatusCodeMatcher, error) {
+	p := strings.TrimSpace(pattern)
+
+	for _, op := range []string{">=", "<=", ">", "<"} {
+		v, ok := strings.CutPrefix(p, op)
+		if !ok {
+			continue
+		}
+		n, err := parseStatusCode(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		switch op {
+		case ">=":
+			return func(code int) bool { return code >= n }, nil
+		case "<=":
+			return func(code int) bool { return code <= n }, nil
+		case ">":
+			return func(code int) bool { return code > n }, nil
+		default:
+			return func(code int) bool { return code < n }, nil
+		}
+	}
+
+	if l, r, ok := strings.Cut(p, "-"); ok {
+		lo, err := parseStatusCode(strings.TrimSpace(l))
+		if err != nil {
+			return nil, err
+		}
+		hi, err := parseStatusCode(strings.TrimSpace(r))
+		if err != nil {
+			return nil, err
+		}
+		if lo > hi {
+			return nil, fmt.Errorf("invalid range %q: the lower bound is greater than the upper bound", pattern)
+		}
+		return func(code int) bool { return code >= lo && code <= hi }, nil
+	}
+
+	if lp := strings.ToLower(p); len(lp) == 3 && strings.HasSuffix(lp, "x") {
+		prefix := strings.TrimRight(lp, "x")
+		pad := 3 - len(prefix)
+		lo, err := parseStatusCode(prefix + strings.Repeat("0", pad))
+		if prefix == "" || err != nil {
+			return nil, fmt.Errorf("invalid wildcard %q", pattern)
+		}
+		hi := lo + int(math.Pow10(pad)) - 1
+		return func(code int) bool { return code >= lo && code <= hi }, nil
+	}
+
+	n, err := parseStatusCode(p)
+	if err != nil {
+		return nil, err
+	}
+	return func(code int) bool { return code == n }, nil
+}
+
+// MatchStatusCodePatterns tells whether the HTTP status code matches any of the patterns (see ParseStatusCodePattern).
+func MatchStatusCodePatterns(code int, patterns []string) (bool, error) {
+	for _, p := range patterns {
+		m, err := ParseStatusCodePattern(p)
+		if err != nil {
+			return false, err
+		}
+		if m(code) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func parseStatusCode(v string) (int, error) {
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not an integer", v)
+	}
+	if n < 100 || n > 599 {
+		return 0, fmt.Errorf("%d is out of the range of HTTP status codes", n)
+	}
+	return n, nil
+}
+
+// matchStatus tells whether the located status matches the sentinel. For the code locator, the sentinel can be a status code pattern
+// (see ParseStatusCodePattern), otherwise, they are compared case insensitively.
+func matchStatus(locator ValueLocator, status, sentinel string) bool {
+	// We tolerate case difference here to be pragmatic.
+	if strings.EqualFold(status, sentinel) {
+		return true
+	}
+	if _, ok := locator.(CodeLocator); !ok {
+		return false
+	}
+	code, err := strconv.Atoi(status)
+	if err != nil {
+		return false
+	}
+	m, err := ParseStatusCodePattern(sentinel)
+	if err != nil {
+		return false
+	}
+	return m(code)
+}
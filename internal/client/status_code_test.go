@@ -0,0 +1,67 @@
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStatusCodePattern(t *testing.T) {
+	cases := []struct {
+		pattern   string
+		match     []int
+		mismatch  []int
+		expectErr bool
+	}{
+		{pattern: "200", match: []int{200}, mismatch: []int{201}},
+		{pattern: "2xx", match: []int{200, 204, 299}, mismatch: []int{199, 300}},
+		{pattern: "20X", match: []int{200, 209}, mismatch: []int{210}},
+		{pattern: "200-299", match: []int{200, 250, 299}, mismatch: []int{300}},
+		{pattern: ">=500", match: []int{500, 503}, mismatch: []int{499}},
+		{pattern: ">499", match: []int{500}, mismatch: []int{499}},
+		{pattern: "<=299", match: []int{299}, mismatch: []int{300}},
+		{pattern: "<300", match: []int{299}, mismatch: []int{300}},
+		{pattern: "xxx", expectErr: true},
+		{pattern: "x00", expectErr: true},
+		{pattern: "9xx", expectErr: true},
+		{pattern: "299-200", expectErr: true},
+		{pattern: "Succeeded", expectErr: true},
+		{pattern: "2000", expectErr: true},
+	}
+	for _, tt := range cases {
+		t.Run(tt.pattern, func(t *testing.T) {
+			m, err := ParseStatusCodePattern(tt.pattern)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			for _, code := range tt.match {
+				require.True(t, m(code), code)
+			}
+			for _, code := range tt.mismatch {
+				require.False(t, m(code), code)
+			}
+		})
+	}
+}
+
+func TestMatchStatus(t *testing.T) {
+	require.True(t, matchStatus(CodeLocator{}, "204", "2xx"))
+	require.False(t, matchStatus(CodeLocator{}, "404", "2xx"))
+	require.True(t, matchStatus(BodyLocator("status"), "succeeded", "Succeeded"))
+	require.False(t, matchStatus(BodyLocator("status"), "204", "2xx"))
+}
+
+func TestMatchStatusCodePatterns(t *testing.T) {
+	ok, err := MatchStatusCodePatterns(409, []string{"2xx", "409"})
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	ok, err = MatchStatusCodePatterns(500, []string{"2xx", "400-499"})
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	_, err = MatchStatusCodePatterns(200, []string{"ok"})
+	require.Error(t, err)
+}
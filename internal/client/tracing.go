@@ -0,0 +1,79 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/magodo/terraform-provider-restful/internal/client"
+
+var (
+	tracerProviderOnce sync.Once
+	tracerProvider     *sdktrace.TracerProvider
+	tracerProviderErr  error
+)
+
+// otlpTracerProvider returns the tracer provider that exports the spans via OTLP/HTTP, which is configured by the standard
+// OTEL_EXPORTER_OTLP_* environment variables. It is shared by all the clients. The spans are exported in batches in the
+// background, which are flushed by ShutdownTracing when the provider exits.
+func otlpTracerProvider(ctx context.Context) (trace.TracerProvider, error) {
+	tracerProviderOnce.Do(func() {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			tracerProviderErr = err
+			return
+		}
+		tracerProvider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	})
+	return tracerProvider, tracerProviderErr
+}
+
+// ShutdownTracing flushes the pending spans and shuts down the tracer provider, if tracing is enabled.
+func ShutdownTracing(ctx context.Context) error {
+	if tracerProvider == nil {
+		return nil
+	}
+	return tracerProvider.Shutdown(ctx)
+}
+
+// tracingTransport starts a client span for each HTTP request (including each retry), and propagates the
+// trace context to the server via the W3C Trace Context headers.
+type tracingTransport struct {
+	http.RoundTripper
+	tracer trace.Tracer
+}
+
+func (t tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx, span := t.tracer.Start(req.Context(), req.Method+" "+req.URL.Path,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Hostname()),
+			attribute.String("url.path", req.URL.Path),
+		),
+	)
+	defer span.End()
+
+	req = req.Clone(ctx)
+	propagation.TraceContext{}.Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.RoundTripper.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,88 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingTransport(t *testing.T) {
+	var gotTraceparent string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotTraceparent = r.Header.Get("Traceparent")
+		if r.URL.Path == "/missing" {
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	c, err := New(context.Background(), srv.URL, &BuildOption{})
+	require.NoError(t, err)
+	c.GetClient().Transport = tracingTransport{
+		RoundTripper: c.GetClient().Transport,
+		tracer:       tp.Tracer(tracerName),
+	}
+
+	_, err = c.Read(context.Background(), "/foo", ReadOption{})
+	require.NoError(t, err)
+	_, err = c.Read(context.Background(), "/missing", ReadOption{})
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+
+	require.Equal(t, "GET /foo", spans[0].Name())
+	require.Contains(t, spans[0].Attributes(), attribute.String("http.request.method", "GET"))
+	require.Contains(t, spans[0].Attributes(), attribute.String("url.path", "/foo"))
+	require.Contains(t, spans[0].Attributes(), attribute.Int("http.response.status_code", http.StatusOK))
+	require.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	require.Equal(t, "GET /missing", spans[1].Name())
+	require.Contains(t, spans[1].Attributes(), attribute.Int("http.response.status_code", http.StatusNotFound))
+	require.Equal(t, codes.Error, spans[1].Status().Code)
+
+	// The trace context is propagated to the server.
+	require.Contains(t, gotTraceparent, spans[1].SpanContext().TraceID().String())
+}
+
+func TestShutdownTracing(t *testing.T) {
+	// Reset the shared tracer provider, which is built on the first use.
+	reset := func() {
+		tracerProviderOnce, tracerProvider, tracerProviderErr = sync.Once{}, nil, nil
+	}
+	reset()
+	t.Cleanup(reset)
+
+	var exports atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			exports.Add(1)
+		}
+	}))
+	defer collector.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", collector.URL)
+	c, err := New(context.Background(), srv.URL, &BuildOption{Tracing: true})
+	require.NoError(t, err)
+	_, err = c.Read(context.Background(), "/foo", ReadOption{})
+	require.NoError(t, err)
+
+	// The span is exported in batch, which is not flushed until the shutdown.
+	require.Equal(t, int32(0), exports.Load())
+	require.NoError(t, ShutdownTracing(context.Background()))
+	require.Equal(t, int32(1), exports.Load())
+}
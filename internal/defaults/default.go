@@ -6,4 +6,6 @@ const (
 	RetryWaitTime    = time.Second
 	RetryMaxWaitTime = time.Hour
 	RetryCount       = 3
+
+	PollDefaultDelay = 10 * time.Second
 )
@@ -1,6 +1,7 @@
 package dynamic
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -55,7 +56,17 @@ func attrValueToJSON(val attr.Value) ([]byte, error) {
 	case types.Float64:
 		return json.Marshal(value.ValueFloat64())
 	case types.Number:
-		v, _ := value.ValueBigFloat().Float64()
+		bf := value.ValueBigFloat()
+		// Keep the precision of the integers that can't be exactly represented by float64 (e.g. 64-bit IDs).
+		if bf.IsInt() {
+			if i, acc := bf.Int64(); acc == big.Exact {
+				return json.Marshal(i)
+			}
+			if u, acc := bf.Uint64(); acc == big.Exact {
+				return json.Marshal(u)
+			}
+		}
+		v, _ := bf.Float64()
 		return json.Marshal(v)
 	case types.List:
 		l, err := attrListToJSON(value.Elements())
@@ -159,11 +170,15 @@ func attrValueFromJSON(b []byte, typ attr.Type) (attr.Value, error) {
 		if b == nil || string(b) == "null" {
 			return types.NumberNull(), nil
 		}
-		var v float64
+		var v json.Number
 		if err := json.Unmarshal(b, &v); err != nil {
 			return nil, err
 		}
-		return types.NumberValue(big.NewFloat(v)), nil
+		f, err := numberToBigFloat(v)
+		if err != nil {
+			return nil, err
+		}
+		return types.NumberValue(f), nil
 	case basetypes.ListType:
 		if b == nil || string(b) == "null" {
 			return types.ListNull(typ.ElemType), nil
@@ -276,7 +291,7 @@ func attrValueFromJSON(b []byte, typ attr.Type) (attr.Value, error) {
 // FromJSONImplied is similar to FromJSON, while it is for typeless case.
 // In which case, the following type conversion rules are applied (Go -> TF):
 // - bool: bool
-// - float64: number
+// - number: number (integers that exceed the float64 precision are kept as is)
 // - string: string
 // - []interface{}: tuple
 // - map[string]interface{}: object
@@ -340,15 +355,21 @@ func attrValueFromJSONImplied(b []byte) (attr.Type, attr.Value, error) {
 
 	// Primitives
 	var v interface{}
-	if err := json.Unmarshal(b, &v); err != nil {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err != nil {
 		return nil, nil, fmt.Errorf("failed to unmarshal %s: %v", string(b), err)
 	}
 
 	switch v := v.(type) {
 	case bool:
 		return types.BoolType, types.BoolValue(v), nil
-	case float64:
-		return types.NumberType, types.NumberValue(big.NewFloat(v)), nil
+	case json.Number:
+		f, err := numberToBigFloat(v)
+		if err != nil {
+			return nil, nil, err
+		}
+		return types.NumberType, types.NumberValue(f), nil
 	case string:
 		return types.StringType, types.StringValue(v), nil
 	default:
@@ -356,6 +377,21 @@ func attrValueFromJSONImplied(b []byte) (attr.Type, attr.Value, error) {
 	}
 }
 
+// numberToBigFloat converts the JSON number to a big float. Integers that can't be exactly represented by float64
+// (e.g. 64-bit IDs) are converted without losing precision.
+func numberToBigFloat(n json.Number) (*big.Float, error) {
+	v, err := n.Float64()
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %s: %v", n, err)
+	}
+	if i, ok := new(big.Int).SetString(n.String(), 10); ok {
+		if f := new(big.Float).SetInt(i); f.Cmp(big.NewFloat(v)) != 0 {
+			return f, nil
+		}
+	}
+	return big.NewFloat(v), nil
+}
+
 // IsFullyKnown returns true if `val` is known. If `val` is an aggregate type,
 // IsFullyKnown only returns true if all elements and attributes are known, as
 // well.
@@ -711,6 +711,20 @@ func TestFromJSONImplied(t *testing.T) {
 				),
 			),
 		},
+		{
+			name:  "big integer",
+			input: `{"id": 1234567890123456789}`,
+			expect: types.DynamicValue(
+				types.ObjectValueMust(
+					map[string]attr.Type{
+						"id": types.NumberType,
+					},
+					map[string]attr.Value{
+						"id": types.NumberValue(new(big.Float).SetInt(big.NewInt(1234567890123456789))),
+					},
+				),
+			),
+		},
 		{
 			name:   "empty",
 			input:  ``,
@@ -726,3 +740,14 @@ func TestFromJSONImplied(t *testing.T) {
 		})
 	}
 }
+
+func TestBigIntegerRoundTrip(t *testing.T) {
+	input := `{"id":1234567890123456789,"uid":18446744073709551615,"float":1.5}`
+	d, err := FromJSONImplied([]byte(input))
+	require.NoError(t, err)
+	b, err := ToJSON(d)
+	require.NoError(t, err)
+	require.JSONEq(t, input, string(b))
+	require.Contains(t, string(b), "1234567890123456789")
+	require.Contains(t, string(b), "18446744073709551615")
+}
@@ -1,13 +1,18 @@
 package exparam
 
 import (
+	"encoding/base64"
+	"fmt"
 	"net/url"
 	"path/filepath"
 	"regexp"
+	"strings"
+
+	"github.com/tidwall/gjson"
 )
 
 var (
-	Pattern = regexp.MustCompile(`\$([\w\.]*)\(([\w.]+)\)`)
+	Pattern = regexp.MustCompile(`\$([\w\.]*)\(([\w.\-]+)\)`)
 )
 
 type FuncName string
@@ -18,6 +23,14 @@ const (
 	FuncBase     FuncName = "base"
 	FuncURLPath  FuncName = "url_path"
 	FuncTrimPath FuncName = "trim_path"
+	FuncLower    FuncName = "lower"
+	FuncUpper    FuncName = "upper"
+
+	FuncBase64Encode FuncName = "base64encode"
+	FuncBase64Decode FuncName = "base64decode"
+
+	// FuncJSON doesn't transform the value, but makes the body param lookup descend into the JSON-encoded string values along the path.
+	FuncJSON FuncName = "json"
 )
 
 type Func func(string) (string, error)
@@ -45,7 +58,92 @@ func (f FuncFactory) Build() map[FuncName]Func {
 		FuncTrimPath: func(s string) (string, error) {
 			return filepath.Rel(f.path, s)
 		},
+		FuncLower: func(s string) (string, error) {
+			return strings.ToLower(s), nil
+		},
+		FuncUpper: func(s string) (string, error) {
+			return strings.ToUpper(s), nil
+		},
+		FuncBase64Encode: func(s string) (string, error) {
+			return base64.StdEncoding.EncodeToString([]byte(s)), nil
+		},
+		FuncBase64Decode: func(s string) (string, error) {
+			b, err := base64.StdEncoding.DecodeString(s)
+			if err != nil {
+				return "", fmt.Errorf("decoding %q as base64: %v", s, err)
+			}
+			return string(b), nil
+		},
+		FuncJSON: func(s string) (string, error) {
+			return s, nil
+		},
 	}
 
 	return m
 }
+
+// hasFunc tells whether the function chain (in form of "f1.f2") contains the function.
+func hasFunc(fnames string, fname FuncName) bool {
+	for _, name := range strings.Split(fnames, ".") {
+		if FuncName(name) == fname {
+			return true
+		}
+	}
+	return false
+}
+
+// getBodyProperty gets the property at the gjson path in the body.
+// If descendJSON is true, the JSON-encoded string values along the path are parsed as JSON, so that the path can descend into them.
+func getBodyProperty(body []byte, jp string, descendJSON bool) gjson.Result {
+	if !descendJSON || jp == "@this" {
+		return gjson.GetBytes(body, jp)
+	}
+	segs := splitPath(jp)
+	var prop gjson.Result
+	for i, seg := range segs {
+		prop = gjson.GetBytes(body, seg)
+		if !prop.Exists() || i == len(segs)-1 {
+			break
+		}
+		body = []byte(prop.Raw)
+		if prop.Type == gjson.String && gjson.Valid(prop.Str) {
+			body = []byte(prop.Str)
+		}
+	}
+	return prop
+}
+
+// splitPath splits the gjson path into the segments that can be evaluated one by one.
+// It only splits on the unescaped dots and pipes that are outside of the queries, modifier arguments and multipaths.
+// A segment starting with "#" keeps the rest of the path, as it applies to each element of the array.
+func splitPath(jp string) []string {
+	var (
+		segs  []string
+		depth int
+		quote bool
+		start int
+	)
+	for i := 0; i < len(jp); i++ {
+		switch c := jp[i]; {
+		case c == '\\':
+			i++
+		case quote:
+			if c == '"' {
+				quote = false
+			}
+		case c == '"':
+			quote = true
+		case c == '(' || c == '[' || c == '{':
+			depth++
+		case c == ')' || c == ']' || c == '}':
+			depth--
+		case depth == 0 && (c == '.' || c == '|'):
+			if jp[start] == '#' {
+				return append(segs, jp[start:])
+			}
+			segs = append(segs, jp[start:i])
+			start = i + 1
+		}
+	}
+	return append(segs, jp[start:])
+}
@@ -0,0 +1,92 @@
+package exparam
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitPath(t *testing.T) {
+	cases := []struct {
+		name   string
+		path   string
+		expect []string
+	}{
+		{
+			name:   "simple path",
+			path:   "a.b.0.c",
+			expect: []string{"a", "b", "0", "c"},
+		},
+		{
+			name:   "escaped dot",
+			path:   `a\.b.c`,
+			expect: []string{`a\.b`, "c"},
+		},
+		{
+			name:   "pipe",
+			path:   "a|b",
+			expect: []string{"a", "b"},
+		},
+		{
+			name:   "array count keeps the rest of the path",
+			path:   "a.#.b.c",
+			expect: []string{"a", "#.b.c"},
+		},
+		{
+			name:   "query with dots",
+			path:   `a.#(b.c=="x.y").d`,
+			expect: []string{"a", `#(b.c=="x.y").d`},
+		},
+		{
+			name:   "modifier with arguments",
+			path:   `a.@pretty:{"indent":"."}.b`,
+			expect: []string{"a", `@pretty:{"indent":"."}`, "b"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, splitPath(tt.path))
+		})
+	}
+}
+
+func TestGetBodyPropertyDescendJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		body   string
+		path   string
+		expect string
+	}{
+		{
+			name:   "escaped dot",
+			body:   `{"a.b": "{\"c\": \"x\"}"}`,
+			path:   `a\.b.c`,
+			expect: "x",
+		},
+		{
+			name:   "array query",
+			body:   `{"a": "[{\"b\": \"x\", \"c\": 1}, {\"b\": \"y\", \"c\": 2}]"}`,
+			path:   `a.#(b=="y").c`,
+			expect: "2",
+		},
+		{
+			name:   "array count",
+			body:   `{"a": "[1, 2, 3]"}`,
+			path:   "a.#",
+			expect: "3",
+		},
+		{
+			name:   "modifier",
+			body:   `{"a": "[1, 2, 3]"}`,
+			path:   "a.@reverse.0",
+			expect: "3",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, getBodyProperty([]byte(tt.body), tt.path, true).String())
+		})
+	}
+}
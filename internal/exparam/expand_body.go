@@ -3,8 +3,6 @@ package exparam
 import (
 	"fmt"
 	"strings"
-
-	"github.com/tidwall/gjson"
 )
 
 // ExpandBody expands params of "$(body[.x.y.z])" in the expression.
@@ -26,7 +24,7 @@ func ExpandBody(expr string, body []byte) (string, error) {
 		} else {
 			return "", fmt.Errorf("invalid match: %s", match[0])
 		}
-		prop := gjson.GetBytes(body, jp)
+		prop := getBodyProperty(body, jp, hasFunc(match[1], FuncJSON))
 		if !prop.Exists() {
 			return "", fmt.Errorf("no property found at path %q in the body", jp)
 		}
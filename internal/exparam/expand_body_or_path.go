@@ -2,9 +2,8 @@ package exparam
 
 import (
 	"fmt"
+	"net/http"
 	"strings"
-
-	"github.com/tidwall/gjson"
 )
 
 // ExpandBodyOrPath expands params of either "$(path)", or "$(body.x.y.z)" in the expression.
@@ -13,7 +12,7 @@ import (
 // The form is like: $f1.f2(body.x.y.z)
 // By defaults, the "escape" is applied. Otherwise, if explicitly defined a function,
 // the "escape" won't be applied automatically, and need manually define if needed.
-func ExpandBodyOrPath(expr string, path string, body []byte) (string, error) {
+func ExpandBodyOrPath(expr string, path string, body []byte, header http.Header) (string, error) {
 	out := expr
 	ff := FuncFactory{path}.Build()
 
@@ -24,38 +23,46 @@ func ExpandBodyOrPath(expr string, path string, body []byte) (string, error) {
 			continue
 		}
 
-		var jp string
-		if match[2] == "body" {
-			jp = "@this"
-		} else if strings.HasPrefix(match[2], "body.") {
-			jp = strings.TrimPrefix(match[2], "body.")
+		var ts string
+		if strings.HasPrefix(match[2], "header.") {
+			name := strings.TrimPrefix(match[2], "header.")
+			if len(header.Values(name)) == 0 {
+				return "", fmt.Errorf("no header %q found in the response", name)
+			}
+			ts = header.Get(name)
 		} else {
-			return "", fmt.Errorf("invalid match: %s", match[0])
-		}
-		prop := gjson.GetBytes(body, jp)
-		if !prop.Exists() {
-			return "", fmt.Errorf("no property found at path %q in the body", jp)
+			var jp string
+			if match[2] == "body" {
+				jp = "@this"
+			} else if strings.HasPrefix(match[2], "body.") {
+				jp = strings.TrimPrefix(match[2], "body.")
+			} else {
+				return "", fmt.Errorf("invalid match: %s", match[0])
+			}
+			prop := getBodyProperty(body, jp, hasFunc(match[1], FuncJSON))
+			if !prop.Exists() {
+				return "", fmt.Errorf("no property found at path %q in the body", jp)
+			}
+			ts = prop.String()
 		}
-		ts := prop.String()
 
 		// Apply functions if any
-		fs := []Func{ff[FuncEscape]}
-		if fnames := match[1]; fnames != "" {
+		fnames := []FuncName{FuncEscape}
+		if match[1] != "" {
 			// If specified any function, remove the default escape function
-			fs = []Func{}
-			for _, fname := range strings.Split(fnames, ".") {
-				f, ok := ff[FuncName(fname)]
-				if !ok {
+			fnames = []FuncName{}
+			for _, fname := range strings.Split(match[1], ".") {
+				if _, ok := ff[FuncName(fname)]; !ok {
 					return "", fmt.Errorf("unknonw function %q", fname)
 				}
-				fs = append(fs, f)
+				fnames = append(fnames, FuncName(fname))
 			}
 		}
-		for i, f := range fs {
+		for i, fname := range fnames {
 			var err error
-			ts, err = f(ts)
+			ts, err = ff[fname](ts)
 			if err != nil {
-				return "", fmt.Errorf("failed to apply %d-th functions: %v", i, err)
+				return "", fmt.Errorf("failed to apply %d-th functions (%s) in %s: %v", i, fname, match[0], err)
 			}
 		}
 		out = strings.ReplaceAll(out, match[0], ts)
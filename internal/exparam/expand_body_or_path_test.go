@@ -1,6 +1,7 @@
 package exparam
 
 import (
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -12,6 +13,7 @@ func TestExpandBodyOrPath(t *testing.T) {
 		pattern string
 		path    string
 		body    string
+		header  http.Header
 		expect  string
 		err     string
 	}{
@@ -96,11 +98,65 @@ func TestExpandBodyOrPath(t *testing.T) {
 			body:    `{"id": "https://base/foo/bar/abc"}`,
 			expect:  "bar/abc",
 		},
+		{
+			name:    "Body value in mixed case, and wants to lower it",
+			pattern: "$url_path.lower(body.id)",
+			body:    `{"id": "https://base/Foo/Bar"}`,
+			expect:  "/foo/bar",
+		},
+		{
+			name:    "Body value in mixed case, and wants to upper it and escape it",
+			pattern: "$upper.escape(body.name)",
+			body:    `{"name": "a/b"}`,
+			expect:  "A%2FB",
+		},
+		{
+			name:    "Body value is a JSON string, and wants to descend into it",
+			pattern: "$json.escape(body.props.name)",
+			body:    `{"props": "{\"name\": \"a/b\"}"}`,
+			expect:  "a%2Fb",
+		},
+		{
+			name:    "Body value is base64 encoded, and wants to decode it",
+			pattern: "$base64decode.escape(body.token)",
+			body:    `{"token": "YS9i"}`,
+			expect:  "a%2Fb",
+		},
+		{
+			name:    "Body value wants to be base64 encoded",
+			pattern: "$base64encode(body.name)",
+			body:    `{"name": "a/b"}`,
+			expect:  "YS9i",
+		},
+		{
+			name:    "Body value is not valid base64",
+			pattern: "$base64decode(body.token)",
+			body:    `{"token": "!!!"}`,
+			err:     `failed to apply 0-th functions (base64decode) in $base64decode(body.token): decoding "!!!" as base64`,
+		},
+		{
+			name:    "Header value",
+			pattern: "$(header.X-Resource-Name)",
+			header:  http.Header{"X-Resource-Name": []string{"a/b"}},
+			expect:  "a%2Fb",
+		},
+		{
+			name:    "Header returns URL, and wants to only keep the path segment, and then trim the call path",
+			pattern: "$url_path.trim_path(header.Location)",
+			path:    "/foo",
+			header:  http.Header{"Location": []string{"https://base/foo/bar/abc"}},
+			expect:  "bar/abc",
+		},
+		{
+			name:    "Header doesn't exist",
+			pattern: "$(header.Location)",
+			err:     `no header "Location" found in the response`,
+		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			actual, err := ExpandBodyOrPath(tt.pattern, tt.path, []byte(tt.body))
+			actual, err := ExpandBodyOrPath(tt.pattern, tt.path, []byte(tt.body), tt.header)
 			if tt.err != "" {
 				require.ErrorContains(t, err, tt.err)
 				return
@@ -51,6 +51,42 @@ func TestExpand(t *testing.T) {
 			body:   `{"a": {"foo": 123}}`,
 			expect: `{"foo": 123}`,
 		},
+		{
+			name:   "$lower(body.a)",
+			expr:   "$lower(body.a)",
+			body:   `{"a": "ABC"}`,
+			expect: "abc",
+		},
+		{
+			name:   "$upper(body.a)",
+			expr:   "$upper(body.a)",
+			body:   `{"a": "abc"}`,
+			expect: "ABC",
+		},
+		{
+			name:   "$json(body.a.b) descends into the JSON string",
+			expr:   "$json(body.a.b)",
+			body:   `{"a": "{\"b\": \"abc\"}"}`,
+			expect: "abc",
+		},
+		{
+			name:   "$json(body.a.b.0.c) descends into the nested JSON strings",
+			expr:   "$json(body.a.b.0.c)",
+			body:   `{"a": "{\"b\": \"[{\\\"c\\\": 1}]\"}"}`,
+			expect: "1",
+		},
+		{
+			name:    "$(body.a.b) doesn't descend into the JSON string",
+			expr:    "$(body.a.b)",
+			body:    `{"a": "{\"b\": \"abc\"}"}`,
+			isError: true,
+		},
+		{
+			name:    "unknown function",
+			expr:    "$foo(body.a)",
+			body:    `{"a": "abc"}`,
+			isError: true,
+		},
 	}
 
 	for _, tt := range cases {
@@ -3,13 +3,20 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
+	"github.com/go-resty/resty/v2"
+
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/magodo/terraform-provider-restful/internal/client"
+	"github.com/magodo/terraform-provider-restful/internal/defaults"
 	"github.com/magodo/terraform-provider-restful/internal/dynamic"
+	"github.com/magodo/terraform-provider-restful/internal/exparam"
 )
 
 type apiOption struct {
@@ -18,15 +25,27 @@ type apiOption struct {
 	UpdateMethod       string
 	DeleteMethod       string
 	MergePatchDisabled bool
+	StrictReadTypes    bool
+	RawPathJoin        bool
 	Query              client.Query
 	Header             client.Header
+
+	// The default polling options of the resource, which are used when the resource doesn't specify its own.
+	DefaultPollCreate types.Object
+	DefaultPollUpdate types.Object
+	DefaultPollDelete types.Object
+
+	// The default prechecks of the resource, which are used when the resource doesn't specify its own.
+	DefaultPrecheckCreate types.List
+	DefaultPrecheckUpdate types.List
+	DefaultPrecheckDelete types.List
 }
 
 func (opt apiOption) ForResourceCreate(ctx context.Context, d resourceData) (*client.CreateOption, diag.Diagnostics) {
 	out := client.CreateOption{
 		Method: opt.CreateMethod,
-		Query:  opt.Query.Clone().TakeOrSelf(ctx, d.Query).TakeOrSelf(ctx, d.CreateQuery),
-		Header: opt.Header.Clone().TakeOrSelf(ctx, d.Header).TakeOrSelf(ctx, d.CreateHeader),
+		Query:  opt.Query.Clone().TakeOrSelf(ctx, d.Query).TakeOrSelf(ctx, d.CreateQuery).Without(ctx, d.RemoveQuery),
+		Header: opt.Header.Clone().TakeOrSelf(ctx, d.Header).TakeOrSelf(ctx, d.CreateHeader).Without(ctx, d.RemoveHeaders),
 	}
 	if !d.CreateMethod.IsUnknown() && !d.CreateMethod.IsNull() {
 		out.Method = d.CreateMethod.ValueString()
@@ -37,8 +56,8 @@ func (opt apiOption) ForResourceCreate(ctx context.Context, d resourceData) (*cl
 
 func (opt apiOption) ForResourceRead(ctx context.Context, d resourceData) (*client.ReadOption, diag.Diagnostics) {
 	out := client.ReadOption{
-		Query:  opt.Query.Clone().TakeOrSelf(ctx, d.Query).TakeOrSelf(ctx, d.ReadQuery),
-		Header: opt.Header.Clone().TakeOrSelf(ctx, d.Header).TakeOrSelf(ctx, d.ReadHeader),
+		Query:  opt.Query.Clone().TakeOrSelf(ctx, d.Query).TakeOrSelf(ctx, d.ReadQuery).Without(ctx, d.RemoveQuery),
+		Header: opt.Header.Clone().TakeOrSelf(ctx, d.Header).TakeOrSelf(ctx, d.ReadHeader).Without(ctx, d.RemoveHeaders),
 	}
 
 	return &out, nil
@@ -48,8 +67,8 @@ func (opt apiOption) ForResourceUpdate(ctx context.Context, d resourceData) (*cl
 	out := client.UpdateOption{
 		Method:             opt.UpdateMethod,
 		MergePatchDisabled: opt.MergePatchDisabled,
-		Query:              opt.Query.Clone().TakeOrSelf(ctx, d.Query).TakeOrSelf(ctx, d.UpdateQuery),
-		Header:             opt.Header.Clone().TakeOrSelf(ctx, d.Header).TakeOrSelf(ctx, d.UpdateHeader),
+		Query:              opt.Query.Clone().TakeOrSelf(ctx, d.Query).TakeOrSelf(ctx, d.UpdateQuery).Without(ctx, d.RemoveQuery),
+		Header:             opt.Header.Clone().TakeOrSelf(ctx, d.Header).TakeOrSelf(ctx, d.UpdateHeader).Without(ctx, d.RemoveHeaders),
 	}
 	if !d.UpdateMethod.IsUnknown() && !d.UpdateMethod.IsNull() {
 		out.Method = d.UpdateMethod.ValueString()
@@ -64,14 +83,36 @@ func (opt apiOption) ForResourceUpdate(ctx context.Context, d resourceData) (*cl
 func (opt apiOption) ForResourceDelete(ctx context.Context, d resourceData) (*client.DeleteOption, diag.Diagnostics) {
 	out := client.DeleteOption{
 		Method: opt.DeleteMethod,
-		Query:  opt.Query.Clone().TakeOrSelf(ctx, d.Query).TakeOrSelf(ctx, d.DeleteQuery),
-		Header: opt.Header.Clone().TakeOrSelf(ctx, d.Header).TakeOrSelf(ctx, d.DeleteHeader),
+		Query:  opt.Query.Clone().TakeOrSelf(ctx, d.Query).TakeOrSelf(ctx, d.DeleteQuery).Without(ctx, d.RemoveQuery),
+		Header: opt.Header.Clone().TakeOrSelf(ctx, d.Header).TakeOrSelf(ctx, d.DeleteHeader).Without(ctx, d.RemoveHeaders),
 	}
 
 	if !d.DeleteMethod.IsUnknown() && !d.DeleteMethod.IsNull() {
 		out.Method = d.DeleteMethod.ValueString()
 	}
 
+	// The `delete_query` can reference the properties of the `output`, e.g. a version token that is only known after creation.
+	if len(d.DeleteQuery.Elements()) != 0 {
+		var diags diag.Diagnostics
+		output, err := dynamic.ToJSON(d.Output)
+		if err != nil {
+			diags.AddError("Failed to marshal json for `output`", err.Error())
+			return nil, diags
+		}
+		for k, vs := range out.Query {
+			for i, v := range vs {
+				vs[i], err = exparam.ExpandBodyOrPath(v, d.Path.ValueString(), output, nil)
+				if err != nil {
+					diags.AddError(
+						"Failed to build the `delete_query`",
+						fmt.Sprintf("Can't expand the value %q of query parameter %q: %v", v, k, err),
+					)
+					return nil, diags
+				}
+			}
+		}
+	}
+
 	return &out, nil
 }
 
@@ -131,6 +172,68 @@ func (opt apiOption) ForPoll(ctx context.Context, defaultHeader client.Header, d
 		header = header.Clone().TakeOrSelf(ctx, d.Header)
 	}
 
+	var retryStatusCodes []int64
+	if !d.RetryStatusCodes.IsNull() {
+		if d := d.RetryStatusCodes.ElementsAs(ctx, &retryStatusCodes, false); d.HasError() {
+			diags.Append(d...)
+			return nil, diags
+		}
+	}
+
+	// The `default_delay_sec` can be absent in the provider level default polling options.
+	defaultDelay := defaults.PollDefaultDelay
+	if !d.DefaultDelay.IsNull() {
+		defaultDelay = time.Duration(d.DefaultDelay.ValueInt64()) * time.Second
+	}
+
+	var conditions []client.PollCondition
+	if !d.Conditions.IsNull() {
+		var conds []pollConditionData
+		if d := d.Conditions.ElementsAs(ctx, &conds, false); d.HasError() {
+			diags.Append(d...)
+			return nil, diags
+		}
+		for i, cond := range conds {
+			var status statusDataGo
+			if d := cond.Status.As(ctx, &status, basetypes.ObjectAsOptions{}); d.HasError() {
+				diags.Append(d...)
+				return nil, diags
+			}
+			loc, err := expandValueLocatorWithParam(cond.StatusLocator.ValueString(), bodyJSON)
+			if err != nil {
+				diags.AddError(fmt.Sprintf("Failed to parse status locator of condition %d", i), err.Error())
+				return nil, diags
+			}
+			conditions = append(conditions, client.PollCondition{
+				StatusLocator: loc,
+				Status: client.PollingStatus{
+					Success: status.Success,
+					Pending: status.Pending,
+				},
+			})
+		}
+	}
+
+	var abortIf []client.PollAbortCondition
+	if !d.AbortIf.IsNull() {
+		var aborts []pollAbortIfData
+		if d := d.AbortIf.ElementsAs(ctx, &aborts, false); d.HasError() {
+			diags.Append(d...)
+			return nil, diags
+		}
+		for i, abort := range aborts {
+			loc, err := expandValueLocatorWithParam(abort.Locator.ValueString(), bodyJSON)
+			if err != nil {
+				diags.AddError(fmt.Sprintf("Failed to parse locator of abort_if %d", i), err.Error())
+				return nil, diags
+			}
+			abortIf = append(abortIf, client.PollAbortCondition{
+				Locator: loc,
+				Values:  abort.Values,
+			})
+		}
+	}
+
 	return &client.PollOption{
 		StatusLocator: statusLocator,
 		Status: client.PollingStatus{
@@ -143,10 +246,54 @@ func (opt apiOption) ForPoll(ctx context.Context, defaultHeader client.Header, d
 		// The poll option always use the default query, which is typically is from the original request
 		Query: defaultQuery,
 
-		DefaultDelay: time.Duration(d.DefaultDelay.ValueInt64()) * time.Second,
+		DefaultDelay: defaultDelay,
+
+		RetryStatusCodes: retryStatusCodes,
+		Conditions:       conditions,
+		AbortIf:          abortIf,
 	}, nil
 }
 
+// AsyncOperationURLLocator returns the locator of the async operation URL returned in the `Operation-Location`, `Azure-AsyncOperation` or `Location` header.
+// It returns nil if there is no such header in the response.
+func AsyncOperationURLLocator(resp resty.Response) client.ValueLocator {
+	for _, h := range []string{"Operation-Location", "Azure-AsyncOperation", "Location"} {
+		if resp.Header().Get(h) != "" {
+			return client.HeaderLocator(h)
+		}
+	}
+	return nil
+}
+
+// ForAutoPoll builds the poll option for a `202 Accepted` response, which polls the URL returned in the `Operation-Location`, `Azure-AsyncOperation` or `Location` header.
+// The operation status monitors (i.e. the former two) are polled until the `status` in the body is `Succeeded`, while the `Location` is polled until it returns
+// a `2xx` other than `202`. It returns nil if there is no such header in the response.
+func (opt apiOption) ForAutoPoll(resp resty.Response, defaultHeader client.Header, defaultQuery client.Query) *client.PollOption {
+	urlLocator := AsyncOperationURLLocator(resp)
+	if urlLocator == nil {
+		return nil
+	}
+	popt := &client.PollOption{
+		StatusLocator: client.CodeLocator{},
+		Status: client.PollingStatus{
+			Success: "2xx",
+			Pending: []string{strconv.Itoa(http.StatusAccepted)},
+		},
+		UrlLocator:   urlLocator,
+		Header:       defaultHeader,
+		Query:        defaultQuery,
+		DefaultDelay: defaults.PollDefaultDelay,
+	}
+	if urlLocator != client.HeaderLocator("Location") {
+		popt.StatusLocator = client.BodyLocator("status")
+		popt.Status = client.PollingStatus{
+			Success: "Succeeded",
+			Pending: []string{"NotStarted", "Running", "InProgress"},
+		}
+	}
+	return popt
+}
+
 func (opt apiOption) ForPrecheck(ctx context.Context, defaultPath string, defaultHeader client.Header, defaultQuery client.Query, d precheckDataApi, body basetypes.DynamicValue) (*client.PollOption, diag.Diagnostics) {
 	var diags diag.Diagnostics
 
@@ -181,7 +328,11 @@ func (opt apiOption) ForPrecheck(ctx context.Context, defaultPath string, defaul
 	if !d.Path.IsNull() {
 		path = d.Path.ValueString()
 	}
-	uRL.Path, err = url.JoinPath(uRL.Path, path)
+	if opt.RawPathJoin {
+		uRL.Path += path
+	} else {
+		uRL.Path, err = url.JoinPath(uRL.Path, path)
+	}
 	if err != nil {
 		diags.Append(diag.NewErrorDiagnostic("failed to create precheck option", fmt.Sprintf("joining url: %v", err)))
 		return nil, diags
@@ -199,6 +350,12 @@ func (opt apiOption) ForPrecheck(ctx context.Context, defaultPath string, defaul
 	uRL.RawQuery = query.Encode()
 	urlLocator := client.ExactLocator(uRL.String())
 
+	// The `default_delay_sec` can be absent in the provider level default prechecks.
+	defaultDelay := defaults.PollDefaultDelay
+	if !d.DefaultDelay.IsNull() {
+		defaultDelay = time.Duration(d.DefaultDelay.ValueInt64()) * time.Second
+	}
+
 	return &client.PollOption{
 		StatusLocator: statusLocator,
 		Status: client.PollingStatus{
@@ -207,6 +364,6 @@ func (opt apiOption) ForPrecheck(ctx context.Context, defaultPath string, defaul
 		},
 		UrlLocator:   urlLocator,
 		Header:       header,
-		DefaultDelay: time.Duration(d.DefaultDelay.ValueInt64()) * time.Second,
+		DefaultDelay: defaultDelay,
 	}, nil
 }
@@ -0,0 +1,185 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/terraform-provider-restful/internal/client"
+	"github.com/magodo/terraform-provider-restful/internal/dynamic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsyncOperationURLLocator(t *testing.T) {
+	cases := []struct {
+		name   string
+		header map[string]string
+		expect client.ValueLocator
+	}{
+		{
+			name: "no header",
+		},
+		{
+			name:   "location",
+			header: map[string]string{"Location": "/operations/1"},
+			expect: client.HeaderLocator("Location"),
+		},
+		{
+			name:   "azure-asyncoperation",
+			header: map[string]string{"Location": "/foo", "Azure-AsyncOperation": "/operations/1"},
+			expect: client.HeaderLocator("Azure-AsyncOperation"),
+		},
+		{
+			name:   "operation-location takes precedence",
+			header: map[string]string{"Location": "/foo", "Operation-Location": "/operations/1"},
+			expect: client.HeaderLocator("Operation-Location"),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range tt.header {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(http.StatusAccepted)
+			}))
+			defer srv.Close()
+
+			c, err := client.New(context.Background(), srv.URL, &client.BuildOption{})
+			require.NoError(t, err)
+			resp, err := c.Operation(context.Background(), "/foo", types.DynamicNull(), client.OperationOption{Method: "DELETE"})
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, AsyncOperationURLLocator(*resp))
+		})
+	}
+}
+
+func TestForAutoPoll(t *testing.T) {
+	cases := []struct {
+		name      string
+		header    string
+		responses []autoPollResponse
+		err       bool
+	}{
+		{
+			name:   "operation-location in progress with 200",
+			header: "Operation-Location",
+			responses: []autoPollResponse{
+				{code: http.StatusOK, body: `{"status": "NotStarted"}`},
+				{code: http.StatusOK, body: `{"status": "InProgress"}`},
+				{code: http.StatusOK, body: `{"status": "Succeeded"}`},
+			},
+		},
+		{
+			name:   "azure-asyncoperation failed",
+			header: "Azure-AsyncOperation",
+			responses: []autoPollResponse{
+				{code: http.StatusOK, body: `{"status": "Running"}`},
+				{code: http.StatusOK, body: `{"status": "Failed"}`},
+			},
+			err: true,
+		},
+		{
+			name:   "location completed with 204",
+			header: "Location",
+			responses: []autoPollResponse{
+				{code: http.StatusAccepted},
+				{code: http.StatusAccepted},
+				{code: http.StatusNoContent},
+			},
+		},
+		{
+			name:   "location completed with 201",
+			header: "Location",
+			responses: []autoPollResponse{
+				{code: http.StatusAccepted},
+				{code: http.StatusCreated, body: `{"id": "1"}`},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var polls int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != "/operations/1" {
+					w.Header().Set(tt.header, "/operations/1")
+					w.WriteHeader(http.StatusAccepted)
+					return
+				}
+				resp := tt.responses[polls]
+				polls++
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(resp.code)
+				w.Write([]byte(resp.body))
+			}))
+			defer srv.Close()
+
+			c, err := client.New(context.Background(), srv.URL, &client.BuildOption{})
+			require.NoError(t, err)
+			resp, err := c.Create(context.Background(), "/foos", `{}`, client.CreateOption{Method: "POST"})
+			require.NoError(t, err)
+
+			opt := apiOption{}.ForAutoPoll(*resp, client.Header{}, client.Query{})
+			require.NotNil(t, opt)
+			opt.DefaultDelay = time.Millisecond
+			p, err := client.NewPollableForPoll(*resp, *opt)
+			require.NoError(t, err)
+			err = p.PollUntilDone(context.Background(), c)
+			if tt.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, len(tt.responses), polls)
+		})
+	}
+}
+
+type autoPollResponse struct {
+	code int
+	body string
+}
+
+func TestForResourceDeleteQueryFromOutput(t *testing.T) {
+	ctx := context.Background()
+
+	// The API requires the latest resource version to delete the resource.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Path != "/items/1" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.URL.Query().Get("resourceVersion") != "42" {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	output, err := dynamic.FromJSONImplied([]byte(`{"id": "1", "metadata": {"resourceVersion": "42"}}`))
+	require.NoError(t, err)
+	deleteQuery, diags := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, map[string][]string{
+		"resourceVersion": {"$(body.metadata.resourceVersion)"},
+	})
+	require.False(t, diags.HasError(), diags)
+
+	opt, diags := apiOption{DeleteMethod: "DELETE", Query: client.Query{"api-version": {"v1"}}, Header: client.Header{}}.ForResourceDelete(ctx, resourceData{
+		Path:        types.StringValue("/items"),
+		DeleteQuery: deleteQuery,
+		Output:      output,
+	})
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, client.Query{"resourceVersion": {"42"}}, opt.Query)
+
+	c, err := client.New(ctx, srv.URL, &client.BuildOption{})
+	require.NoError(t, err)
+	resp, err := c.Delete(ctx, "/items/1", "", *opt)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode())
+}
@@ -1,11 +1,17 @@
 package provider
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 
 	"github.com/magodo/terraform-provider-restful/internal/attrpath"
+	"github.com/santhosh-tekuri/jsonschema/v5"
 	"github.com/tidwall/gjson"
 	"github.com/tidwall/sjson"
 )
@@ -156,8 +162,11 @@ func FilterAttrsInJSON(doc string, attrs []string) (string, error) {
 		paths = append(paths, path)
 	}
 
+	// Use json.Number to keep the precision of large integers.
 	var jsonDoc any
-	if err := json.Unmarshal([]byte(doc), &jsonDoc); err != nil {
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	if err := dec.Decode(&jsonDoc); err != nil {
 		return "", err
 	}
 
@@ -208,7 +217,9 @@ func filterAttrInJSON(doc any, prefix, path attrpath.AttrPath) (any, error) {
 		if err != nil {
 			return nil, err
 		}
-		if err := json.Unmarshal(b, &odoc); err != nil {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		if err := dec.Decode(&odoc); err != nil {
 			return nil, err
 		}
 		return odoc, nil
@@ -347,3 +358,220 @@ func mergeObject(addr string, obj1, obj2 map[string]interface{}) (map[string]int
 	}
 	return obj, nil
 }
+
+// RemapJSON relocates the values in the JSON document, from the source paths (the keys of m) to the target paths (the values of m).
+// The source paths that don't exist in the document are skipped.
+func RemapJSON(doc string, m map[string]string) (string, error) {
+	var srcs []string
+	for src := range m {
+		srcs = append(srcs, src)
+	}
+	slices.Sort(srcs)
+
+	// Retrieve all the source values before any modification, in case a target path overlaps with another source path.
+	values := map[string]string{}
+	for _, src := range srcs {
+		if res := gjson.Get(doc, src); res.Exists() {
+			values[src] = res.Raw
+		}
+	}
+
+	var err error
+	for _, src := range srcs {
+		if _, ok := values[src]; !ok {
+			continue
+		}
+		doc, err = sjson.Delete(doc, src)
+		if err != nil {
+			return "", fmt.Errorf("deleting %q: %v", src, err)
+		}
+	}
+	for _, src := range srcs {
+		v, ok := values[src]
+		if !ok {
+			continue
+		}
+		doc, err = sjson.SetRaw(doc, m[src], v)
+		if err != nil {
+			return "", fmt.Errorf("setting %q: %v", m[src], err)
+		}
+	}
+	return doc, nil
+}
+
+// RemoveNullsInJSON recursively removes the null valued attributes of the objects in the JSON document.
+// The null elements of the arrays are kept, as removing them shifts the indexes.
+func RemoveNullsInJSON(doc string) (string, error) {
+	// Use json.Number to keep the precision of large integers.
+	var jsonDoc any
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	if err := dec.Decode(&jsonDoc); err != nil {
+		return "", err
+	}
+	b, err := json.Marshal(removeNulls(jsonDoc))
+	if err != nil {
+		return "", fmt.Errorf("marshalling the document: %v", err)
+	}
+	return string(b), nil
+}
+
+func removeNulls(doc any) any {
+	switch doc := doc.(type) {
+	case map[string]any:
+		for k, v := range doc {
+			if v == nil {
+				delete(doc, k)
+				continue
+			}
+			doc[k] = removeNulls(v)
+		}
+	case []any:
+		for i, v := range doc {
+			doc[i] = removeNulls(v)
+		}
+	}
+	return doc
+}
+
+const (
+	normalizeLowercase = "lowercase"
+	normalizeCIDR      = "cidr"
+	normalizeTrim      = "trim"
+	normalizeJSON      = "json"
+)
+
+// NormalizeBody keeps the string values of the base at the specified paths (in gjson syntax) in the body, if they are
+// equivalent to the body values after being normalized by the specified kinds. This is used to keep the configured values
+// that are only normalized by the server (e.g. lowercased), to avoid the perpetual diffs.
+func NormalizeBody(base, body string, normalize map[string]string) (string, error) {
+	var paths []string
+	for path := range normalize {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	for _, path := range paths {
+		baseValue, bodyValue := gjson.Get(base, path), gjson.Get(body, path)
+		if baseValue.Type != gjson.String || bodyValue.Type != gjson.String || baseValue.Str == bodyValue.Str {
+			continue
+		}
+		baseNorm, err := normalizeValue(baseValue.Str, normalize[path])
+		if err != nil {
+			continue
+		}
+		bodyNorm, err := normalizeValue(bodyValue.Str, normalize[path])
+		if err != nil {
+			continue
+		}
+		if baseNorm != bodyNorm {
+			continue
+		}
+		body, err = sjson.SetRaw(body, path, baseValue.Raw)
+		if err != nil {
+			return "", fmt.Errorf("setting %q: %v", path, err)
+		}
+	}
+	return body, nil
+}
+
+// normalizeValue normalizes the value by the kind, it returns error if the value can't be normalized by the kind.
+func normalizeValue(v, kind string) (string, error) {
+	switch kind {
+	case normalizeLowercase:
+		return strings.ToLower(v), nil
+	case normalizeTrim:
+		return strings.TrimSpace(v), nil
+	case normalizeCIDR:
+		if prefix, err := netip.ParsePrefix(v); err == nil {
+			return prefix.Masked().String(), nil
+		}
+		addr, err := netip.ParseAddr(v)
+		if err != nil {
+			return "", fmt.Errorf("%q is neither a CIDR nor an IP address", v)
+		}
+		return addr.String(), nil
+	case normalizeJSON:
+		var doc any
+		dec := json.NewDecoder(strings.NewReader(v))
+		dec.UseNumber()
+		if err := dec.Decode(&doc); err != nil {
+			return "", err
+		}
+		b, err := json.Marshal(doc)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	default:
+		return "", fmt.Errorf("unknown normalization kind %q", kind)
+	}
+}
+
+// ValidateBodySchema validates the JSON body against the JSON Schema, which is either specified inline by schema, or by the
+// path of the schema file. Nothing is validated if neither is specified.
+func ValidateBodySchema(schema, schemaFile string, body []byte) error {
+	var (
+		sch *jsonschema.Schema
+		err error
+	)
+	switch {
+	case schema != "":
+		sch, err = jsonschema.CompileString("body_schema.json", schema)
+	case schemaFile != "":
+		sch, err = jsonschema.Compile(schemaFile)
+	default:
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("compiling the JSON Schema: %v", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return fmt.Errorf("unmarshal the body: %v", err)
+	}
+	return sch.Validate(v)
+}
+
+// BodyToQuery flattens the JSON object into query parameters. The nested object properties are joined by dot (e.g. `filter.name`),
+// while the array elements are sent as repeated parameters. Null values are skipped.
+func BodyToQuery(body string) (url.Values, error) {
+	dec := json.NewDecoder(strings.NewReader(body))
+	dec.UseNumber()
+	var doc any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("unmarshal the body %q: %v", body, err)
+	}
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("the body is expected to be an object, got %T", doc)
+	}
+	out := url.Values{}
+	for k, v := range obj {
+		flattenQuery(out, k, v)
+	}
+	return out, nil
+}
+
+func flattenQuery(out url.Values, key string, v any) {
+	switch v := v.(type) {
+	case nil:
+	case map[string]any:
+		for k, vv := range v {
+			flattenQuery(out, key+"."+k, vv)
+		}
+	case []any:
+		for _, vv := range v {
+			flattenQuery(out, key, vv)
+		}
+	case string:
+		out.Add(key, v)
+	case json.Number:
+		out.Add(key, v.String())
+	case bool:
+		out.Add(key, strconv.FormatBool(v))
+	}
+}
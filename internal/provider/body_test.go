@@ -1,9 +1,15 @@
 package provider
 
 import (
+	"context"
 	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/require"
 )
 
@@ -359,3 +365,322 @@ func TestFilterJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestBodyToQuery(t *testing.T) {
+	cases := []struct {
+		name        string
+		body        string
+		expect      url.Values
+		expectError bool
+	}{
+		{
+			name:        "invalid body",
+			body:        "",
+			expectError: true,
+		},
+		{
+			name:        "non object body",
+			body:        `[1, 2]`,
+			expectError: true,
+		},
+		{
+			name:   "empty object",
+			body:   `{}`,
+			expect: url.Values{},
+		},
+		{
+			name: "primitives",
+			body: `{"s": "a", "n": 12345678901234567890, "b": true, "null": null}`,
+			expect: url.Values{
+				"s": []string{"a"},
+				"n": []string{"12345678901234567890"},
+				"b": []string{"true"},
+			},
+		},
+		{
+			name: "array and nested object",
+			body: `{"status": ["active", "pending"], "filter": {"name": "foo", "tags": {"env": "prod"}}}`,
+			expect: url.Values{
+				"status":          []string{"active", "pending"},
+				"filter.name":     []string{"foo"},
+				"filter.tags.env": []string{"prod"},
+			},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := BodyToQuery(tt.body)
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestRemapJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		doc    string
+		m      map[string]string
+		expect string
+	}{
+		{
+			name:   "empty mapping",
+			doc:    `{"name": "foo"}`,
+			m:      map[string]string{},
+			expect: `{"name": "foo"}`,
+		},
+		{
+			name:   "relocate nested property",
+			doc:    `{"id": "1", "properties": {"displayName": "foo", "size": 1}}`,
+			m:      map[string]string{"properties.displayName": "name"},
+			expect: `{"id": "1", "properties": {"size": 1}, "name": "foo"}`,
+		},
+		{
+			name:   "relocate object",
+			doc:    `{"spec": {"tags": {"env": "prod"}}}`,
+			m:      map[string]string{"spec.tags": "tags"},
+			expect: `{"spec": {}, "tags": {"env": "prod"}}`,
+		},
+		{
+			name:   "absent source is skipped",
+			doc:    `{"name": "foo"}`,
+			m:      map[string]string{"properties.size": "size"},
+			expect: `{"name": "foo"}`,
+		},
+		{
+			name:   "swap",
+			doc:    `{"a": 1, "b": 2}`,
+			m:      map[string]string{"a": "b", "b": "a"},
+			expect: `{"a": 2, "b": 1}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := RemapJSON(tt.doc, tt.m)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestSendBody(t *testing.T) {
+	body := []byte(`{"name":"foo","props":{"a":1,"b":null},"etag":"x"}`)
+	cases := []struct {
+		name      string
+		sendAttrs types.Set
+		omitNull  bool
+		expect    string
+	}{
+		{
+			name:      "send all",
+			sendAttrs: types.SetNull(types.StringType),
+			expect:    string(body),
+		},
+		{
+			name:      "send subset",
+			sendAttrs: types.SetValueMust(types.StringType, []attr.Value{types.StringValue("name"), types.StringValue("props.a")}),
+			expect:    `{"name":"foo","props":{"a":1}}`,
+		},
+		{
+			name:      "send all without nulls",
+			sendAttrs: types.SetNull(types.StringType),
+			omitNull:  true,
+			expect:    `{"name":"foo","props":{"a":1},"etag":"x"}`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, diags := sendBody(context.Background(), tt.sendAttrs, tt.omitNull, body)
+			require.False(t, diags.HasError(), diags)
+			require.JSONEq(t, tt.expect, string(b))
+		})
+	}
+}
+
+func TestValidateBodySchema(t *testing.T) {
+	schema := `{
+  "type": "object",
+  "properties": {
+    "name": {"type": "string"},
+    "size": {"type": "integer", "minimum": 1}
+  },
+  "required": ["name"]
+}`
+	schemaFile := filepath.Join(t.TempDir(), "schema.json")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(schema), 0644))
+
+	cases := []struct {
+		name        string
+		schema      string
+		schemaFile  string
+		body        string
+		expectError bool
+	}{
+		{
+			name: "no schema",
+			body: `{"size": 0}`,
+		},
+		{
+			name:   "valid body",
+			schema: schema,
+			body:   `{"name": "foo", "size": 12345678901234567890}`,
+		},
+		{
+			name:        "missing required property",
+			schema:      schema,
+			body:        `{"size": 1}`,
+			expectError: true,
+		},
+		{
+			name:        "invalid property",
+			schema:      schema,
+			body:        `{"name": "foo", "size": 0}`,
+			expectError: true,
+		},
+		{
+			name:       "valid body with schema file",
+			schemaFile: schemaFile,
+			body:       `{"name": "foo"}`,
+		},
+		{
+			name:        "invalid body with schema file",
+			schemaFile:  schemaFile,
+			body:        `{"name": 1}`,
+			expectError: true,
+		},
+		{
+			name:        "invalid schema",
+			schema:      `{"type": 1}`,
+			body:        `{"name": "foo"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateBodySchema(tt.schema, tt.schemaFile, []byte(tt.body))
+			if tt.expectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestRemoveNullsInJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		doc    string
+		expect string
+	}{
+		{
+			name:   "no null",
+			doc:    `{"a":1,"b":"x"}`,
+			expect: `{"a":1,"b":"x"}`,
+		},
+		{
+			name:   "nested nulls",
+			doc:    `{"a":null,"b":{"c":null,"d":1},"e":[{"f":null,"g":2}]}`,
+			expect: `{"b":{"d":1},"e":[{"g":2}]}`,
+		},
+		{
+			name:   "null array elements kept",
+			doc:    `{"a":[1,null,3]}`,
+			expect: `{"a":[1,null,3]}`,
+		},
+		{
+			name:   "large integer",
+			doc:    `{"a":12345678901234567890,"b":null}`,
+			expect: `{"a":12345678901234567890}`,
+		},
+	}
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := RemoveNullsInJSON(tt.doc)
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, out)
+		})
+	}
+}
+
+func TestNormalizeBody(t *testing.T) {
+	cases := []struct {
+		name      string
+		base      string
+		body      string
+		normalize map[string]string
+		expect    string
+	}{
+		{
+			name:      "lowercase",
+			base:      `{"host": "Example.COM", "name": "Foo"}`,
+			body:      `{"host": "example.com", "name": "foo"}`,
+			normalize: map[string]string{"host": normalizeLowercase},
+			expect:    `{"host": "Example.COM", "name": "foo"}`,
+		},
+		{
+			name:      "lowercase different value",
+			base:      `{"host": "Example.COM"}`,
+			body:      `{"host": "example.org"}`,
+			normalize: map[string]string{"host": normalizeLowercase},
+			expect:    `{"host": "example.org"}`,
+		},
+		{
+			name:      "cidr",
+			base:      `{"cidrs": ["10.0.0.1/24", "2001:db8::/32"]}`,
+			body:      `{"cidrs": ["10.0.0.0/24", "2001:0db8:0000::/32"]}`,
+			normalize: map[string]string{"cidrs.0": normalizeCIDR, "cidrs.1": normalizeCIDR},
+			expect:    `{"cidrs": ["10.0.0.1/24", "2001:db8::/32"]}`,
+		},
+		{
+			name:      "cidr different prefix",
+			base:      `{"cidr": "10.0.0.0/24"}`,
+			body:      `{"cidr": "10.0.0.0/16"}`,
+			normalize: map[string]string{"cidr": normalizeCIDR},
+			expect:    `{"cidr": "10.0.0.0/16"}`,
+		},
+		{
+			name:      "cidr invalid",
+			base:      `{"cidr": "foo"}`,
+			body:      `{"cidr": "FOO"}`,
+			normalize: map[string]string{"cidr": normalizeCIDR},
+			expect:    `{"cidr": "FOO"}`,
+		},
+		{
+			name:      "trim",
+			base:      `{"properties": {"desc": "foo "}}`,
+			body:      `{"properties": {"desc": "foo"}}`,
+			normalize: map[string]string{"properties.desc": normalizeTrim},
+			expect:    `{"properties": {"desc": "foo "}}`,
+		},
+		{
+			name:      "json",
+			base:      `{"policy": "{\"b\": 1, \"a\": [1, 2]}"}`,
+			body:      `{"policy": "{\"a\":[1,2],\"b\":1}"}`,
+			normalize: map[string]string{"policy": normalizeJSON},
+			expect:    `{"policy": "{\"b\": 1, \"a\": [1, 2]}"}`,
+		},
+		{
+			name:      "non-string and absent values are skipped",
+			base:      `{"a": 1, "b": "x"}`,
+			body:      `{"a": 2}`,
+			normalize: map[string]string{"a": normalizeTrim, "b": normalizeTrim},
+			expect:    `{"a": 2}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := NormalizeBody(tt.base, tt.body, tt.normalize)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expect, actual)
+		})
+	}
+}
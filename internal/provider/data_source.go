@@ -5,9 +5,12 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
@@ -22,15 +25,20 @@ type DataSource struct {
 var _ datasource.DataSource = &DataSource{}
 
 type dataSourceData struct {
-	ID            types.String  `tfsdk:"id"`
-	Method        types.String  `tfsdk:"method"`
-	Query         types.Map     `tfsdk:"query"`
-	Header        types.Map     `tfsdk:"header"`
-	Selector      types.String  `tfsdk:"selector"`
-	OutputAttrs   types.Set     `tfsdk:"output_attrs"`
-	AllowNotExist types.Bool    `tfsdk:"allow_not_exist"`
-	Precheck      types.List    `tfsdk:"precheck"`
-	Output        types.Dynamic `tfsdk:"output"`
+	ID              types.String  `tfsdk:"id"`
+	Method          types.String  `tfsdk:"method"`
+	Body            types.Dynamic `tfsdk:"body"`
+	BodyAsQuery     types.Bool    `tfsdk:"body_as_query"`
+	Query           types.Map     `tfsdk:"query"`
+	Header          types.Map     `tfsdk:"header"`
+	Selector        types.String  `tfsdk:"selector"`
+	OutputAttrs     types.Set     `tfsdk:"output_attrs"`
+	OutputTypeHints types.Map     `tfsdk:"output_type_hints"`
+	OutputSort      types.List    `tfsdk:"output_sort"`
+	AllowNotExist   types.Bool    `tfsdk:"allow_not_exist"`
+	Precheck        types.List    `tfsdk:"precheck"`
+	Output          types.Dynamic `tfsdk:"output"`
+	OutputRaw       types.String  `tfsdk:"output_raw"`
 }
 
 func (d *DataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -55,6 +63,19 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 					stringvalidator.OneOf("GET", "POST", "HEAD"),
 				},
 			},
+			"body": schema.DynamicAttribute{
+				Description:         "The payload of the request, which is sent as a JSON document. This is typically used together with the `POST` method to query a search-style endpoint that expects a filter in the request body.",
+				MarkdownDescription: "The payload of the request, which is sent as a JSON document. This is typically used together with the `POST` method to query a search-style endpoint that expects a filter in the request body.",
+				Optional:            true,
+			},
+			"body_as_query": schema.BoolAttribute{
+				Description:         "Whether to send the `body` as query parameters instead of the payload, for the APIs that only accept the filter via the query string. The `body` must be an object, whose nested object properties are joined by dot (e.g. `filter.name`), and whose array elements are sent as repeated parameters. The `query` takes precedence over the parameters built from the `body` with the same name. Defaults to `false`.",
+				MarkdownDescription: "Whether to send the `body` as query parameters instead of the payload, for the APIs that only accept the filter via the query string. The `body` must be an object, whose nested object properties are joined by dot (e.g. `filter.name`), and whose array elements are sent as repeated parameters. The `query` takes precedence over the parameters built from the `body` with the same name. Defaults to `false`.",
+				Optional:            true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("body")),
+				},
+			},
 			"query": schema.MapAttribute{
 				Description:         "The query parameters that are applied to each request. This overrides the `query` set in the provider block.",
 				MarkdownDescription: "The query parameters that are applied to each request. This overrides the `query` set in the provider block.",
@@ -78,6 +99,34 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"output_type_hints": schema.MapAttribute{
+				Description:         outputTypeHintsDescription,
+				MarkdownDescription: outputTypeHintsMarkdownDescription,
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(outputTypeString, outputTypeNumber, outputTypeBool)),
+				},
+			},
+			"output_sort": schema.ListNestedAttribute{
+				Description:         "A list of arrays in the `output` to be sorted, so that the order of the array elements is stable across reads. This is useful for the APIs that return arrays in a nondeterministic order. Elements are ordered by number if the keys of both are numbers, otherwise by the string representation of the keys.",
+				MarkdownDescription: "A list of arrays in the `output` to be sorted, so that the order of the array elements is stable across reads. This is useful for the APIs that return arrays in a nondeterministic order. Elements are ordered by number if the keys of both are numbers, otherwise by the string representation of the keys.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Description:         "The path (in gjson syntax) to the array in the `output`. Use `@this` for the `output` itself.",
+							MarkdownDescription: "The path (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to the array in the `output`. Use `@this` for the `output` itself.",
+							Required:            true,
+						},
+						"key": schema.StringAttribute{
+							Description:         "The path (in gjson syntax) to the sort key, relative to each array element. Use `@this` for the element itself.",
+							MarkdownDescription: "The path (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to the sort key, relative to each array element. Use `@this` for the element itself.",
+							Required:            true,
+						},
+					},
+				},
+			},
 			"allow_not_exist": schema.BoolAttribute{
 				Description:         "Whether to throw error if the data source being queried doesn't exist (i.e. status code is 404). Defaults to `false`.",
 				MarkdownDescription: "Whether to throw error if the data source being queried doesn't exist (i.e. status code is 404). Defaults to `false`.",
@@ -89,6 +138,11 @@ func (d *DataSource) Schema(ctx context.Context, req datasource.SchemaRequest, r
 				MarkdownDescription: "The response body after reading the resource.",
 				Computed:            true,
 			},
+			"output_raw": schema.StringAttribute{
+				Description:         "The raw JSON of the `output`, which keeps the exact response body (after `selector`, `output_attrs` and `output_type_hints`) without round-tripping through the dynamic type.",
+				MarkdownDescription: "The raw JSON of the `output`, which keeps the exact response body (after `selector`, `output_attrs` and `output_type_hints`) without round-tripping through the dynamic type.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -139,16 +193,52 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 	}
 
 	state := dataSourceData{
-		ID:            config.ID,
-		Query:         config.Query,
-		Header:        config.Header,
-		Selector:      config.Selector,
-		OutputAttrs:   config.OutputAttrs,
-		AllowNotExist: config.AllowNotExist,
-		Precheck:      config.Precheck,
+		ID:              config.ID,
+		Method:          config.Method,
+		Body:            config.Body,
+		BodyAsQuery:     config.BodyAsQuery,
+		Query:           config.Query,
+		Header:          config.Header,
+		Selector:        config.Selector,
+		OutputAttrs:     config.OutputAttrs,
+		OutputTypeHints: config.OutputTypeHints,
+		OutputSort:      config.OutputSort,
+		AllowNotExist:   config.AllowNotExist,
+		Precheck:        config.Precheck,
 	}
 
-	response, err := c.ReadDS(ctx, config.ID.ValueString(), *opt)
+	var body string
+	if !config.Body.IsNull() {
+		b, err := dynamic.ToJSON(config.Body)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to marshal `body`",
+				err.Error(),
+			)
+			return
+		}
+		body = string(b)
+	}
+	if config.BodyAsQuery.ValueBool() && body != "" {
+		q, err := BodyToQuery(body)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Failed to build query parameters from `body`",
+				err.Error(),
+			)
+			return
+		}
+		query := opt.Query.Clone()
+		for k, v := range q {
+			if _, ok := query[k]; !ok {
+				query[k] = v
+			}
+		}
+		opt.Query = query
+		body = ""
+	}
+
+	response, err := c.ReadDS(ctx, config.ID.ValueString(), body, *opt)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error to call Read",
@@ -164,7 +254,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 			return
 		}
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Read API returns %d", response.StatusCode()),
+			fmt.Sprintf("Read API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 			string(response.Body()),
 		)
 		return
@@ -211,6 +301,29 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		b = []byte(fb)
 	}
 
+	b, diags = coerceOutputTypes(ctx, config.OutputTypeHints, b)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if !config.OutputSort.IsNull() {
+		sorts, diags := expandOutputSorts(ctx, config.OutputSort)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		sb, err := SortArraysInJSON(string(b), sorts)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Sort `output` during Read",
+				err.Error(),
+			)
+			return
+		}
+		b = []byte(sb)
+	}
+
 	output, err := dynamic.FromJSONImplied(b)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -220,6 +333,7 @@ func (d *DataSource) Read(ctx context.Context, req datasource.ReadRequest, resp
 		return
 	}
 	state.Output = output
+	state.OutputRaw = types.StringValue(string(b))
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -0,0 +1,53 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+func TestDataSource_Body(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var filter struct {
+			Name string `json:"name"`
+		}
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&filter); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"items": []any{map[string]any{"name": filter.Name}}})
+	}))
+	defer srv.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+data "restful_resource" "test" {
+  id     = "/search"
+  method = "POST"
+  body = {
+    name = "foo"
+  }
+}
+`, srv.URL),
+				Check: resource.TestCheckResourceAttr("data.restful_resource.test", "output.items.0.name", "foo"),
+			},
+		},
+	})
+}
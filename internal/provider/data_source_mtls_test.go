@@ -7,6 +7,7 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/base64"
 	"encoding/pem"
 	"fmt"
 	"math/big"
@@ -19,6 +20,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
 	"github.com/magodo/terraform-provider-restful/internal/acceptance"
 	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
 )
 
 func TestDataSourceMTLS(t *testing.T) {
@@ -65,6 +67,55 @@ data "restful_resource" "test" {
 `, url, caCert, clientCert, clientKey)
 }
 
+func TestDataSourceMTLSPKCS12(t *testing.T) {
+	serverTLSConfig, caCert, clientCert, clientKey, err := certSetup()
+	require.NoError(t, err)
+
+	cert, err := tls.X509KeyPair(clientCert, clientKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	pfx, err := pkcs12.Modern.Encode(cert.PrivateKey, leaf, nil, "secret")
+	require.NoError(t, err)
+
+	resp := "{}"
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, resp)
+	}))
+	server.TLS = serverTLSConfig
+	server.StartTLS()
+	defer server.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: mtlsPKCS12Config(server.URL, caCert, base64.StdEncoding.EncodeToString(pfx), "secret"),
+			},
+		},
+	})
+}
+
+func mtlsPKCS12Config(url string, caCert []byte, pfx, password string) string {
+	return fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+  client = {
+    root_ca_certificates = [%q]
+    certificates = [
+      {
+        pkcs12          = %q
+        pkcs12_password = %q
+      },
+    ]
+  }
+}
+data "restful_resource" "test" {
+  id = "/"
+}
+`, url, caCert, pfx, password)
+}
+
 func certSetup() (serverTLSConf *tls.Config, caCert, clientCert, clientKey []byte, err error) {
 	// set up our CA certificate
 	ca := &x509.Certificate{
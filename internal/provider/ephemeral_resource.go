@@ -4,11 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework-validators/dynamicvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -16,6 +19,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/magodo/terraform-provider-restful/internal/client"
+	"github.com/magodo/terraform-provider-restful/internal/defaults"
 	"github.com/magodo/terraform-provider-restful/internal/dynamic"
 	"github.com/magodo/terraform-provider-restful/internal/exparam"
 	myvalidator "github.com/magodo/terraform-provider-restful/internal/validator"
@@ -41,6 +46,9 @@ type ephemeralResourceData struct {
 	OpenQuery  types.Map     `tfsdk:"open_query"`
 	OpenHeader types.Map     `tfsdk:"open_header"`
 
+	OpenBodyRaw types.String `tfsdk:"open_body_raw"`
+	BodyFormat  types.String `tfsdk:"body_format"`
+
 	Query  types.Map `tfsdk:"query"`
 	Header types.Map `tfsdk:"header"`
 
@@ -50,6 +58,8 @@ type ephemeralResourceData struct {
 	RenewQuery  types.Map     `tfsdk:"renew_query"`
 	RenewHeader types.Map     `tfsdk:"renew_header"`
 
+	RenewOutputLocator types.String `tfsdk:"renew_output_locator"`
+
 	ExpiryAhead   types.String `tfsdk:"expiry_ahead"`
 	ExpiryType    types.String `tfsdk:"expiry_type"`
 	ExpiryLocator types.String `tfsdk:"expiry_locator"`
@@ -60,8 +70,15 @@ type ephemeralResourceData struct {
 	CloseQuery  types.Map     `tfsdk:"close_query"`
 	CloseHeader types.Map     `tfsdk:"close_header"`
 
-	OutputAttrs types.Set     `tfsdk:"output_attrs"`
-	Output      types.Dynamic `tfsdk:"output"`
+	Retry types.Object `tfsdk:"retry"`
+
+	OutputAttrs     types.Set     `tfsdk:"output_attrs"`
+	OutputTypeHints types.Map     `tfsdk:"output_type_hints"`
+	OutputSort      types.List    `tfsdk:"output_sort"`
+	Output          types.Dynamic `tfsdk:"output"`
+	OutputRaw       types.String  `tfsdk:"output_raw"`
+
+	ExpiresAt types.String `tfsdk:"expires_at"`
 }
 
 func (e *EphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
@@ -122,6 +139,24 @@ func (e *EphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequ
 				ElementType:         types.StringType,
 				Optional:            true,
 			},
+			"open_body_raw": schema.StringAttribute{
+				Description:         "The raw payload to open the ephemeral resource, which is sent as is. The `Content-Type` is expected to be set in the `open_header` or `header`. This is useful for the payloads that are not JSON. Conflicts with `body`.",
+				MarkdownDescription: "The raw payload to open the ephemeral resource, which is sent as is. The `Content-Type` is expected to be set in the `open_header` or `header`. This is useful for the payloads that are not JSON. Conflicts with `body`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("body"),
+					),
+				},
+			},
+			"body_format": schema.StringAttribute{
+				Description:         "The format of the `body` to open the ephemeral resource. Possible values are `json` and `form`. The `form` encodes the `body`, which is expected to be an object of strings, as `application/x-www-form-urlencoded`, e.g. for the OAuth token requests. This is ignored if the `Content-Type` is set in the `open_header` or `header`. Defaults to `json`.",
+				MarkdownDescription: "The format of the `body` to open the ephemeral resource. Possible values are `json` and `form`. The `form` encodes the `body`, which is expected to be an object of strings, as `application/x-www-form-urlencoded`, e.g. for the OAuth token requests. This is ignored if the `Content-Type` is set in the `open_header` or `header`. Defaults to `json`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("json", "form"),
+				},
+			},
 			"query": schema.MapAttribute{
 				Description:         "The query parameters that are applied to each request. This overrides the `query` set in the provider block.",
 				MarkdownDescription: "The query parameters that are applied to each request. This overrides the `query` set in the provider block.",
@@ -147,8 +182,8 @@ func (e *EphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequ
 				},
 			},
 			"renew_path": schema.StringAttribute{
-				Description:         "The path used to renew the ephemeral resource, relative to the `base_url` of the provider. " + pathDescription,
-				MarkdownDescription: "The path used to renew the ephemeral resource, relative to the `base_url` of the provider. " + pathDescription,
+				Description:         "The path used to renew the ephemeral resource, relative to the `base_url` of the provider. " + pathDescription + headerParamDescription,
+				MarkdownDescription: "The path used to renew the ephemeral resource, relative to the `base_url` of the provider. " + pathDescription + headerParamDescription,
 				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.AlsoRequires(
@@ -188,10 +223,21 @@ func (e *EphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequ
 					),
 				},
 			},
+			"renew_output_locator": schema.StringAttribute{
+				Description:         "Specifies how to discover the renewed value in the renew response, which replaces the same attribute in the subsequent renew and close requests. The format is either `body.path`, which replaces the attribute at the path of the `renew_body` and `close_body`, or `header.name`, which replaces the header of the `renew_header` and `close_header`. Only the attributes that exist in the requests are replaced. This is useful for the chained renewal, where each renew returns a new token for the next renew. Note that the `output` is not updated, as it is fixed once opened.",
+				MarkdownDescription: "Specifies how to discover the renewed value in the renew response, which replaces the same attribute in the subsequent renew and close requests. The format is either `body.path`, which replaces the attribute at the path (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) of the `renew_body` and `close_body`, or `header.name`, which replaces the header of the `renew_header` and `close_header`. Only the attributes that exist in the requests are replaced. This is useful for the chained renewal, where each renew returns a new token for the next renew. Note that the `output` is not updated, as it is fixed once opened.",
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsParsable("renew_output_locator", validateRenewOutputLocator),
+					stringvalidator.AlsoRequires(
+						path.MatchRoot("renew_method"),
+					),
+				},
+			},
 
 			"expiry_type": schema.StringAttribute{
-				Description:         `The type of the ephemeral resource expiry time. Possible values are: "duration", "time" and "time.[layout]". "duration" means the expiry time is a duration; "time" means the expiry time is a time, which defaults to RF3339 layout, unless the "layout" is explicitly specified (following Go's convention: https://pkg.go.dev/time).`,
-				MarkdownDescription: `The type of the ephemeral resource expiry time. Possible values are: "duration", "time" and "time.[layout]". "duration" means the expiry time is a [duration](https://pkg.go.dev/time#ParseDuration); "time" means the expiry time is a time, which defaults to RF3339 layout, unless the "layout" is explicitly specified (following Go's [convention](https://pkg.go.dev/time)).`,
+				Description:         `The type of the ephemeral resource expiry time. Possible values are: "duration", "time", "time.[layout]" and "cache-control". "duration" means the expiry time is a duration; "time" means the expiry time is a time, which defaults to RF3339 layout, unless the "layout" is explicitly specified (following Go's convention: https://pkg.go.dev/time); "cache-control" means the expiry time is the "max-age" directive of a Cache-Control header value (e.g. located by "header.Cache-Control").`,
+				MarkdownDescription: `The type of the ephemeral resource expiry time. Possible values are: "duration", "time", "time.[layout]" and "cache-control". "duration" means the expiry time is a [duration](https://pkg.go.dev/time#ParseDuration); "time" means the expiry time is a time, which defaults to RF3339 layout, unless the "layout" is explicitly specified (following Go's [convention](https://pkg.go.dev/time)); "cache-control" means the expiry time is the "max-age" directive of a Cache-Control header value (e.g. located by "header.Cache-Control").`,
 				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.AlsoRequires(
@@ -245,8 +291,8 @@ func (e *EphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequ
 				},
 			},
 			"close_path": schema.StringAttribute{
-				Description:         "The path used to close the ephemeral resource, relative to the `base_url` of the provider. " + pathDescription,
-				MarkdownDescription: "The path used to close the ephemeral resource, relative to the `base_url` of the provider. " + pathDescription,
+				Description:         "The path used to close the ephemeral resource, relative to the `base_url` of the provider. " + pathDescription + headerParamDescription,
+				MarkdownDescription: "The path used to close the ephemeral resource, relative to the `base_url` of the provider. " + pathDescription + headerParamDescription,
 				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.AlsoRequires(
@@ -287,18 +333,90 @@ func (e *EphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequ
 				},
 			},
 
+			"retry": schema.SingleNestedAttribute{
+				Description:         "The retry option for the `Open`/`Renew`/`Close` calls, which is on top of the retry option of the provider's client. The call is retried on error or on the specified status codes.",
+				MarkdownDescription: "The retry option for the `Open`/`Renew`/`Close` calls, which is on top of the retry option of the provider's client. The call is retried on error or on the specified status codes.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"status_codes": schema.ListAttribute{
+						Description:         "The status codes that will retry.",
+						MarkdownDescription: "The status codes that will retry.",
+						Required:            true,
+						ElementType:         types.Int64Type,
+					},
+					"count": schema.Int64Attribute{
+						Description:         fmt.Sprintf("The maximum allowed retries. Defaults to `%d`.", defaults.RetryCount),
+						MarkdownDescription: fmt.Sprintf("The maximum allowed retries. Defaults to `%d`.", defaults.RetryCount),
+						Optional:            true,
+					},
+					"wait_in_sec": schema.Int64Attribute{
+						Description:         fmt.Sprintf("The initial retry wait time between two retries in second, if there is no `Retry-After` in the response header. The wait time will be doubled for each retry, at most up to `max_wait_in_sec`. Defaults to `%v`.", defaults.RetryWaitTime.Seconds()),
+						MarkdownDescription: fmt.Sprintf("The initial retry wait time between two retries in second, if there is no `Retry-After` in the response header. The wait time will be doubled for each retry, at most up to `max_wait_in_sec`. Defaults to `%v`.", defaults.RetryWaitTime.Seconds()),
+						Optional:            true,
+					},
+					"max_wait_in_sec": schema.Int64Attribute{
+						Description:         fmt.Sprintf("The maximum allowed retry wait time. Defaults to `%v`.", defaults.RetryMaxWaitTime.Seconds()),
+						MarkdownDescription: fmt.Sprintf("The maximum allowed retry wait time. Defaults to `%v`.", defaults.RetryMaxWaitTime.Seconds()),
+						Optional:            true,
+					},
+					"retry_on_connection_error": schema.BoolAttribute{
+						Description:         "Whether to retry on the connection errors (e.g. connection reset, DNS failure, TLS handshake timeout), where there is no response. Defaults to `true`.",
+						MarkdownDescription: "Whether to retry on the connection errors (e.g. connection reset, DNS failure, TLS handshake timeout), where there is no response. Defaults to `true`.",
+						Optional:            true,
+					},
+				},
+			},
+
 			"output_attrs": schema.SetAttribute{
 				Description:         "A set of `output` attribute paths (in gjson syntax) that will be exported in the `output`. If this is not specified, all attributes will be exported by `output`.",
 				MarkdownDescription: "A set of `output` attribute paths (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) that will be exported in the `output`. If this is not specified, all attributes will be exported by `output`.",
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"output_type_hints": schema.MapAttribute{
+				Description:         outputTypeHintsDescription,
+				MarkdownDescription: outputTypeHintsMarkdownDescription,
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(outputTypeString, outputTypeNumber, outputTypeBool)),
+				},
+			},
+			"output_sort": schema.ListNestedAttribute{
+				Description:         "A list of arrays in the `output` to be sorted, so that the order of the array elements is stable across reads. This is useful for the APIs that return arrays in a nondeterministic order. Elements are ordered by number if the keys of both are numbers, otherwise by the string representation of the keys.",
+				MarkdownDescription: "A list of arrays in the `output` to be sorted, so that the order of the array elements is stable across reads. This is useful for the APIs that return arrays in a nondeterministic order. Elements are ordered by number if the keys of both are numbers, otherwise by the string representation of the keys.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Description:         "The path (in gjson syntax) to the array in the `output`. Use `@this` for the `output` itself.",
+							MarkdownDescription: "The path (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to the array in the `output`. Use `@this` for the `output` itself.",
+							Required:            true,
+						},
+						"key": schema.StringAttribute{
+							Description:         "The path (in gjson syntax) to the sort key, relative to each array element. Use `@this` for the element itself.",
+							MarkdownDescription: "The path (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to the sort key, relative to each array element. Use `@this` for the element itself.",
+							Required:            true,
+						},
+					},
+				},
+			},
 
 			"output": schema.DynamicAttribute{
 				Description:         "The response body.",
 				MarkdownDescription: "The response body.",
 				Computed:            true,
 			},
+			"output_raw": schema.StringAttribute{
+				Description:         "The raw JSON of the `output`, which keeps the exact response body (after `output_attrs` and `output_type_hints`) without round-tripping through the dynamic type.",
+				MarkdownDescription: "The raw JSON of the `output`, which keeps the exact response body (after `output_attrs` and `output_type_hints`) without round-tripping through the dynamic type.",
+				Computed:            true,
+			},
+			"expires_at": schema.StringAttribute{
+				Description:         "The expiry time (in RFC3339 format) parsed from the open response via `expiry_type` and `expiry_locator`, without subtracting the `expiry_ahead`. This is null if `expiry_type` is not specified. Note that this is not updated by the renew operation.",
+				MarkdownDescription: "The expiry time (in RFC3339 format) parsed from the open response via `expiry_type` and `expiry_locator`, without subtracting the `expiry_ahead`. This is null if `expiry_type` is not specified. Note that this is not updated by the renew operation.",
+				Computed:            true,
+			},
 		},
 	}
 	return
@@ -322,8 +440,29 @@ func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
 	if diags.HasError() {
 		return
 	}
+	if config.BodyFormat.ValueString() == "form" {
+		hasContentType := false
+		for k := range opt.Header {
+			if strings.EqualFold(k, "Content-Type") {
+				hasContentType = true
+			}
+		}
+		if !hasContentType {
+			opt.Header["Content-Type"] = "application/x-www-form-urlencoded"
+		}
+	}
+	opt.RawBody = config.OpenBodyRaw.ValueString()
+
+	var retryOpt *client.RetryOption
+	if !config.Retry.IsNull() {
+		retryOpt, diags = populateRetry(ctx, config.Retry)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+	}
 
-	response, err := c.Operation(ctx, config.Path.ValueString(), config.Body, *opt)
+	response, err := c.OperationWithRetry(ctx, config.Path.ValueString(), config.Body, *opt, retryOpt)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error to call open operation",
@@ -333,7 +472,7 @@ func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
 	}
 	if !response.IsSuccess() {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Open operation API returns %d", response.StatusCode()),
+			fmt.Sprintf("Open operation API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 			string(response.Body()),
 		)
 		return
@@ -351,11 +490,15 @@ func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
 		}
 		tflog.Info(ctx, fmt.Sprintf("renew_at=%v", t))
 		resp.RenewAt = t
+
+		// The expiry_ahead has been validated by GetExpiryTime above.
+		ahead, _ := time.ParseDuration(config.ExpiryAhead.ValueString())
+		config.ExpiresAt = types.StringValue(t.Add(ahead).Format(time.RFC3339))
 	}
 
 	// Set Renew and Close, if any
 	if !config.RenewMethod.IsNull() {
-		path, err := exparam.ExpandBodyOrPath(config.RenewPath.ValueString(), config.Path.ValueString(), response.Body())
+		path, err := exparam.ExpandBodyOrPath(config.RenewPath.ValueString(), config.Path.ValueString(), response.Body(), response.Header())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				fmt.Sprintf("Failed to build the path for renew the resource"),
@@ -371,6 +514,8 @@ func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
 			ExpiryType:    config.ExpiryType,
 			ExpiryLocator: config.ExpiryLocator,
 			ExpiryAhead:   config.ExpiryAhead,
+			OutputLocator: config.RenewOutputLocator,
+			Retry:         retryOpt,
 		}
 		b, err := json.Marshal(ed)
 		if err != nil {
@@ -387,7 +532,7 @@ func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
 	}
 
 	if !config.CloseMethod.IsNull() {
-		path, err := exparam.ExpandBodyOrPath(config.ClosePath.ValueString(), config.Path.ValueString(), response.Body())
+		path, err := exparam.ExpandBodyOrPath(config.ClosePath.ValueString(), config.Path.ValueString(), response.Body(), response.Header())
 		if err != nil {
 			resp.Diagnostics.AddError(
 				fmt.Sprintf("Failed to build the path for renew the resource"),
@@ -400,6 +545,7 @@ func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
 			Body:   config.CloseBody,
 			Header: config.CloseHeader,
 			Query:  config.CloseQuery,
+			Retry:  retryOpt,
 		}
 		b, err := json.Marshal(ed)
 		if err != nil {
@@ -436,6 +582,29 @@ func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
 		rb = []byte(fb)
 	}
 
+	rb, diags = coerceOutputTypes(ctx, config.OutputTypeHints, rb)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if !config.OutputSort.IsNull() {
+		sorts, diags := expandOutputSorts(ctx, config.OutputSort)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		sb, err := SortArraysInJSON(string(rb), sorts)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Sort `output` during operation",
+				err.Error(),
+			)
+			return
+		}
+		rb = []byte(sb)
+	}
+
 	output, err := dynamic.FromJSONImplied(rb)
 	if err != nil {
 		resp.Diagnostics.AddError(
@@ -445,6 +614,7 @@ func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest,
 		return
 	}
 	config.Output = output
+	config.OutputRaw = types.StringValue(string(rb))
 
 	diags = resp.Result.Set(ctx, config)
 	resp.Diagnostics.Append(diags...)
@@ -480,7 +650,7 @@ func (e *EphemeralResource) Renew(ctx context.Context, req ephemeral.RenewReques
 		return
 	}
 
-	response, err := c.Operation(ctx, pd.Path.ValueString(), pd.Body, *opt)
+	response, err := c.OperationWithRetry(ctx, pd.Path.ValueString(), pd.Body, *opt, pd.Retry)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error to call renew operation",
@@ -490,11 +660,19 @@ func (e *EphemeralResource) Renew(ctx context.Context, req ephemeral.RenewReques
 	}
 	if !response.IsSuccess() {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Renew operation API returns %d", response.StatusCode()),
+			fmt.Sprintf("Renew operation API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 			string(response.Body()),
 		)
 		return
 	}
+	tflog.Debug(ctx, "Renewed an ephemeral resource", map[string]interface{}{"path": pd.Path.ValueString(), "status": response.StatusCode(), "body": string(response.Body())})
+
+	if !pd.OutputLocator.IsNull() {
+		resp.Diagnostics.Append(e.applyRenewOutput(ctx, req, resp, pd, *response)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	t, err := GetExpiryTime(pd.ExpiryType.ValueString(), pd.ExpiryLocator.ValueString(), pd.ExpiryAhead.ValueString(), *response)
 	if err != nil {
@@ -534,7 +712,7 @@ func (e *EphemeralResource) Close(ctx context.Context, req ephemeral.CloseReques
 		return
 	}
 
-	response, err := c.Operation(ctx, pd.Path.ValueString(), pd.Body, *opt)
+	response, err := c.OperationWithRetry(ctx, pd.Path.ValueString(), pd.Body, *opt, pd.Retry)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error to call close operation",
@@ -544,9 +722,76 @@ func (e *EphemeralResource) Close(ctx context.Context, req ephemeral.CloseReques
 	}
 	if !response.IsSuccess() {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Close operation API returns %d", response.StatusCode()),
+			fmt.Sprintf("Close operation API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 			string(response.Body()),
 		)
 		return
 	}
+	tflog.Debug(ctx, "Closed an ephemeral resource", map[string]interface{}{"path": pd.Path.ValueString(), "status": response.StatusCode(), "body": string(response.Body())})
+}
+
+// applyRenewOutput replaces the attributes of the subsequent renew and close requests, which are kept in the private
+// data, with the value located by the `renew_output_locator` in the renew response.
+func (e *EphemeralResource) applyRenewOutput(ctx context.Context, req ephemeral.RenewRequest, resp *ephemeral.RenewResponse, pd ephemeralResourcePrivateData, response resty.Response) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	locator := pd.OutputLocator.ValueString()
+	raw, ok := LocateRenewOutput(locator, response.Body(), response.Header())
+	if !ok {
+		diags.AddWarning(
+			"Renewed value not found",
+			fmt.Sprintf("The `renew_output_locator` %q doesn't locate any value in the renew response, the subsequent requests are kept unchanged.", locator),
+		)
+		return diags
+	}
+
+	if err := pd.applyRenewOutput(locator, raw); err != nil {
+		diags.AddError(
+			"Applying the renewed value to the renew request",
+			err.Error(),
+		)
+		return diags
+	}
+	b, err := json.Marshal(pd)
+	if err != nil {
+		diags.AddError(
+			"Setting private data for renew",
+			err.Error(),
+		)
+		return diags
+	}
+	diags.Append(resp.Private.SetKey(ctx, pkRenew, b)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	b, odiags := req.Private.GetKey(ctx, pkClose)
+	diags.Append(odiags...)
+	if diags.HasError() || b == nil {
+		return diags
+	}
+	var cpd ephemeralResourcePrivateData
+	if err := json.Unmarshal(b, &cpd); err != nil {
+		diags.AddError(
+			"Unmarshal private data",
+			err.Error(),
+		)
+		return diags
+	}
+	if err := cpd.applyRenewOutput(locator, raw); err != nil {
+		diags.AddError(
+			"Applying the renewed value to the close request",
+			err.Error(),
+		)
+		return diags
+	}
+	if b, err = json.Marshal(cpd); err != nil {
+		diags.AddError(
+			"Setting private data for close",
+			err.Error(),
+		)
+		return diags
+	}
+	diags.Append(resp.Private.SetKey(ctx, pkClose, b)...)
+	return diags
 }
@@ -2,6 +2,7 @@ package provider
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -55,11 +56,36 @@ func GetExpiryTime(typ string, loc string, ahead string, resp resty.Response) (t
 			return time.Time{}, err
 		}
 		return time.Now().Add(dur).Add(-aheadDur), nil
+	case "cache-control":
+		if ok {
+			return time.Time{}, fmt.Errorf("invalid format of expiry type")
+		}
+		dur, err := parseCacheControlMaxAge(v)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Now().Add(dur).Add(-aheadDur), nil
 	default:
 		return time.Time{}, fmt.Errorf("invalid format of expiry type")
 	}
 }
 
+// parseCacheControlMaxAge parses the `max-age` directive (in seconds) from the value of the `Cache-Control` header.
+func parseCacheControlMaxAge(v string) (time.Duration, error) {
+	for _, directive := range strings.Split(v, ",") {
+		k, dv, ok := strings.Cut(strings.TrimSpace(directive), "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(k), "max-age") {
+			continue
+		}
+		sec, err := strconv.ParseInt(strings.Trim(strings.TrimSpace(dv), `"`), 10, 64)
+		if err != nil || sec < 0 {
+			return 0, fmt.Errorf("invalid max-age directive: %s", directive)
+		}
+		return time.Duration(sec) * time.Second, nil
+	}
+	return 0, fmt.Errorf("no max-age directive found in %q", v)
+}
+
 func validateExpiryType(v string) error {
 	l, r, ok := strings.Cut(v, ".")
 	switch l {
@@ -69,7 +95,7 @@ func validateExpiryType(v string) error {
 				return err
 			}
 		}
-	case "duration":
+	case "duration", "cache-control":
 		if ok {
 			return fmt.Errorf("invalid format of expiry type")
 		}
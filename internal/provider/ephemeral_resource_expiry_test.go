@@ -0,0 +1,55 @@
+package provider
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCacheControlMaxAge(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect time.Duration
+		err    bool
+	}{
+		{
+			name:   "max-age only",
+			input:  "max-age=3600",
+			expect: time.Hour,
+		},
+		{
+			name:   "multiple directives",
+			input:  "no-cache, Max-Age=60, must-revalidate",
+			expect: time.Minute,
+		},
+		{
+			name:   "quoted",
+			input:  `private, max-age="30"`,
+			expect: 30 * time.Second,
+		},
+		{
+			name:  "no max-age",
+			input: "no-store",
+			err:   true,
+		},
+		{
+			name:  "invalid max-age",
+			input: "max-age=abc",
+			err:   true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := parseCacheControlMaxAge(tt.input)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, actual)
+		})
+	}
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/magodo/terraform-provider-restful/internal/client"
 	"github.com/magodo/terraform-provider-restful/internal/dynamic"
 )
 
@@ -23,6 +25,10 @@ type ephemeralResourcePrivateData struct {
 	ExpiryAhead   types.String
 	ExpiryType    types.String
 	ExpiryLocator types.String
+
+	OutputLocator types.String
+
+	Retry *client.RetryOption
 }
 
 type ephemeralResourcePrivateDataGo struct {
@@ -37,6 +43,19 @@ type ephemeralResourcePrivateDataGo struct {
 	ExpiryAhead   string `json:"expiry_ahead,omitempty"`
 	ExpiryType    string `json:"expiry_type,omitempty"`
 	ExpiryLocator string `json:"expiry_locator,omitempty"`
+
+	OutputLocator string `json:"output_locator,omitempty"`
+
+	Retry *retryOptionGo `json:"retry,omitempty"`
+}
+
+type retryOptionGo struct {
+	StatusCodes []int64       `json:"status_codes,omitempty"`
+	Count       int           `json:"count"`
+	WaitTime    time.Duration `json:"wait_time"`
+	MaxWaitTime time.Duration `json:"max_wait_time"`
+
+	NoConnectionErrorRetry bool `json:"no_connection_error_retry,omitempty"`
 }
 
 func (d ephemeralResourcePrivateData) MarshalJSON() ([]byte, error) {
@@ -46,6 +65,18 @@ func (d ephemeralResourcePrivateData) MarshalJSON() ([]byte, error) {
 		ExpiryAhead:   d.ExpiryAhead.ValueString(),
 		ExpiryType:    d.ExpiryType.ValueString(),
 		ExpiryLocator: d.ExpiryLocator.ValueString(),
+		OutputLocator: d.OutputLocator.ValueString(),
+	}
+
+	if d.Retry != nil {
+		dg.Retry = &retryOptionGo{
+			StatusCodes: d.Retry.StatusCodes,
+			Count:       d.Retry.Count,
+			WaitTime:    d.Retry.WaitTime,
+			MaxWaitTime: d.Retry.MaxWaitTime,
+
+			NoConnectionErrorRetry: d.Retry.NoConnectionErrorRetry,
+		}
 	}
 
 	var err error
@@ -122,6 +153,23 @@ func (d *ephemeralResourcePrivateData) UnmarshalJSON(b []byte) error {
 	}
 	d.ExpiryLocator = expiryLocator
 
+	outputLocator := types.StringNull()
+	if dg.OutputLocator != "" {
+		outputLocator = types.StringValue(dg.OutputLocator)
+	}
+	d.OutputLocator = outputLocator
+
+	if dg.Retry != nil {
+		d.Retry = &client.RetryOption{
+			StatusCodes: dg.Retry.StatusCodes,
+			Count:       dg.Retry.Count,
+			WaitTime:    dg.Retry.WaitTime,
+			MaxWaitTime: dg.Retry.MaxWaitTime,
+
+			NoConnectionErrorRetry: dg.Retry.NoConnectionErrorRetry,
+		}
+	}
+
 	return nil
 }
 
@@ -5,9 +5,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/terraform-provider-restful/internal/client"
 	"github.com/stretchr/testify/require"
 )
 
@@ -39,6 +41,13 @@ func TestMarshalEphemeralResourcePrivateData(t *testing.T) {
 				),
 				ExpiryType:    types.StringValue("et"),
 				ExpiryLocator: types.StringValue("el"),
+				OutputLocator: types.StringValue("body.token"),
+				Retry: &client.RetryOption{
+					StatusCodes: []int64{429},
+					Count:       3,
+					WaitTime:    time.Second,
+					MaxWaitTime: time.Minute,
+				},
 			},
 			expect: fmt.Sprintf(`
 {
@@ -58,7 +67,14 @@ func TestMarshalEphemeralResourcePrivateData(t *testing.T) {
     "q1": ["v1"]
   },
   "expiry_type": "et",
-  "expiry_locator": "el"
+  "expiry_locator": "el",
+  "output_locator": "body.token",
+  "retry": {
+    "status_codes": [429],
+    "count": 3,
+    "wait_time": 1000000000,
+    "max_wait_time": 60000000000
+  }
 }`, base64.StdEncoding.EncodeToString([]byte(`{"foo":"bar"}`))),
 		},
 
@@ -114,7 +130,14 @@ func TestUnMarshalEphemeralResourcePrivateData(t *testing.T) {
     "q1": ["v1"]
   },
   "expiry_type": "et",
-  "expiry_locator": "el"
+  "expiry_locator": "el",
+  "output_locator": "body.token",
+  "retry": {
+    "status_codes": [429],
+    "count": 3,
+    "wait_time": 1000000000,
+    "max_wait_time": 60000000000
+  }
 }`, base64.StdEncoding.EncodeToString([]byte(`{"foo":"bar"}`))),
 			expect: ephemeralResourcePrivateData{
 				Method:        types.StringValue("POST"),
@@ -136,6 +159,13 @@ func TestUnMarshalEphemeralResourcePrivateData(t *testing.T) {
 				),
 				ExpiryType:    types.StringValue("et"),
 				ExpiryLocator: types.StringValue("el"),
+				OutputLocator: types.StringValue("body.token"),
+				Retry: &client.RetryOption{
+					StatusCodes: []int64{429},
+					Count:       3,
+					WaitTime:    time.Second,
+					MaxWaitTime: time.Minute,
+				},
 			},
 		},
 
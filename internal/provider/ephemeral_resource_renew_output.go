@@ -0,0 +1,77 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/magodo/terraform-provider-restful/internal/dynamic"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+// validateRenewOutputLocator validates the `renew_output_locator`, which is either `body.<path>` or `header.<name>`.
+func validateRenewOutputLocator(locator string) error {
+	scope, name, ok := strings.Cut(locator, ".")
+	if !ok || name == "" || (scope != "body" && scope != "header") {
+		return fmt.Errorf("expect `body.<path>` or `header.<name>`, got %q", locator)
+	}
+	return nil
+}
+
+// LocateRenewOutput returns the raw JSON value located by the `renew_output_locator` in the renew response.
+func LocateRenewOutput(locator string, body []byte, header http.Header) (string, bool) {
+	scope, name, _ := strings.Cut(locator, ".")
+	switch scope {
+	case "body":
+		res := gjson.GetBytes(body, name)
+		if !res.Exists() {
+			return "", false
+		}
+		return res.Raw, true
+	case "header":
+		v := header.Get(name)
+		if v == "" {
+			return "", false
+		}
+		return fmt.Sprintf("%q", v), true
+	}
+	return "", false
+}
+
+// applyRenewOutput replaces the attribute of the same locator in the request body (for `body.<path>`) or header
+// (for `header.<name>`) with the renewed value, if the attribute exists in the request.
+func (d *ephemeralResourcePrivateData) applyRenewOutput(locator, raw string) error {
+	scope, name, _ := strings.Cut(locator, ".")
+	switch scope {
+	case "body":
+		if d.Body.IsNull() {
+			return nil
+		}
+		b, err := dynamic.ToJSON(d.Body)
+		if err != nil {
+			return fmt.Errorf("convert dynamic body to json: %v", err)
+		}
+		if !gjson.GetBytes(b, name).Exists() {
+			return nil
+		}
+		b, err = sjson.SetRawBytes(b, name, []byte(raw))
+		if err != nil {
+			return fmt.Errorf("setting %q: %v", name, err)
+		}
+		body, err := dynamic.FromJSONImplied(b)
+		if err != nil {
+			return fmt.Errorf("convert dynamic body from json: %v", err)
+		}
+		d.Body = body
+	case "header":
+		header := headerToGo(d.Header)
+		for k := range header {
+			if strings.EqualFold(k, name) {
+				header[k] = gjson.Parse(raw).String()
+			}
+		}
+		d.Header = headerFromGo(header)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/terraform-provider-restful/internal/dynamic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocateRenewOutput(t *testing.T) {
+	body := []byte(`{"token": "t2", "expires_in": 3600}`)
+	header := http.Header{"X-Token": []string{"h2"}}
+
+	cases := []struct {
+		name    string
+		locator string
+		expect  string
+		ok      bool
+	}{
+		{
+			name:    "body string",
+			locator: "body.token",
+			expect:  `"t2"`,
+			ok:      true,
+		},
+		{
+			name:    "body number",
+			locator: "body.expires_in",
+			expect:  `3600`,
+			ok:      true,
+		},
+		{
+			name:    "header",
+			locator: "header.x-token",
+			expect:  `"h2"`,
+			ok:      true,
+		},
+		{
+			name:    "absent",
+			locator: "body.refresh_token",
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, ok := LocateRenewOutput(tt.locator, body, header)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestApplyRenewOutput(t *testing.T) {
+	cases := []struct {
+		name         string
+		body         string
+		header       map[string]string
+		locator      string
+		raw          string
+		expectBody   string
+		expectHeader map[string]string
+	}{
+		{
+			name:         "body",
+			body:         `{"grant_type": "refresh_token", "refresh_token": "t1"}`,
+			locator:      "body.refresh_token",
+			raw:          `"t2"`,
+			expectBody:   `{"grant_type": "refresh_token", "refresh_token": "t2"}`,
+			expectHeader: map[string]string{},
+		},
+		{
+			name:         "body attribute absent",
+			body:         `{"grant_type": "refresh_token"}`,
+			locator:      "body.refresh_token",
+			raw:          `"t2"`,
+			expectBody:   `{"grant_type": "refresh_token"}`,
+			expectHeader: map[string]string{},
+		},
+		{
+			name:         "header",
+			header:       map[string]string{"X-Token": "t1", "Accept": "application/json"},
+			locator:      "header.x-token",
+			raw:          `"t2"`,
+			expectHeader: map[string]string{"X-Token": "t2", "Accept": "application/json"},
+		},
+		{
+			name:         "header absent",
+			header:       map[string]string{"Accept": "application/json"},
+			locator:      "header.X-Token",
+			raw:          `"t2"`,
+			expectHeader: map[string]string{"Accept": "application/json"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			d := ephemeralResourcePrivateData{
+				Body:   types.DynamicNull(),
+				Header: headerFromGo(tt.header),
+			}
+			if tt.body != "" {
+				body, err := dynamic.FromJSONImplied([]byte(tt.body))
+				require.NoError(t, err)
+				d.Body = body
+			}
+			require.NoError(t, d.applyRenewOutput(tt.locator, tt.raw))
+			if tt.expectBody != "" {
+				b, err := dynamic.ToJSON(d.Body)
+				require.NoError(t, err)
+				require.JSONEq(t, tt.expectBody, string(b))
+			} else {
+				require.True(t, d.Body.IsNull())
+			}
+			require.Equal(t, tt.expectHeader, headerToGo(d.Header))
+		})
+	}
+}
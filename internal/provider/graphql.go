@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/terraform-provider-restful/internal/dynamic"
+	"github.com/tidwall/gjson"
+)
+
+type graphqlData struct {
+	Query         types.String  `tfsdk:"query"`
+	Variables     types.Dynamic `tfsdk:"variables"`
+	OperationName types.String  `tfsdk:"operation_name"`
+	SelectData    types.Bool    `tfsdk:"select_data"`
+}
+
+// BuildGraphQLBody builds the GraphQL request body, in form of `{"query": ..., "variables": ..., "operationName": ...}`.
+func BuildGraphQLBody(d graphqlData) (types.Dynamic, error) {
+	body := map[string]json.RawMessage{}
+
+	b, err := json.Marshal(d.Query.ValueString())
+	if err != nil {
+		return types.Dynamic{}, err
+	}
+	body["query"] = b
+
+	if !d.Variables.IsNull() && !d.Variables.IsUnderlyingValueNull() {
+		b, err := dynamic.ToJSON(d.Variables)
+		if err != nil {
+			return types.Dynamic{}, fmt.Errorf("converting `variables` to JSON: %v", err)
+		}
+		body["variables"] = b
+	}
+
+	if !d.OperationName.IsNull() {
+		b, err := json.Marshal(d.OperationName.ValueString())
+		if err != nil {
+			return types.Dynamic{}, err
+		}
+		body["operationName"] = b
+	}
+
+	b, err = json.Marshal(body)
+	if err != nil {
+		return types.Dynamic{}, err
+	}
+	return dynamic.FromJSONImplied(b)
+}
+
+// GraphQLErrors returns an error that contains the messages of the `errors` in the GraphQL response body, if any.
+func GraphQLErrors(body []byte) error {
+	errs := gjson.GetBytes(body, "errors")
+	if !errs.IsArray() || len(errs.Array()) == 0 {
+		return nil
+	}
+	var msgs []string
+	for _, e := range errs.Array() {
+		if msg := e.Get("message"); msg.Exists() {
+			msgs = append(msgs, msg.String())
+		} else {
+			msgs = append(msgs, e.Raw)
+		}
+	}
+	return fmt.Errorf("%s", strings.Join(msgs, "\n"))
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/terraform-provider-restful/internal/dynamic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildGraphQLBody(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  graphqlData
+		expect string
+	}{
+		{
+			name: "query only",
+			input: graphqlData{
+				Query:         types.StringValue("{ viewer { login } }"),
+				Variables:     types.DynamicNull(),
+				OperationName: types.StringNull(),
+			},
+			expect: `{"query": "{ viewer { login } }"}`,
+		},
+		{
+			name: "all set",
+			input: graphqlData{
+				Query: types.StringValue("query Q($id: ID!) { node(id: $id) { id } }"),
+				Variables: types.DynamicValue(types.ObjectValueMust(
+					map[string]attr.Type{"id": types.StringType},
+					map[string]attr.Value{"id": types.StringValue("abc")},
+				)),
+				OperationName: types.StringValue("Q"),
+			},
+			expect: `{"query": "query Q($id: ID!) { node(id: $id) { id } }", "variables": {"id": "abc"}, "operationName": "Q"}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := BuildGraphQLBody(tt.input)
+			require.NoError(t, err)
+			b, err := dynamic.ToJSON(body)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expect, string(b))
+		})
+	}
+}
+
+func TestGraphQLErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		input  string
+		expect string
+	}{
+		{
+			name:  "no errors",
+			input: `{"data": {"id": 1}}`,
+		},
+		{
+			name:  "empty errors",
+			input: `{"data": {"id": 1}, "errors": []}`,
+		},
+		{
+			name:   "errors",
+			input:  `{"data": null, "errors": [{"message": "foo"}, {"message": "bar"}]}`,
+			expect: "foo\nbar",
+		},
+		{
+			name:   "error without message",
+			input:  `{"errors": [{"code": 1}]}`,
+			expect: `{"code": 1}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			err := GraphQLErrors([]byte(tt.input))
+			if tt.expect == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tt.expect)
+		})
+	}
+}
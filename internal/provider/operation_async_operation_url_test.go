@@ -0,0 +1,105 @@
+package provider_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// asyncOperationServer is an API that starts an async operation for each call, whose URL is returned in the `Location`
+// header. The operation is pending for a number of polls.
+type asyncOperationServer struct {
+	mu       sync.Mutex
+	pendings int
+	ops      int
+	polls    map[string]int
+}
+
+func (s *asyncOperationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if strings.HasPrefix(r.URL.Path, "/operations/") {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+		s.polls[r.URL.Path]++
+		w.Header().Set("Retry-After", "0")
+		if s.polls[r.URL.Path] <= s.pendings {
+			w.WriteHeader(http.StatusAccepted)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	// Polling the operation path instead of the async operation URL is unexpected.
+	if r.Method == http.MethodGet {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.ops++
+	w.Header().Set("Location", fmt.Sprintf("/operations/%d", s.ops))
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func TestOperation_PollAsyncOperationURL(t *testing.T) {
+	srv := &asyncOperationServer{pendings: 2, polls: map[string]int{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	expectPolls := func(expect map[string]int) func(*terraform.State) error {
+		return func(*terraform.State) error {
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if fmt.Sprint(srv.polls) != fmt.Sprint(expect) {
+				return fmt.Errorf("expect polls %v, got %v", expect, srv.polls)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		CheckDestroy:             expectPolls(map[string]int{"/operations/1": 3, "/operations/2": 3}),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_operation" "test" {
+  path                     = "/jobs"
+  method                   = "POST"
+  delete_method            = "DELETE"
+  poll_async_operation_url = true
+  poll = {
+    status_locator = "code"
+    status = {
+      success = "200"
+      pending = ["202"]
+    }
+  }
+  poll_delete = {
+    status_locator = "code"
+    status = {
+      success = "200"
+      pending = ["202"]
+    }
+  }
+}
+`, ts.URL),
+				Check: expectPolls(map[string]int{"/operations/1": 3}),
+			},
+		},
+	})
+}
@@ -0,0 +1,66 @@
+package provider_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// TestOperation_ChainByHeader chains two operations, where the second one passes the session token returned in the
+// response header of the first one.
+func TestOperation_ChainByHeader(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/sessions":
+			w.Header().Set("X-Session-Token", "secret")
+			w.WriteHeader(http.StatusCreated)
+		case "/jobs":
+			if r.Header.Get("X-Session-Token") != "secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"status": "Succeeded"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_operation" "session" {
+  path   = "/sessions"
+  method = "POST"
+}
+
+resource "restful_operation" "job" {
+  path   = "/jobs"
+  method = "POST"
+  header = {
+    X-Session-Token = restful_operation.session.output_header["X-Session-Token"]
+  }
+}
+`, srv.URL),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue("restful_operation.session", tfjsonpath.New("output_header").AtMapKey("X-Session-Token"), knownvalue.StringExact("secret")),
+					statecheck.ExpectKnownValue("restful_operation.job", tfjsonpath.New("output").AtMapKey("status"), knownvalue.StringExact("Succeeded")),
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,49 @@
+package provider_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+func TestOperation_ExpectedStatusCodes(t *testing.T) {
+	addr := "restful_operation.test"
+
+	// The API returns an error envelope when the item already exists.
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		w.Write([]byte(`{"error": {"code": "AlreadyExists"}}`))
+	}))
+	defer srv.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_operation" "test" {
+  path                  = "/items"
+  method                = "POST"
+  body                  = {}
+  expected_status_codes = ["2xx", "409"]
+}
+`, srv.URL),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output").AtMapKey("error").AtMapKey("code"), knownvalue.StringExact("AlreadyExists")),
+				},
+			},
+		},
+	})
+}
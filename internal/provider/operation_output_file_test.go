@@ -0,0 +1,67 @@
+package provider_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+func TestOperation_OutputFile(t *testing.T) {
+	addr := "restful_operation.test"
+	content := []byte{0x1f, 0x8b, 0x08, 0x00, 0xff, 0x00}
+	sum := sha256.Sum256(content)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/gzip")
+		w.Write(content)
+	}))
+	defer srv.Close()
+
+	outputFile := filepath.Join(t.TempDir(), "export.tar.gz")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_operation" "test" {
+  path        = "/exports"
+  method      = "POST"
+  output_file = %q
+}
+`, srv.URL, outputFile),
+				Check: func(*terraform.State) error {
+					b, err := os.ReadFile(outputFile)
+					if err != nil {
+						return err
+					}
+					if !bytes.Equal(b, content) {
+						return fmt.Errorf("unexpected content of the output file: %v", b)
+					}
+					return nil
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output_file_sha256"), knownvalue.StringExact(hex.EncodeToString(sum[:]))),
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output"), knownvalue.Null()),
+				},
+			},
+		},
+	})
+}
@@ -2,9 +2,18 @@ package provider
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
 
+	"github.com/go-resty/resty/v2"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -22,6 +31,7 @@ import (
 	"github.com/magodo/terraform-provider-restful/internal/dynamic"
 	"github.com/magodo/terraform-provider-restful/internal/exparam"
 	myvalidator "github.com/magodo/terraform-provider-restful/internal/validator"
+	"github.com/tidwall/gjson"
 )
 
 type OperationResource struct {
@@ -30,13 +40,26 @@ type OperationResource struct {
 
 var _ resource.Resource = &OperationResource{}
 var _ resource.ResourceWithUpgradeState = &OperationResource{}
+var _ resource.ResourceWithImportState = &OperationResource{}
+
+// methodTokenRegexp matches the uppercase HTTP method tokens, which allows the non-standard methods like the WebDAV `PROPFIND`.
+var methodTokenRegexp = regexp.MustCompile(`^[A-Z][A-Z0-9_-]*$`)
 
 type operationResourceData struct {
-	ID        types.String  `tfsdk:"id"`
-	Path      types.String  `tfsdk:"path"`
-	IdBuilder types.String  `tfsdk:"id_builder"`
-	Method    types.String  `tfsdk:"method"`
-	Body      types.Dynamic `tfsdk:"body"`
+	ID           types.String  `tfsdk:"id"`
+	Path         types.String  `tfsdk:"path"`
+	IdBuilder    types.String  `tfsdk:"id_builder"`
+	Method       types.String  `tfsdk:"method"`
+	UpdateMethod types.String  `tfsdk:"update_method"`
+	UpdatePath   types.String  `tfsdk:"update_path"`
+	Body         types.Dynamic `tfsdk:"body"`
+	Triggers     types.Map     `tfsdk:"triggers"`
+	GraphQL      types.Object  `tfsdk:"graphql"`
+
+	ExpectedStatusCodes types.List `tfsdk:"expected_status_codes"`
+
+	BodyFile            types.String `tfsdk:"body_file"`
+	BodyFileContentType types.String `tfsdk:"body_file_content_type"`
 
 	Query          types.Map `tfsdk:"query"`
 	OperationQuery types.Map `tfsdk:"operation_query"`
@@ -46,15 +69,31 @@ type operationResourceData struct {
 	OperationHeader types.Map `tfsdk:"operation_header"`
 	DeleteHeader    types.Map `tfsdk:"delete_header"`
 
-	Precheck       types.List    `tfsdk:"precheck"`
-	Poll           types.Object  `tfsdk:"poll"`
-	DeleteMethod   types.String  `tfsdk:"delete_method"`
-	DeleteBody     types.Dynamic `tfsdk:"delete_body"`
-	DeletePath     types.String  `tfsdk:"delete_path"`
-	PrecheckDelete types.List    `tfsdk:"precheck_delete"`
-	PollDelete     types.Object  `tfsdk:"poll_delete"`
-	OutputAttrs    types.Set     `tfsdk:"output_attrs"`
-	Output         types.Dynamic `tfsdk:"output"`
+	Precheck              types.List    `tfsdk:"precheck"`
+	Poll                  types.Object  `tfsdk:"poll"`
+	DeleteMethod          types.String  `tfsdk:"delete_method"`
+	DeleteBody            types.Dynamic `tfsdk:"delete_body"`
+	DeletePath            types.String  `tfsdk:"delete_path"`
+	PrecheckDelete        types.List    `tfsdk:"precheck_delete"`
+	PollDelete            types.Object  `tfsdk:"poll_delete"`
+	PollAsyncOperationURL types.Bool    `tfsdk:"poll_async_operation_url"`
+	WaitFor               types.Object  `tfsdk:"wait_for"`
+	WaitForDelete         types.Object  `tfsdk:"wait_for_delete"`
+	OutputAttrs           types.Set     `tfsdk:"output_attrs"`
+	OutputTypeHints       types.Map     `tfsdk:"output_type_hints"`
+	OutputSort            types.List    `tfsdk:"output_sort"`
+	Output                types.Dynamic `tfsdk:"output"`
+	OutputRaw             types.String  `tfsdk:"output_raw"`
+	OutputSHA256          types.String  `tfsdk:"output_sha256"`
+	OutputHeader          types.Map     `tfsdk:"output_header"`
+	OutputTrailer         types.Map     `tfsdk:"output_trailer"`
+
+	OutputFile       types.String `tfsdk:"output_file"`
+	OutputFileSHA256 types.String `tfsdk:"output_file_sha256"`
+
+	LogLevel types.String `tfsdk:"log_level"`
+
+	LastRequestDurationMs types.Int64 `tfsdk:"last_request_duration_ms"`
 }
 
 func (r *OperationResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -68,6 +107,9 @@ func (r *OperationResource) Schema(ctx context.Context, req resource.SchemaReque
 	pollDelete := pollAttribute("`Delete`")
 	pollDelete.Validators = append(pollDelete.Validators, objectvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("delete_method")))
 
+	waitForDelete := waitForDeleteAttribute()
+	waitForDelete.Validators = append(waitForDelete.Validators, objectvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("delete_method")))
+
 	resp.Schema = schema.Schema{
 		Description:         "`restful_operation` represents a one-time API call operation.",
 		MarkdownDescription: "`restful_operation` represents a one-time API call operation.",
@@ -91,25 +133,90 @@ func (r *OperationResource) Schema(ctx context.Context, req resource.SchemaReque
 			},
 			// This is actually the same as the `read_path` of restful_resource, besides the name
 			"id_builder": schema.StringAttribute{
-				Description:         "The pattern used to build the `id`. The `path` is used as the `id` instead if absent." + pathDescription,
-				MarkdownDescription: "The pattern used to build the `id`. The `path` is used as the `id` instead if absent." + pathDescription,
+				Description:         "The pattern used to build the `id`. The `path` is used as the `id` instead if absent." + pathDescription + headerParamDescription,
+				MarkdownDescription: "The pattern used to build the `id`. The `path` is used as the `id` instead if absent." + pathDescription + headerParamDescription,
 				Optional:            true,
 				Validators: []validator.String{
 					myvalidator.StringIsPathBuilder(),
 				},
 			},
 			"method": schema.StringAttribute{
-				Description:         "The HTTP method for the `Create`/`Update` call. Possible values are `GET`, `PUT`, `POST`, `PATCH` and `DELETE`.",
-				MarkdownDescription: "The HTTP method for the `Create`/`Update` call. Possible values are `GET`, `PUT`, `POST`, `PATCH` and `DELETE`.",
+				Description:         "The HTTP method for the `Create`/`Update` call. Common values are `GET`, `PUT`, `POST`, `PATCH` and `DELETE`, while any uppercase method token is accepted (e.g. the WebDAV `PROPFIND` and `MKCOL`).",
+				MarkdownDescription: "The HTTP method for the `Create`/`Update` call. Common values are `GET`, `PUT`, `POST`, `PATCH` and `DELETE`, while any uppercase method token is accepted (e.g. the WebDAV `PROPFIND` and `MKCOL`).",
 				Required:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("GET", "PUT", "POST", "PATCH", "DELETE"),
+					stringvalidator.RegexMatches(methodTokenRegexp, "must be an uppercase HTTP method token"),
+				},
+			},
+			"update_method": schema.StringAttribute{
+				Description:         "The HTTP method for the `Update` call. The `method` is used instead if `update_method` is absent.",
+				MarkdownDescription: "The HTTP method for the `Update` call. The `method` is used instead if `update_method` is absent.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(methodTokenRegexp, "must be an uppercase HTTP method token"),
+				},
+			},
+			"update_path": schema.StringAttribute{
+				Description:         "The path for the `Update` call, relative to the `base_url` of the provider. The `path` is used instead if `update_path` is absent. The body param below refers to the `output` of the previous call." + pathDescription,
+				MarkdownDescription: "The path for the `Update` call, relative to the `base_url` of the provider. The `path` is used instead if `update_path` is absent. The body param below refers to the `output` of the previous call." + pathDescription,
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsPathBuilderWithoutHeader(),
 				},
 			},
 			"body": schema.DynamicAttribute{
-				Description:         "The payload for the `Create`/`Update` call.",
-				MarkdownDescription: "The payload for the `Create`/`Update` call.",
+				Description:         "The payload for the `Create`/`Update` call. If absent, no payload is sent (neither is the default `Content-Type: application/json` header). Note that an empty object (`{}`) is sent as is.",
+				MarkdownDescription: "The payload for the `Create`/`Update` call. If absent, no payload is sent (neither is the default `Content-Type: application/json` header). Note that an empty object (`{}`) is sent as is.",
+				Optional:            true,
+			},
+			"body_file": schema.StringAttribute{
+				Description:         "The path to a file, whose content is sent as the raw payload for the `Create`/`Update` call, e.g. uploading a binary artifact. As changing the file content doesn't change this attribute, consider to add the file hash (e.g. `filesha256()`) to the `triggers` to re-run the operation on content change.",
+				MarkdownDescription: "The path to a file, whose content is sent as the raw payload for the `Create`/`Update` call, e.g. uploading a binary artifact. As changing the file content doesn't change this attribute, consider to add the file hash (e.g. `filesha256()`) to the `triggers` to re-run the operation on content change.",
 				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(
+						path.MatchRoot("body"),
+						path.MatchRoot("graphql"),
+					),
+				},
+			},
+			"body_file_content_type": schema.StringAttribute{
+				Description:         "The `Content-Type` of the `body_file`. Defaults to `application/octet-stream`.",
+				MarkdownDescription: "The `Content-Type` of the `body_file`. Defaults to `application/octet-stream`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("body_file")),
+				},
+			},
+			"graphql": schema.SingleNestedAttribute{
+				Description:         "The GraphQL request, which is used to build the payload for the `Create`/`Update` call. The `method` is expected to be `POST`. The `errors` in the GraphQL response are regarded as a failure, even if the HTTP status code indicates a success.",
+				MarkdownDescription: "The GraphQL request, which is used to build the payload for the `Create`/`Update` call. The `method` is expected to be `POST`. The `errors` in the GraphQL response are regarded as a failure, even if the HTTP status code indicates a success.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"query": schema.StringAttribute{
+						Description:         "The GraphQL query (or mutation) document.",
+						MarkdownDescription: "The GraphQL query (or mutation) document.",
+						Required:            true,
+					},
+					"variables": schema.DynamicAttribute{
+						Description:         "The variables of the GraphQL query.",
+						MarkdownDescription: "The variables of the GraphQL query.",
+						Optional:            true,
+					},
+					"operation_name": schema.StringAttribute{
+						Description:         "The name of the operation to execute, when the `query` contains multiple operations.",
+						MarkdownDescription: "The name of the operation to execute, when the `query` contains multiple operations.",
+						Optional:            true,
+					},
+					"select_data": schema.BoolAttribute{
+						Description:         "Whether to only export the `data` of the GraphQL response in the `output`. Defaults to `false`.",
+						MarkdownDescription: "Whether to only export the `data` of the GraphQL response in the `output`. Defaults to `false`.",
+						Optional:            true,
+					},
+				},
+				Validators: []validator.Object{
+					objectvalidator.ConflictsWith(path.MatchRoot("body")),
+				},
 			},
 			"query": schema.MapAttribute{
 				Description:         "The query parameters that are applied to each request. This overrides the `query` set in the provider block.",
@@ -148,15 +255,38 @@ func (r *OperationResource) Schema(ctx context.Context, req resource.SchemaReque
 				Optional:            true,
 			},
 
+			"triggers": schema.MapAttribute{
+				Description:         "A map of arbitrary strings that, when changed, will re-run the operation (i.e. the `Update` call), e.g. a hash of some content that the operation depends on.",
+				MarkdownDescription: "A map of arbitrary strings that, when changed, will re-run the operation (i.e. the `Update` call), e.g. a hash of some content that the operation depends on.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+
+			"expected_status_codes": schema.ListAttribute{
+				Description:         "The status codes of the `Create`/`Update` call that are regarded as success, e.g. an error envelope that is deliberately expected. Each element can be an exact code (e.g. `409`), a wildcard (e.g. `2xx`), a range (e.g. `400-404`) or a comparison (e.g. `>=500`). The response body of an expected status code is parsed into the `output` as well. Defaults to any `2xx`.",
+				MarkdownDescription: "The status codes of the `Create`/`Update` call that are regarded as success, e.g. an error envelope that is deliberately expected. Each element can be an exact code (e.g. `409`), a wildcard (e.g. `2xx`), a range (e.g. `400-404`) or a comparison (e.g. `>=500`). The response body of an expected status code is parsed into the `output` as well. Defaults to any `2xx`.",
+				ElementType:         types.StringType,
+				Optional:            true,
+				Validators: []validator.List{
+					listvalidator.ValueStringsAre(
+						myvalidator.StringIsParsable("status code pattern", func(s string) error {
+							_, err := client.ParseStatusCodePattern(s)
+							return err
+						}),
+					),
+				},
+			},
+
 			"precheck": precheckAttribute("`Create`/`Update`", true, "", false),
 			"poll":     pollAttribute("`Create`/`Update`"),
+			"wait_for": waitForAttribute("`Create`/`Update`", "By default, the `id` of this resource is used."),
 
 			"delete_method": schema.StringAttribute{
-				Description:         "The method for the `Delete` call. Possible values are `POST`, `PUT`, `PATCH` and `DELETE`. If this is not specified, no `Delete` call will occur.",
-				MarkdownDescription: "The method for the `Delete` call. Possible values are `POST`, `PUT`, `PATCH` and `DELETE`. If this is not specified, no `Delete` call will occur.",
+				Description:         "The method for the `Delete` call. Common values are `POST`, `PUT`, `PATCH` and `DELETE`, while any uppercase method token is accepted. If this is not specified, no `Delete` call will occur.",
+				MarkdownDescription: "The method for the `Delete` call. Common values are `POST`, `PUT`, `PATCH` and `DELETE`, while any uppercase method token is accepted. If this is not specified, no `Delete` call will occur.",
 				Optional:            true,
 				Validators: []validator.String{
-					stringvalidator.OneOf("POST", "PUT", "PATCH", "DELETE"),
+					stringvalidator.RegexMatches(methodTokenRegexp, "must be an uppercase HTTP method token"),
 				},
 			},
 
@@ -166,7 +296,7 @@ func (r *OperationResource) Schema(ctx context.Context, req resource.SchemaReque
 				Optional:            true,
 				Validators: []validator.String{
 					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("delete_method")),
-					myvalidator.StringIsPathBuilder(),
+					myvalidator.StringIsPathBuilderWithoutHeader(),
 				},
 			},
 
@@ -178,6 +308,22 @@ func (r *OperationResource) Schema(ctx context.Context, req resource.SchemaReque
 
 			"precheck_delete": precheckDelete,
 			"poll_delete":     pollDelete,
+			"wait_for_delete": waitForDelete,
+
+			"poll_async_operation_url": schema.BoolAttribute{
+				Description:         "Whether to poll the URL returned in the `Operation-Location`, `Azure-AsyncOperation` or `Location` header (in this order), when the `url_locator` of `poll` (or `poll_delete`) is absent and the call returns `202 Accepted` with such a header. Otherwise, the resource id (or the `Delete` call's URL) is polled. Defaults to `false`.",
+				MarkdownDescription: "Whether to poll the URL returned in the `Operation-Location`, `Azure-AsyncOperation` or `Location` header (in this order), when the `url_locator` of `poll` (or `poll_delete`) is absent and the call returns `202 Accepted` with such a header. Otherwise, the resource id (or the `Delete` call's URL) is polled. Defaults to `false`.",
+				Optional:            true,
+			},
+
+			"log_level": schema.StringAttribute{
+				Description:         "The log level at which the request and response dumps of this resource are emitted, which are emitted at the `DEBUG` level by default. Possible values are `INFO`, `WARN` and `ERROR`. For example, setting it to `INFO` makes them visible with `TF_LOG=INFO`, without the debug logs of the other resources. This is useful for debugging a single resource.",
+				MarkdownDescription: "The log level at which the request and response dumps of this resource are emitted, which are emitted at the `DEBUG` level by default. Possible values are `INFO`, `WARN` and `ERROR`. For example, setting it to `INFO` makes them visible with `TF_LOG=INFO`, without the debug logs of the other resources. This is useful for debugging a single resource.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("INFO", "WARN", "ERROR"),
+				},
+			},
 
 			"output_attrs": schema.SetAttribute{
 				Description:         "A set of `output` attribute paths (in gjson syntax) that will be exported in the `output`. If this is not specified, all attributes will be exported by `output`.",
@@ -185,12 +331,77 @@ func (r *OperationResource) Schema(ctx context.Context, req resource.SchemaReque
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"output_type_hints": schema.MapAttribute{
+				Description:         outputTypeHintsDescription,
+				MarkdownDescription: outputTypeHintsMarkdownDescription,
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(outputTypeString, outputTypeNumber, outputTypeBool)),
+				},
+			},
+			"output_sort": schema.ListNestedAttribute{
+				Description:         "A list of arrays in the `output` to be sorted, so that the order of the array elements is stable across reads. This is useful for the APIs that return arrays in a nondeterministic order. Elements are ordered by number if the keys of both are numbers, otherwise by the string representation of the keys.",
+				MarkdownDescription: "A list of arrays in the `output` to be sorted, so that the order of the array elements is stable across reads. This is useful for the APIs that return arrays in a nondeterministic order. Elements are ordered by number if the keys of both are numbers, otherwise by the string representation of the keys.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Description:         "The path (in gjson syntax) to the array in the `output`. Use `@this` for the `output` itself.",
+							MarkdownDescription: "The path (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to the array in the `output`. Use `@this` for the `output` itself.",
+							Required:            true,
+						},
+						"key": schema.StringAttribute{
+							Description:         "The path (in gjson syntax) to the sort key, relative to each array element. Use `@this` for the element itself.",
+							MarkdownDescription: "The path (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to the sort key, relative to each array element. Use `@this` for the element itself.",
+							Required:            true,
+						},
+					},
+				},
+			},
 
+			"output_file": schema.StringAttribute{
+				Description:         "The path to a file, where the raw response body is written to. In this case, the response body is not parsed into the `output` (and `output_raw`), which is useful for the binary or large responses, e.g. an export.",
+				MarkdownDescription: "The path to a file, where the raw response body is written to. In this case, the response body is not parsed into the `output` (and `output_raw`), which is useful for the binary or large responses, e.g. an export.",
+				Optional:            true,
+			},
+			"output_file_sha256": schema.StringAttribute{
+				Description:         "The hex encoded SHA256 checksum of the content written to the `output_file`.",
+				MarkdownDescription: "The hex encoded SHA256 checksum of the content written to the `output_file`.",
+				Computed:            true,
+			},
 			"output": schema.DynamicAttribute{
 				Description:         "The response body.",
 				MarkdownDescription: "The response body.",
 				Computed:            true,
 			},
+			"output_raw": schema.StringAttribute{
+				Description:         "The raw JSON of the `output`, which keeps the exact response body (after `output_attrs` and `output_type_hints`) without round-tripping through the dynamic type.",
+				MarkdownDescription: "The raw JSON of the `output`, which keeps the exact response body (after `output_attrs` and `output_type_hints`) without round-tripping through the dynamic type.",
+				Computed:            true,
+			},
+			"output_sha256": schema.StringAttribute{
+				Description:         "The hex encoded SHA256 hash of the `output_raw`, whose object keys are sorted beforehand. It only changes when the `output` changes, which is useful to trigger the changes of the downstream resources (e.g. via `replace_triggered_by`).",
+				MarkdownDescription: "The hex encoded SHA256 hash of the `output_raw`, whose object keys are sorted beforehand. It only changes when the `output` changes, which is useful to trigger the changes of the downstream resources (e.g. via `replace_triggered_by`).",
+				Computed:            true,
+			},
+			"output_header": schema.MapAttribute{
+				Description:         "The response headers, whose multiple values are joined by `, `. This is useful to pass the response headers (e.g. a session token or an `ETag`) to the dependent resources.",
+				MarkdownDescription: "The response headers, whose multiple values are joined by `, `. This is useful to pass the response headers (e.g. a session token or an `ETag`) to the dependent resources.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"output_trailer": schema.MapAttribute{
+				Description:         "The response trailers, whose multiple values are joined by `, `. This is only set when the response has any trailers.",
+				MarkdownDescription: "The response trailers, whose multiple values are joined by `, `. This is only set when the response has any trailers.",
+				ElementType:         types.StringType,
+				Computed:            true,
+			},
+			"last_request_duration_ms": schema.Int64Attribute{
+				Description:         "The duration of the operation HTTP request, in millisecond. It is only meant for performance debugging, and never triggers a plan diff.",
+				MarkdownDescription: "The duration of the operation HTTP request, in millisecond. It is only meant for performance debugging, and never triggers a plan diff.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -214,7 +425,10 @@ func (r *OperationResource) Configure(ctx context.Context, req resource.Configur
 	r.p = providerData.provider
 }
 
-func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Plan, tfstate *tfsdk.State, diagnostics *diag.Diagnostics, forCreate bool) {
+// createOrUpdate runs the operation for create (prior is nil) or update (prior is the prior state).
+func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Plan, tfstate *tfsdk.State, prior *tfsdk.State, diagnostics *diag.Diagnostics) {
+	forCreate := prior == nil
+
 	c := r.p.client
 	c.SetLoggerContext(ctx)
 
@@ -225,18 +439,71 @@ func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Pla
 		return
 	}
 
+	ctx = client.WithLogLevel(ctx, plan.LogLevel.ValueString())
+	c.SetLoggerContext(ctx)
+
 	if forCreate {
 		tflog.Info(ctx, "Create an operation resource", map[string]interface{}{"id": plan.Path.ValueString()})
 	} else {
 		tflog.Info(ctx, "Update an operation resource", map[string]interface{}{"id": plan.ID.ValueString()})
 	}
 
-	opt, diags := r.p.apiOpt.ForOperation(ctx, plan.Method, plan.Query, plan.Header, plan.OperationQuery, plan.OperationHeader)
+	method := plan.Method
+	path := plan.Path.ValueString()
+	if !forCreate {
+		if !plan.UpdateMethod.IsNull() {
+			method = plan.UpdateMethod
+		}
+		if !plan.UpdatePath.IsNull() {
+			var state operationResourceData
+			diags = prior.Get(ctx, &state)
+			diagnostics.Append(diags...)
+			if diags.HasError() {
+				return
+			}
+			body, err := dynamic.ToJSON(state.Output)
+			if err != nil {
+				diagnostics.AddError(
+					fmt.Sprintf("Failed to build the path for updating the operation resource"),
+					fmt.Sprintf("Failed to marshal the output: %v", err),
+				)
+				return
+			}
+			path, err = exparam.ExpandBodyOrPath(plan.UpdatePath.ValueString(), plan.Path.ValueString(), body, nil)
+			if err != nil {
+				diagnostics.AddError(
+					fmt.Sprintf("Failed to build the path for updating the operation resource"),
+					fmt.Sprintf("Can't build path with `update_path`: %q, `path`: %q, `body`: %q, error: %v", plan.UpdatePath.ValueString(), plan.Path.ValueString(), string(body), err),
+				)
+				return
+			}
+		}
+	}
+
+	opt, diags := r.p.apiOpt.ForOperation(ctx, method, plan.Query, plan.Header, plan.OperationQuery, plan.OperationHeader)
 	diagnostics.Append(diags...)
 	if diags.HasError() {
 		return
 	}
 
+	body := plan.Body
+	var graphql graphqlData
+	if !plan.GraphQL.IsNull() {
+		if diags := plan.GraphQL.As(ctx, &graphql, basetypes.ObjectAsOptions{}); diags.HasError() {
+			diagnostics.Append(diags...)
+			return
+		}
+		var err error
+		body, err = BuildGraphQLBody(graphql)
+		if err != nil {
+			diagnostics.AddError(
+				"Failed to build the GraphQL request body",
+				err.Error(),
+			)
+			return
+		}
+	}
+
 	// Precheck
 	if !plan.Precheck.IsNull() {
 		unlockFunc, diags := precheck(ctx, c, r.p.apiOpt, plan.Path.ValueString(), opt.Header, opt.Query, plan.Precheck, basetypes.NewDynamicNull())
@@ -247,7 +514,13 @@ func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Pla
 		defer unlockFunc()
 	}
 
-	response, err := c.Operation(ctx, plan.Path.ValueString(), plan.Body, *opt)
+	var response *resty.Response
+	var err error
+	if !plan.BodyFile.IsNull() {
+		response, err = c.OperationWithFile(ctx, path, plan.BodyFile.ValueString(), bodyFileContentType(plan.BodyFileContentType), *opt)
+	} else {
+		response, err = c.Operation(ctx, path, body, *opt)
+	}
 	if err != nil {
 		diagnostics.AddError(
 			"Error to call operation",
@@ -255,17 +528,47 @@ func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Pla
 		)
 		return
 	}
-	if !response.IsSuccess() {
+	// The response of any accepted status code goes on to populate the `output`.
+	accepted := response.IsSuccess()
+	if !plan.ExpectedStatusCodes.IsNull() {
+		var patterns []string
+		if diags := plan.ExpectedStatusCodes.ElementsAs(ctx, &patterns, false); diags.HasError() {
+			diagnostics.Append(diags...)
+			return
+		}
+		accepted, err = client.MatchStatusCodePatterns(response.StatusCode(), patterns)
+		if err != nil {
+			diagnostics.AddError(
+				"Invalid `expected_status_codes`",
+				err.Error(),
+			)
+			return
+		}
+	}
+	if !accepted {
 		diagnostics.AddError(
-			fmt.Sprintf("Operation API returns %d", response.StatusCode()),
+			fmt.Sprintf("Operation API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 			string(response.Body()),
 		)
 		return
 	}
+	plan.LastRequestDurationMs = types.Int64Value(response.Time().Milliseconds())
+	plan.OutputHeader = HeaderToMap(response.Header())
+	// The trailers are only available after the response body is fully read, which is guaranteed by resty.
+	plan.OutputTrailer = HeaderToMap(response.RawResponse.Trailer)
+	if !plan.GraphQL.IsNull() {
+		if err := GraphQLErrors(response.Body()); err != nil {
+			diagnostics.AddError(
+				"GraphQL operation returns errors",
+				err.Error(),
+			)
+			return
+		}
+	}
 
 	resourceId := plan.Path.ValueString()
 	if !plan.IdBuilder.IsNull() {
-		resourceId, err = exparam.ExpandBodyOrPath(plan.IdBuilder.ValueString(), plan.Path.ValueString(), response.Body())
+		resourceId, err = exparam.ExpandBodyOrPath(plan.IdBuilder.ValueString(), plan.Path.ValueString(), response.Body(), response.Header())
 		if err != nil {
 			diagnostics.AddError(
 				fmt.Sprintf("Failed to build the id for this resource"),
@@ -308,6 +611,9 @@ func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Pla
 			diagnostics.Append(diags...)
 			return
 		}
+		if opt.UrlLocator == nil && plan.PollAsyncOperationURL.ValueBool() && response.StatusCode() == http.StatusAccepted {
+			opt.UrlLocator = AsyncOperationURLLocator(*response)
+		}
 		if opt.UrlLocator == nil {
 			response.Request.URL = resourceId
 		}
@@ -328,11 +634,51 @@ func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Pla
 		}
 	}
 
+	diags = waitForBlock(ctx, c, r.p.apiOpt, "wait_for", plan.WaitFor, waitForOption{
+		DefaultPath: resourceId,
+		Header:      opt.Header,
+		Query:       opt.Query,
+		RespBody:    response.Body(),
+		RespHeader:  response.Header(),
+		Output:      types.DynamicNull(),
+	})
+	diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
 	// Set resource ID to state
 	plan.ID = types.StringValue(resourceId)
 
+	// Write the response body to the file, instead of setting it as the output.
+	plan.OutputFileSHA256 = types.StringNull()
+	if !plan.OutputFile.IsNull() {
+		if err := os.WriteFile(plan.OutputFile.ValueString(), response.Body(), 0644); err != nil {
+			diagnostics.AddError(
+				"Writing the response body to `output_file`",
+				err.Error(),
+			)
+			return
+		}
+		sum := sha256.Sum256(response.Body())
+		plan.OutputFileSHA256 = types.StringValue(hex.EncodeToString(sum[:]))
+		plan.Output = types.DynamicNull()
+		plan.OutputRaw = types.StringNull()
+		plan.OutputSHA256 = types.StringNull()
+
+		diags = tfstate.Set(ctx, plan)
+		diagnostics.Append(diags...)
+		return
+	}
+
 	// Set Output to state
 	rb := response.Body()
+	if graphql.SelectData.ValueBool() {
+		rb = []byte("null")
+		if data := gjson.GetBytes(response.Body(), "data"); data.Exists() {
+			rb = []byte(data.Raw)
+		}
+	}
 	if !plan.OutputAttrs.IsNull() {
 		// Update the output to only contain the specified attributes.
 		var outputAttrs []string
@@ -352,6 +698,29 @@ func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Pla
 		rb = []byte(fb)
 	}
 
+	rb, diags = coerceOutputTypes(ctx, plan.OutputTypeHints, rb)
+	diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	if !plan.OutputSort.IsNull() {
+		sorts, diags := expandOutputSorts(ctx, plan.OutputSort)
+		diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		sb, err := SortArraysInJSON(string(rb), sorts)
+		if err != nil {
+			diagnostics.AddError(
+				"Sort `output` during operation",
+				err.Error(),
+			)
+			return
+		}
+		rb = []byte(sb)
+	}
+
 	output, err := dynamic.FromJSONImplied(rb)
 	if err != nil {
 		diagnostics.AddError(
@@ -361,6 +730,8 @@ func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Pla
 		return
 	}
 	plan.Output = output
+	plan.OutputRaw = types.StringValue(string(rb))
+	plan.OutputSHA256 = types.StringValue(HashJSON(string(rb)))
 
 	diags = tfstate.Set(ctx, plan)
 	diagnostics.Append(diags...)
@@ -370,12 +741,12 @@ func (r *OperationResource) createOrUpdate(ctx context.Context, tfplan tfsdk.Pla
 }
 
 func (r *OperationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	r.createOrUpdate(ctx, req.Plan, &resp.State, &resp.Diagnostics, true)
+	r.createOrUpdate(ctx, req.Plan, &resp.State, nil, &resp.Diagnostics)
 	return
 }
 
 func (r *OperationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	r.createOrUpdate(ctx, req.Plan, &resp.State, &resp.Diagnostics, false)
+	r.createOrUpdate(ctx, req.Plan, &resp.State, &req.State, &resp.Diagnostics)
 	return
 }
 
@@ -394,6 +765,9 @@ func (r *OperationResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
+	ctx = client.WithLogLevel(ctx, state.LogLevel.ValueString())
+	c.SetLoggerContext(ctx)
+
 	tflog.Info(ctx, "Delete an operation resource", map[string]interface{}{"id": state.ID.ValueString()})
 
 	if state.DeleteMethod.IsNull() {
@@ -426,7 +800,7 @@ func (r *OperationResource) Delete(ctx context.Context, req resource.DeleteReque
 			)
 			return
 		}
-		path, err = exparam.ExpandBodyOrPath(state.DeletePath.ValueString(), state.Path.ValueString(), body)
+		path, err = exparam.ExpandBodyOrPath(state.DeletePath.ValueString(), state.Path.ValueString(), body, nil)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				fmt.Sprintf("Failed to build the path for deleting the operation resource"),
@@ -446,7 +820,7 @@ func (r *OperationResource) Delete(ctx context.Context, req resource.DeleteReque
 	}
 	if !response.IsSuccess() {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Delete: Operation API returns %d", response.StatusCode()),
+			fmt.Sprintf("Delete: Operation API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 			string(response.Body()),
 		)
 		return
@@ -464,6 +838,10 @@ func (r *OperationResource) Delete(ctx context.Context, req resource.DeleteReque
 			resp.Diagnostics.Append(diags...)
 			return
 		}
+		if opt.UrlLocator == nil && state.PollAsyncOperationURL.ValueBool() && response.StatusCode() == http.StatusAccepted {
+			// The delete call has started an async operation, whose URL is returned in the header. Poll it instead of the delete URL.
+			opt.UrlLocator = AsyncOperationURLLocator(*response)
+		}
 		p, err := client.NewPollableForPoll(*response, *opt)
 		if err != nil {
 			resp.Diagnostics.AddError(
@@ -481,5 +859,109 @@ func (r *OperationResource) Delete(ctx context.Context, req resource.DeleteReque
 		}
 	}
 
+	diags = waitForBlock(ctx, c, r.p.apiOpt, "wait_for_delete", state.WaitForDelete, waitForOption{
+		DefaultPath:   state.ID.ValueString(),
+		Header:        opt.Header,
+		Query:         opt.Query,
+		RespBody:      response.Body(),
+		RespHeader:    response.Header(),
+		Output:        state.Output,
+		GoneAsSuccess: true,
+	})
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
 	return
 }
+
+type operationImportSpec struct {
+	// Id is the operation id. The `path` is used instead if absent.
+	Id string `json:"id"`
+
+	// Method is the HTTP method of the operation. Required.
+	Method string `json:"method"`
+
+	// Path is the path of the operation. Required.
+	Path string `json:"path"`
+
+	// Query is the query parameters of the operation.
+	Query *url.Values `json:"query"`
+
+	// Header is the header of the operation.
+	Header map[string]string `json:"header"`
+
+	// Body is the payload of the operation.
+	Body *json.RawMessage `json:"body"`
+
+	// Output is the pre-known response of the operation. As the operation won't be invoked during import,
+	// this is used to reconstruct the `output`, so that its references won't break.
+	Output *json.RawMessage `json:"output"`
+}
+
+func (r *OperationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	var imp operationImportSpec
+	if err := json.Unmarshal([]byte(req.ID), &imp); err != nil {
+		resp.Diagnostics.AddError(
+			"Resource Import Error",
+			fmt.Sprintf("failed to unmarshal ID: %v", err),
+		)
+		return
+	}
+
+	if imp.Method == "" {
+		resp.Diagnostics.AddError(
+			"Resource Import Error",
+			fmt.Sprintf("`method` not specified in the import spec"),
+		)
+		return
+	}
+
+	if imp.Path == "" {
+		resp.Diagnostics.AddError(
+			"Resource Import Error",
+			fmt.Sprintf("`path` not specified in the import spec"),
+		)
+		return
+	}
+
+	id := imp.Id
+	if id == "" {
+		id = imp.Path
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("method"), imp.Method)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("path"), imp.Path)...)
+
+	if imp.Query != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("query"), imp.Query)...)
+	}
+	if imp.Header != nil {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("header"), imp.Header)...)
+	}
+	if imp.Body != nil {
+		body, err := dynamic.FromJSONImplied(*imp.Body)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Resource Import Error",
+				fmt.Sprintf("unmarshal `body`: %v", err),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("body"), body)...)
+	}
+	if imp.Output != nil {
+		output, err := dynamic.FromJSONImplied(*imp.Output)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Resource Import Error",
+				fmt.Sprintf("unmarshal `output`: %v", err),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output"), output)...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_raw"), string(*imp.Output))...)
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("output_sha256"), HashJSON(string(*imp.Output)))...)
+	}
+}
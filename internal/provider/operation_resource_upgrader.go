@@ -92,6 +92,14 @@ func (r *OperationResource) UpgradeState(context.Context) map[int64]resource.Sta
 					return
 				}
 
+				poll, diags := upgradePollObject(ctx, pd.Poll)
+				resp.Diagnostics.Append(diags...)
+				pollDelete, diags := upgradePollObject(ctx, pd.PollDelete)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
 				upgradedStateData := operationResourceData{
 					ID:             pd.ID,
 					Path:           pd.Path,
@@ -100,12 +108,12 @@ func (r *OperationResource) UpgradeState(context.Context) map[int64]resource.Sta
 					Query:          pd.Query,
 					Header:         pd.Header,
 					Precheck:       pd.Precheck,
-					Poll:           pd.Poll,
+					Poll:           poll,
 					DeleteMethod:   pd.DeleteMethod,
 					DeleteBody:     pd.DeleteBody,
 					DeletePath:     pd.DeletePath,
 					PrecheckDelete: pd.PrecheckDelete,
-					PollDelete:     pd.PollDelete,
+					PollDelete:     pollDelete,
 					OutputAttrs:    pd.OutputAttrs,
 					Output:         pd.Output,
 				}
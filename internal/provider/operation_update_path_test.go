@@ -0,0 +1,72 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+func TestOperation_UpdatePath(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"id": "1"})
+	}))
+	defer srv.Close()
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_operation" "test" {
+  path          = "/jobs"
+  method        = "POST"
+  update_method = "PUT"
+  update_path   = "$(path)/$(body.id)"
+  body = {
+    name = %q
+  }
+}
+`, srv.URL, name)
+	}
+
+	expectCalls := func(expect ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			mu.Lock()
+			defer mu.Unlock()
+			if fmt.Sprint(calls) != fmt.Sprint(expect) {
+				return fmt.Errorf("expect calls %v, got %v", expect, calls)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("foo"),
+				Check:  expectCalls("POST /jobs"),
+			},
+			{
+				Config: config("bar"),
+				Check:  expectCalls("POST /jobs", "PUT /jobs/1"),
+			},
+		},
+	})
+}
@@ -1 +1,327 @@
 package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/terraform-provider-restful/internal/attrpath"
+	"github.com/tidwall/gjson"
+	"github.com/tidwall/sjson"
+)
+
+const (
+	outputTypeString = "string"
+	outputTypeNumber = "number"
+	outputTypeBool   = "bool"
+)
+
+const (
+	outputTypeHintsDescription         = "A map of `output` attribute paths (in gjson syntax) to their expected types, which are used to coerce the attribute values to a stable type before being exported in the `output`. Possible types are `string`, `number` and `bool`. The boolean-like strings and numbers (i.e. `true`/`false`, `1`/`0`, `yes`/`no` and `on`/`off`, case insensitively) are coerced to `bool`. Attributes that don't exist in the response are ignored."
+	outputTypeHintsMarkdownDescription = "A map of `output` attribute paths (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to their expected types, which are used to coerce the attribute values to a stable type before being exported in the `output`. Possible types are `string`, `number` and `bool`. The boolean-like strings and numbers (i.e. `true`/`false`, `1`/`0`, `yes`/`no` and `on`/`off`, case insensitively) are coerced to `bool`. Attributes that don't exist in the response are ignored."
+)
+
+// coerceOutputTypes coerces the attributes in the response body by the `output_type_hints`, if any.
+func coerceOutputTypes(ctx context.Context, outputTypeHints types.Map, b []byte) ([]byte, diag.Diagnostics) {
+	if outputTypeHints.IsNull() {
+		return b, nil
+	}
+	var hints map[string]string
+	diags := outputTypeHints.ElementsAs(ctx, &hints, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+	cb, err := CoerceTypesInJSON(string(b), hints)
+	if err != nil {
+		diags.AddError(
+			"Coerce `output` types",
+			err.Error(),
+		)
+		return nil, diags
+	}
+	return []byte(cb), diags
+}
+
+// CoerceTypesInJSON coerces the attributes in the JSON document to the specified types.
+// The key of the hints is the attribute path, the value is one of "string", "number" and "bool".
+// Attributes that don't exist in the document are skipped.
+func CoerceTypesInJSON(doc string, hints map[string]string) (string, error) {
+	if len(hints) == 0 {
+		return doc, nil
+	}
+
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	var jsonDoc any
+	if err := dec.Decode(&jsonDoc); err != nil {
+		return "", err
+	}
+
+	for attr, typ := range hints {
+		path, err := attrpath.Path(attr)
+		if err != nil {
+			return "", fmt.Errorf("parsing %q: %v", attr, err)
+		}
+		jsonDoc, err = coerceAttrInJSON(jsonDoc, attrpath.AttrPath{}, path, typ)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	b, err := json.Marshal(jsonDoc)
+	if err != nil {
+		return "", fmt.Errorf("marshalling the coerced document: %v", err)
+	}
+	return string(b), nil
+}
+
+func coerceAttrInJSON(doc any, prefix, path attrpath.AttrPath, typ string) (any, error) {
+	if len(path) == 0 {
+		v, err := coerceValue(doc, typ)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", prefix, err)
+		}
+		return v, nil
+	}
+
+	step := path[0]
+	prefix = append(prefix, step)
+	remain := path[1:]
+	switch doc := doc.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		if _, ok := step.(attrpath.AttrStepSplat); !ok {
+			return nil, fmt.Errorf("%s: expect a splat step, got %T", prefix, step)
+		}
+		for i := range doc {
+			v, err := coerceAttrInJSON(doc[i], prefix, remain, typ)
+			if err != nil {
+				return nil, err
+			}
+			doc[i] = v
+		}
+		return doc, nil
+	case map[string]interface{}:
+		step, ok := step.(attrpath.AttrStepValue)
+		if !ok {
+			return nil, fmt.Errorf("%s: expect a value step, got %T", prefix, step)
+		}
+		k := string(step)
+		v, ok := doc[k]
+		if !ok {
+			return doc, nil
+		}
+		v, err := coerceAttrInJSON(v, prefix, remain, typ)
+		if err != nil {
+			return nil, err
+		}
+		doc[k] = v
+		return doc, nil
+	default:
+		return nil, fmt.Errorf("%s: invalid document type %T", prefix, doc)
+	}
+}
+
+func coerceValue(v any, typ string) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch typ {
+	case outputTypeString:
+		switch v := v.(type) {
+		case string:
+			return v, nil
+		case json.Number:
+			return v.String(), nil
+		case bool:
+			return strconv.FormatBool(v), nil
+		}
+	case outputTypeNumber:
+		switch v := v.(type) {
+		case json.Number:
+			return v, nil
+		case string:
+			dec := json.NewDecoder(strings.NewReader(v))
+			dec.UseNumber()
+			var n any
+			if err := dec.Decode(&n); err != nil || dec.More() {
+				return nil, fmt.Errorf("%q is not a number", v)
+			}
+			if _, ok := n.(json.Number); !ok {
+				return nil, fmt.Errorf("%q is not a number", v)
+			}
+			return n, nil
+		}
+	case outputTypeBool:
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case string:
+			b, ok := parseBoolLike(v)
+			if !ok {
+				return nil, fmt.Errorf("%q is not a bool", v)
+			}
+			return b, nil
+		case json.Number:
+			b, ok := parseBoolLike(v.String())
+			if !ok {
+				return nil, fmt.Errorf("%s is not a bool", v)
+			}
+			return b, nil
+		}
+	default:
+		return nil, fmt.Errorf("unknown type %q", typ)
+	}
+	return nil, fmt.Errorf("can't coerce %T to %s", v, typ)
+}
+
+// parseBoolLike parses the boolean-like strings that are commonly returned by the APIs, case insensitively, i.e.
+// "true"/"false", "t"/"f", "1"/"0", "yes"/"no" and "on"/"off".
+func parseBoolLike(s string) (bool, bool) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "true", "t", "1", "yes", "on":
+		return true, true
+	case "false", "f", "0", "no", "off":
+		return false, true
+	}
+	return false, false
+}
+
+// ChangedAttrsInJSON returns the attribute paths (in gjson syntax) whose values are different between the two JSON documents.
+// An attribute that only exists in one of the documents is regarded as changed.
+func ChangedAttrsInJSON(oldDoc, newDoc string, attrs []string) []string {
+	var changed []string
+	for _, attr := range attrs {
+		ov, nv := gjson.Get(oldDoc, attr), gjson.Get(newDoc, attr)
+		if ov.Exists() != nv.Exists() || !reflect.DeepEqual(ov.Value(), nv.Value()) {
+			changed = append(changed, attr)
+		}
+	}
+	return changed
+}
+
+// AliasAttrsInJSON sets the aliases (the keys of aliases) in the JSON document, whose values are copied from the
+// source paths (the values of aliases) in the source JSON document. Both paths are in gjson syntax.
+// Sources that don't exist in the source document are skipped.
+func AliasAttrsInJSON(doc, source string, aliases map[string]string) (string, error) {
+	var keys []string
+	for alias := range aliases {
+		keys = append(keys, alias)
+	}
+	sort.Strings(keys)
+
+	var err error
+	for _, alias := range keys {
+		v := gjson.Get(source, aliases[alias])
+		if !v.Exists() {
+			continue
+		}
+		doc, err = sjson.SetRaw(doc, alias, v.Raw)
+		if err != nil {
+			return "", fmt.Errorf("setting alias %q: %v", alias, err)
+		}
+	}
+	return doc, nil
+}
+
+// HashJSON returns the hex encoded SHA256 hash of the JSON document, which is normalized (i.e. sorted object keys without insignificant whitespaces) beforehand.
+// The document is hashed as is if it isn't a valid JSON.
+func HashJSON(doc string) string {
+	b := []byte(doc)
+	var v any
+	dec := json.NewDecoder(strings.NewReader(doc))
+	dec.UseNumber()
+	if err := dec.Decode(&v); err == nil {
+		if nb, err := json.Marshal(v); err == nil {
+			b = nb
+		}
+	}
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+type outputSortData struct {
+	Path types.String `tfsdk:"path"`
+	Key  types.String `tfsdk:"key"`
+}
+
+// OutputSort specifies that the array at Path (in gjson syntax) shall be sorted by the value at Key (in gjson syntax) of each element.
+type OutputSort struct {
+	Path string
+	Key  string
+}
+
+func expandOutputSorts(ctx context.Context, l types.List) ([]OutputSort, diag.Diagnostics) {
+	var sorts []outputSortData
+	if diags := l.ElementsAs(ctx, &sorts, false); diags.HasError() {
+		return nil, diags
+	}
+	var out []OutputSort
+	for _, s := range sorts {
+		out = append(out, OutputSort{Path: s.Path.ValueString(), Key: s.Key.ValueString()})
+	}
+	return out, nil
+}
+
+// SortArraysInJSON sorts the arrays in the JSON document by the specified keys, so that the order of the array elements is stable.
+// Elements are ordered by number if both keys are numbers, otherwise by their string representation.
+// Arrays that don't exist in the document are skipped.
+func SortArraysInJSON(doc string, sorts []OutputSort) (string, error) {
+	for _, s := range sorts {
+		arr := gjson.Get(doc, s.Path)
+		if !arr.Exists() {
+			continue
+		}
+		if !arr.IsArray() {
+			return "", fmt.Errorf("%q is not an array", s.Path)
+		}
+		elems := arr.Array()
+		sort.SliceStable(elems, func(i, j int) bool {
+			ki, kj := elems[i].Get(s.Key), elems[j].Get(s.Key)
+			if ki.Type == gjson.Number && kj.Type == gjson.Number {
+				return ki.Num < kj.Num
+			}
+			return ki.String() < kj.String()
+		})
+		var raws []string
+		for _, e := range elems {
+			raws = append(raws, e.Raw)
+		}
+		sorted := "[" + strings.Join(raws, ",") + "]"
+
+		if s.Path == "@this" {
+			doc = sorted
+			continue
+		}
+		var err error
+		doc, err = sjson.SetRaw(doc, s.Path, sorted)
+		if err != nil {
+			return "", fmt.Errorf("setting the sorted array at %q: %v", s.Path, err)
+		}
+	}
+	return doc, nil
+}
+
+// HeaderToMap converts the response headers (or trailers) to a map, whose multiple values are joined by ", ".
+// A null map is returned if there is no header.
+func HeaderToMap(header http.Header) types.Map {
+	if len(header) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	m := map[string]attr.Value{}
+	for k, vs := range header {
+		m[k] = types.StringValue(strings.Join(vs, ", "))
+	}
+	return types.MapValueMust(types.StringType, m)
+}
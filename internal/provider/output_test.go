@@ -0,0 +1,341 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoerceTypesInJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		doc    string
+		hints  map[string]string
+		expect interface{}
+	}{
+		{
+			name:   "no hints",
+			doc:    `{"a":1}`,
+			expect: `{"a":1}`,
+		},
+		{
+			name:   "to string",
+			doc:    `{"a":1,"b":true,"c":"x","d":null}`,
+			hints:  map[string]string{"a": "string", "b": "string", "c": "string", "d": "string"},
+			expect: `{"a":"1","b":"true","c":"x","d":null}`,
+		},
+		{
+			name:   "to number",
+			doc:    `{"a":"12345678901234567890","b":1.5,"c":"-1e3"}`,
+			hints:  map[string]string{"a": "number", "b": "number", "c": "number"},
+			expect: `{"a":12345678901234567890,"b":1.5,"c":-1e3}`,
+		},
+		{
+			name:   "to bool",
+			doc:    `{"a":"true","b":0,"c":false}`,
+			hints:  map[string]string{"a": "bool", "b": "bool", "c": "bool"},
+			expect: `{"a":true,"b":false,"c":false}`,
+		},
+		{
+			name:   "boolean-like to bool",
+			doc:    `{"a":"True","b":"1","c":"0","d":"yes","e":"No","f":"on","g":"OFF","h":1}`,
+			hints:  map[string]string{"a": "bool", "b": "bool", "c": "bool", "d": "bool", "e": "bool", "f": "bool", "g": "bool", "h": "bool"},
+			expect: `{"a":true,"b":true,"c":false,"d":true,"e":false,"f":true,"g":false,"h":true}`,
+		},
+		{
+			name:   "invalid bool",
+			doc:    `{"a":"maybe"}`,
+			hints:  map[string]string{"a": "bool"},
+			expect: errors.New(`a: "maybe" is not a bool`),
+		},
+		{
+			name:   "nested with splat",
+			doc:    `{"obj":{"arr":[{"v":1},{"v":"2"},{"w":3}]}}`,
+			hints:  map[string]string{"obj.arr.#.v": "string"},
+			expect: `{"obj":{"arr":[{"v":"1"},{"v":"2"},{"w":3}]}}`,
+		},
+		{
+			name:   "non-existing attribute",
+			doc:    `{"a":1}`,
+			hints:  map[string]string{"b.c": "string"},
+			expect: `{"a":1}`,
+		},
+		{
+			name:   "invalid number",
+			doc:    `{"a":"abc"}`,
+			hints:  map[string]string{"a": "number"},
+			expect: errors.New(`a: "abc" is not a number`),
+		},
+		{
+			name:   "non-primitive value",
+			doc:    `{"a":{"b":1}}`,
+			hints:  map[string]string{"a": "string"},
+			expect: errors.New(`a: can't coerce map[string]interface {} to string`),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := CoerceTypesInJSON(tt.doc, tt.hints)
+			switch expect := tt.expect.(type) {
+			case error:
+				require.EqualError(t, err, expect.Error())
+			default:
+				require.NoError(t, err)
+				require.JSONEq(t, expect.(string), actual)
+			}
+		})
+	}
+}
+
+func TestCoerceOutputTypes(t *testing.T) {
+	cases := []struct {
+		name        string
+		hints       types.Map
+		input       string
+		expect      string
+		expectError bool
+	}{
+		{
+			name:   "no hints",
+			hints:  types.MapNull(types.StringType),
+			input:  `{"a": "1"}`,
+			expect: `{"a": "1"}`,
+		},
+		{
+			name: "coerced",
+			hints: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"a": types.StringValue(outputTypeNumber),
+				"b": types.StringValue(outputTypeBool),
+			}),
+			input:  `{"a": "1", "b": "yes"}`,
+			expect: `{"a": 1, "b": true}`,
+		},
+		{
+			name: "not coercible",
+			hints: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"a": types.StringValue(outputTypeNumber),
+			}),
+			input:       `{"a": "foo"}`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			b, diags := coerceOutputTypes(context.Background(), tt.hints, []byte(tt.input))
+			if tt.expectError {
+				require.True(t, diags.HasError())
+				return
+			}
+			require.False(t, diags.HasError(), diags)
+			require.JSONEq(t, tt.expect, string(b))
+		})
+	}
+}
+
+func TestChangedAttrsInJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		old    string
+		new    string
+		attrs  []string
+		expect []string
+	}{
+		{
+			name:  "no change",
+			old:   `{"a":1,"b":{"c":[1,2]}}`,
+			new:   `{"b":{"c":[1,2]},"a":1}`,
+			attrs: []string{"a", "b.c"},
+		},
+		{
+			name:   "value changed",
+			old:    `{"a":1,"b":{"c":[1,2]}}`,
+			new:    `{"a":2,"b":{"c":[1,3]}}`,
+			attrs:  []string{"a", "b.c"},
+			expect: []string{"a", "b.c"},
+		},
+		{
+			name:   "attribute added or removed",
+			old:    `{"a":1}`,
+			new:    `{"b":1}`,
+			attrs:  []string{"a", "b", "c"},
+			expect: []string{"a", "b"},
+		},
+		{
+			name:  "unrelated change",
+			old:   `{"a":1,"b":1}`,
+			new:   `{"a":1,"b":2}`,
+			attrs: []string{"a"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, ChangedAttrsInJSON(tt.old, tt.new, tt.attrs))
+		})
+	}
+}
+
+func TestSortArraysInJSON(t *testing.T) {
+	cases := []struct {
+		name   string
+		doc    string
+		sorts  []OutputSort
+		expect string
+		err    bool
+	}{
+		{
+			name:   "array not exist",
+			doc:    `{"a": 1}`,
+			sorts:  []OutputSort{{Path: "items", Key: "name"}},
+			expect: `{"a": 1}`,
+		},
+		{
+			name:  "not an array",
+			doc:   `{"items": 1}`,
+			sorts: []OutputSort{{Path: "items", Key: "name"}},
+			err:   true,
+		},
+		{
+			name:   "sort by string key",
+			doc:    `{"items": [{"name": "b"}, {"name": "c"}, {"name": "a"}]}`,
+			sorts:  []OutputSort{{Path: "items", Key: "name"}},
+			expect: `{"items": [{"name": "a"}, {"name": "b"}, {"name": "c"}]}`,
+		},
+		{
+			name:   "sort by number key",
+			doc:    `{"items": [{"id": 10}, {"id": 9}, {"id": 100}]}`,
+			sorts:  []OutputSort{{Path: "items", Key: "id"}},
+			expect: `{"items": [{"id": 9}, {"id": 10}, {"id": 100}]}`,
+		},
+		{
+			name:   "sort the root array of primitives",
+			doc:    `["b", "a"]`,
+			sorts:  []OutputSort{{Path: "@this", Key: "@this"}},
+			expect: `["a", "b"]`,
+		},
+		{
+			name: "sort multiple arrays",
+			doc:  `{"a": [{"k": "y"}, {"k": "x"}], "b": {"c": [{"k": 2}, {"k": 1}]}}`,
+			sorts: []OutputSort{
+				{Path: "a", Key: "k"},
+				{Path: "b.c", Key: "k"},
+			},
+			expect: `{"a": [{"k": "x"}, {"k": "y"}], "b": {"c": [{"k": 1}, {"k": 2}]}}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := SortArraysInJSON(tt.doc, tt.sorts)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestHashJSON(t *testing.T) {
+	h := HashJSON(`{"a": 1, "b": {"c": [1, 2]}}`)
+	require.Len(t, h, 64)
+
+	// The key order and whitespaces don't matter.
+	require.Equal(t, h, HashJSON(`{"b":{"c":[1,2]},"a":1}`))
+
+	// The value changes matter.
+	require.NotEqual(t, h, HashJSON(`{"a": 1, "b": {"c": [2, 1]}}`))
+
+	// The large integers keep the precision.
+	require.NotEqual(t, HashJSON(`{"n": 12345678901234567890}`), HashJSON(`{"n": 12345678901234567891}`))
+
+	// The invalid JSON is hashed as is.
+	require.Equal(t, HashJSON("foo"), HashJSON("foo"))
+	require.NotEqual(t, HashJSON("foo"), HashJSON("bar"))
+}
+
+func TestAliasAttrsInJSON(t *testing.T) {
+	source := `{"id": "1", "properties": {"ipConfigurations": [{"properties": {"privateIPAddress": "10.0.0.4"}}], "name": "foo"}}`
+
+	cases := []struct {
+		name    string
+		doc     string
+		aliases map[string]string
+		expect  string
+	}{
+		{
+			name:    "no alias",
+			doc:     `{"id": "1"}`,
+			aliases: map[string]string{},
+			expect:  `{"id": "1"}`,
+		},
+		{
+			name: "alias nested values",
+			doc:  source,
+			aliases: map[string]string{
+				"ip":          "properties.ipConfigurations.0.properties.privateIPAddress",
+				"meta.name":   "properties.name",
+				"ipConfigs.0": "properties.ipConfigurations.0",
+			},
+			expect: `{"id": "1", "properties": {"ipConfigurations": [{"properties": {"privateIPAddress": "10.0.0.4"}}], "name": "foo"}, "ip": "10.0.0.4", "meta": {"name": "foo"}, "ipConfigs": [{"properties": {"privateIPAddress": "10.0.0.4"}}]}`,
+		},
+		{
+			name:    "alias source filtered out of the doc",
+			doc:     `{"id": "1"}`,
+			aliases: map[string]string{"ip": "properties.ipConfigurations.0.properties.privateIPAddress"},
+			expect:  `{"id": "1", "ip": "10.0.0.4"}`,
+		},
+		{
+			name:    "absent source is skipped",
+			doc:     `{"id": "1"}`,
+			aliases: map[string]string{"ip": "properties.ip"},
+			expect:  `{"id": "1"}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			actual, err := AliasAttrsInJSON(tt.doc, source, tt.aliases)
+			require.NoError(t, err)
+			require.JSONEq(t, tt.expect, actual)
+		})
+	}
+}
+
+func TestHeaderToMap(t *testing.T) {
+	cases := []struct {
+		name   string
+		header http.Header
+		expect types.Map
+	}{
+		{
+			name:   "no header",
+			expect: types.MapNull(types.StringType),
+		},
+		{
+			name: "headers",
+			header: http.Header{
+				"Grpc-Status":  []string{"0"},
+				"Grpc-Message": []string{"a", "b"},
+			},
+			expect: types.MapValueMust(types.StringType, map[string]attr.Value{
+				"Grpc-Status":  types.StringValue("0"),
+				"Grpc-Message": types.StringValue("a, b"),
+			}),
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, HeaderToMap(tt.header))
+		})
+	}
+}
@@ -2,16 +2,37 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/magodo/terraform-provider-restful/internal/client"
+	"github.com/magodo/terraform-provider-restful/internal/dynamic"
+	"github.com/magodo/terraform-provider-restful/internal/exparam"
 	"github.com/magodo/terraform-provider-restful/internal/locks"
 )
 
 func precheck(ctx context.Context, c *client.Client, apiOpt apiOption, defaultPath string, defaultHeader client.Header, defaultQuery client.Query, prechecks basetypes.ListValue, body basetypes.DynamicValue) (func(), diag.Diagnostics) {
-	lockedNames := []string{}
+	// cleanups are run in the reverse order of the prechecks, after the operation finishes.
+	var cleanups []func()
+	cleanup := func() {
+		for i := len(cleanups) - 1; i >= 0; i-- {
+			cleanups[i]()
+		}
+	}
+	// Release the preceding prechecks on failure, as the caller won't get the cleanup function.
+	succeeded := false
+	defer func() {
+		if !succeeded {
+			cleanup()
+		}
+	}()
+
 	var checks []precheckData
 	if diags := prechecks.ElementsAs(ctx, &checks, false); diags.HasError() {
 		return nil, diags
@@ -28,16 +49,11 @@ func precheck(ctx context.Context, c *client.Client, apiOpt apiOption, defaultPa
 			if diags.HasError() {
 				return nil, diags
 			}
-			p, err := client.NewPollableForPrecheck(*opt)
-			if err != nil {
-				return nil, diag.Diagnostics{
-					diag.NewErrorDiagnostic(
-						fmt.Sprintf("Failed to build poller for %d-th check (api)", i),
-						err.Error(),
-					),
-				}
+			var ttl time.Duration
+			if !d.CacheTTL.IsNull() {
+				ttl = time.Duration(d.CacheTTL.ValueInt64()) * time.Second
 			}
-			if err := p.PollUntilDone(ctx, c); err != nil {
+			if err := pollPrecheckApi(ctx, c, *opt, ttl); err != nil {
 				return nil, diag.Diagnostics{
 					diag.NewErrorDiagnostic(
 						fmt.Sprintf("Pre-checking %d-th check (api) failure", i),
@@ -55,13 +71,174 @@ func precheck(ctx context.Context, c *client.Client, apiOpt apiOption, defaultPa
 					),
 				}
 			}
-			lockedNames = append(lockedNames, key)
+			cleanups = append(cleanups, func() { locks.Unlock(key) })
+		case !check.Action.IsNull():
+			var d precheckDataAction
+			if diags := check.Action.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
+				return nil, diags
+			}
+			release, diags := precheckAction(ctx, c, defaultHeader, defaultQuery, d)
+			if diags.HasError() {
+				return nil, diags
+			}
+			if release != nil {
+				cleanups = append(cleanups, release)
+			}
 		}
 	}
 
+	succeeded = true
+	return cleanup, nil
+}
+
+// precheckAction issues the API call of the action, and returns a function that issues the release call, if specified.
+func precheckAction(ctx context.Context, c *client.Client, defaultHeader client.Header, defaultQuery client.Query, d precheckDataAction) (func(), diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	header := defaultHeader
+	if !d.Header.IsNull() {
+		if d := d.Header.ElementsAs(ctx, &header, false); d.HasError() {
+			diags.Append(d...)
+			return nil, diags
+		}
+	}
+	query := defaultQuery
+	if !d.Query.IsNull() {
+		if d := d.Query.ElementsAs(ctx, &query, false); d.HasError() {
+			diags.Append(d...)
+			return nil, diags
+		}
+	}
+
+	body, err := precheckActionBody(d.Body)
+	if err != nil {
+		diags.AddError("Failed to convert `body`", err.Error())
+		return nil, diags
+	}
+
+	method, path := d.Method.ValueString(), d.Path.ValueString()
+	resp, err := c.Operation(ctx, path, body, client.OperationOption{
+		Method: method,
+		Query:  query,
+		Header: header,
+	})
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Calling %s %s", method, path), err.Error())
+		return nil, diags
+	}
+	if !resp.IsSuccess() {
+		diags.AddError(
+			fmt.Sprintf("Calling %s %s", method, path),
+			fmt.Sprintf("Unexpected response (%s): %s", resp.Status(), string(resp.Body())),
+		)
+		return nil, diags
+	}
+
+	if d.ReleaseMethod.IsNull() {
+		return nil, nil
+	}
+
+	releaseMethod, releasePath := d.ReleaseMethod.ValueString(), path
+	if !d.ReleasePath.IsNull() {
+		releasePath, err = exparam.ExpandBodyOrPath(d.ReleasePath.ValueString(), path, resp.Body(), resp.Header())
+		if err != nil {
+			diags.AddError("Failed to build the path for the release call", err.Error())
+			return nil, diags
+		}
+	}
+	releaseBody, err := precheckActionBody(d.ReleaseBody)
+	if err != nil {
+		diags.AddError("Failed to convert `release_body`", err.Error())
+		return nil, diags
+	}
+
+	// The release call is issued in the deferred cleanup, where there is no way to report diagnostics.
 	return func() {
-		for i := len(lockedNames) - 1; i >= 0; i-- {
-			locks.Unlock(lockedNames[i])
+		resp, err := c.Operation(ctx, releasePath, releaseBody, client.OperationOption{
+			Method: releaseMethod,
+			Query:  query,
+			Header: header,
+		})
+		if err != nil {
+			tflog.Warn(ctx, "Failed to release the precheck action", map[string]interface{}{"method": releaseMethod, "path": releasePath, "error": err.Error()})
+			return
+		}
+		if !resp.IsSuccess() {
+			tflog.Warn(ctx, "Failed to release the precheck action", map[string]interface{}{"method": releaseMethod, "path": releasePath, "status": resp.Status(), "body": string(resp.Body())})
 		}
 	}, nil
 }
+
+func precheckActionBody(body types.String) (types.Dynamic, error) {
+	if body.IsNull() {
+		return types.DynamicNull(), nil
+	}
+	return dynamic.FromJSONImplied([]byte(body.ValueString()))
+}
+
+var (
+	precheckCacheMu sync.Mutex
+	// precheckCache records the expiry of the passed api prechecks, keyed by their effective requests.
+	precheckCache = map[string]time.Time{}
+)
+
+// precheckCacheKey returns the key of the api precheck in the cache, which identifies its effective request.
+func precheckCacheKey(opt client.PollOption) (string, error) {
+	b, err := json.Marshal(struct {
+		URL           string
+		Query         client.Query
+		Header        client.Header
+		StatusLocator string
+		Status        client.PollingStatus
+	}{
+		URL:           opt.UrlLocator.String(),
+		Query:         opt.Query,
+		Header:        opt.Header,
+		StatusLocator: opt.StatusLocator.String(),
+		Status:        opt.Status,
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// pollPrecheckApi polls the api precheck until it passes. If the ttl is non-zero, the passed precheck is cached for the ttl,
+// during which the same precheck passes without polling. The same prechecks are polled one at a time, so that the
+// concurrent ones wait for the first one to pass.
+func pollPrecheckApi(ctx context.Context, c *client.Client, opt client.PollOption, ttl time.Duration) error {
+	p, err := client.NewPollableForPrecheck(opt)
+	if err != nil {
+		return fmt.Errorf("building poller: %v", err)
+	}
+	if ttl == 0 {
+		return p.PollUntilDone(ctx, c)
+	}
+
+	key, err := precheckCacheKey(opt)
+	if err != nil {
+		return fmt.Errorf("building the cache key: %v", err)
+	}
+	lockKey := "precheck-cache:" + key
+	if err := locks.Lock(ctx, lockKey); err != nil {
+		return err
+	}
+	defer locks.Unlock(lockKey)
+
+	precheckCacheMu.Lock()
+	expiry, ok := precheckCache[key]
+	precheckCacheMu.Unlock()
+	if ok && time.Now().Before(expiry) {
+		tflog.Debug(ctx, "Precheck passed by cache", map[string]interface{}{"url": opt.UrlLocator.String()})
+		return nil
+	}
+
+	if err := p.PollUntilDone(ctx, c); err != nil {
+		return err
+	}
+
+	precheckCacheMu.Lock()
+	precheckCache[key] = time.Now().Add(ttl)
+	precheckCacheMu.Unlock()
+	return nil
+}
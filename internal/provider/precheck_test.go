@@ -0,0 +1,381 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/terraform-provider-restful/internal/client"
+	"github.com/magodo/terraform-provider-restful/internal/locks"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrecheckAction(t *testing.T) {
+	ctx := context.Background()
+
+	var (
+		mu    sync.Mutex
+		calls []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/leases":
+			w.Write([]byte(`{"id": "l1"}`))
+		case "/leases/l1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusConflict)
+		}
+	}))
+	defer srv.Close()
+
+	c, err := client.New(ctx, srv.URL, &client.BuildOption{})
+	require.NoError(t, err)
+
+	elemType := precheckAttribute("", true, "", false).GetType().(types.ListType).ElemType
+	actionType := elemType.(types.ObjectType).AttrTypes["action"].(types.ObjectType)
+
+	buildPrechecks := func(action precheckDataAction) types.List {
+		obj, diags := types.ObjectValueFrom(ctx, actionType.AttrTypes, action)
+		require.False(t, diags.HasError(), diags)
+		l, diags := types.ListValueFrom(ctx, elemType, []precheckData{
+			{
+				Api:    types.ObjectNull(elemType.(types.ObjectType).AttrTypes["api"].(types.ObjectType).AttrTypes),
+				Mutex:  types.StringNull(),
+				Action: obj,
+			},
+		})
+		require.False(t, diags.HasError(), diags)
+		return l
+	}
+
+	cases := []struct {
+		name      string
+		action    precheckDataAction
+		expectErr bool
+		expect    []string
+	}{
+		{
+			name: "action without release",
+			action: precheckDataAction{
+				Method:        types.StringValue("POST"),
+				Path:          types.StringValue("/leases"),
+				Body:          types.StringValue(`{"ttl": 60}`),
+				Query:         types.MapNull(types.ListType{ElemType: types.StringType}),
+				Header:        types.MapNull(types.StringType),
+				ReleaseMethod: types.StringNull(),
+				ReleasePath:   types.StringNull(),
+				ReleaseBody:   types.StringNull(),
+			},
+			expect: []string{"POST /leases"},
+		},
+		{
+			name: "action with release",
+			action: precheckDataAction{
+				Method:        types.StringValue("POST"),
+				Path:          types.StringValue("/leases"),
+				Body:          types.StringNull(),
+				Query:         types.MapNull(types.ListType{ElemType: types.StringType}),
+				Header:        types.MapNull(types.StringType),
+				ReleaseMethod: types.StringValue("DELETE"),
+				ReleasePath:   types.StringValue("$(path)/$(body.id)"),
+				ReleaseBody:   types.StringNull(),
+			},
+			expect: []string{"POST /leases", "DELETE /leases/l1"},
+		},
+		{
+			name: "action failure",
+			action: precheckDataAction{
+				Method:        types.StringValue("POST"),
+				Path:          types.StringValue("/conflict"),
+				Body:          types.StringNull(),
+				Query:         types.MapNull(types.ListType{ElemType: types.StringType}),
+				Header:        types.MapNull(types.StringType),
+				ReleaseMethod: types.StringValue("DELETE"),
+				ReleasePath:   types.StringNull(),
+				ReleaseBody:   types.StringNull(),
+			},
+			expectErr: true,
+			expect:    []string{"POST /conflict"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+			unlock, diags := precheck(ctx, c, apiOption{}, "", nil, nil, buildPrechecks(tt.action), types.DynamicNull())
+			if tt.expectErr {
+				require.True(t, diags.HasError())
+				require.Equal(t, tt.expect, calls)
+				return
+			}
+			require.False(t, diags.HasError(), diags)
+			unlock()
+			require.Equal(t, tt.expect, calls)
+		})
+	}
+}
+
+func TestPrecheckApiHeaderStatus(t *testing.T) {
+	ctx := context.Background()
+
+	cases := []struct {
+		name       string
+		states     []string
+		expectErr  bool
+		expectGets int
+	}{
+		{
+			name:       "ready at once",
+			states:     []string{"Ready"},
+			expectGets: 1,
+		},
+		{
+			name:       "ready after provisioning",
+			states:     []string{"Provisioning", "Provisioning", "Ready"},
+			expectGets: 3,
+		},
+		{
+			name:       "unexpected state",
+			states:     []string{"Provisioning", "Failed"},
+			expectErr:  true,
+			expectGets: 2,
+		},
+		{
+			name:       "header absent",
+			states:     []string{""},
+			expectErr:  true,
+			expectGets: 1,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var gets int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				state := tt.states[min(gets, len(tt.states)-1)]
+				gets++
+				if state != "" {
+					w.Header().Set("X-Provisioning-State", state)
+				}
+				w.Header().Set("Retry-After", "0")
+				w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			c, err := client.New(ctx, srv.URL, &client.BuildOption{})
+			require.NoError(t, err)
+			uRL, err := url.Parse(srv.URL)
+			require.NoError(t, err)
+
+			elemType := precheckAttribute("", true, "", false).GetType().(types.ListType).ElemType.(types.ObjectType)
+			apiType := elemType.AttrTypes["api"].(types.ObjectType)
+			statusType := apiType.AttrTypes["status"].(types.ObjectType)
+
+			status, diags := types.ObjectValueFrom(ctx, statusType.AttrTypes, statusDataGo{
+				Success: "Ready",
+				Pending: []string{"Provisioning"},
+			})
+			require.False(t, diags.HasError(), diags)
+			api, diags := types.ObjectValueFrom(ctx, apiType.AttrTypes, precheckDataApi{
+				StatusLocator: types.StringValue("header.X-Provisioning-State"),
+				Status:        status,
+				Path:          types.StringValue("/foos/1"),
+				Query:         types.MapNull(types.ListType{ElemType: types.StringType}),
+				Header:        types.MapNull(types.StringType),
+				DefaultDelay:  types.Int64Null(),
+			})
+			require.False(t, diags.HasError(), diags)
+			prechecks, diags := types.ListValueFrom(ctx, elemType, []precheckData{
+				{
+					Api:    api,
+					Mutex:  types.StringNull(),
+					Action: types.ObjectNull(elemType.AttrTypes["action"].(types.ObjectType).AttrTypes),
+				},
+			})
+			require.False(t, diags.HasError(), diags)
+
+			unlock, diags := precheck(ctx, c, apiOption{BaseURL: *uRL}, "", nil, nil, prechecks, types.DynamicNull())
+			require.Equal(t, tt.expectGets, gets)
+			if tt.expectErr {
+				require.True(t, diags.HasError())
+				return
+			}
+			require.False(t, diags.HasError(), diags)
+			unlock()
+		})
+	}
+}
+
+func TestPrecheckApiCache(t *testing.T) {
+	ctx := context.Background()
+
+	var gets int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		w.Write([]byte(`{"status": "Ready"}`))
+	}))
+	defer srv.Close()
+
+	c, err := client.New(ctx, srv.URL, &client.BuildOption{})
+	require.NoError(t, err)
+	uRL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	elemType := precheckAttribute("", true, "", false).GetType().(types.ListType).ElemType.(types.ObjectType)
+	apiType := elemType.AttrTypes["api"].(types.ObjectType)
+	statusType := apiType.AttrTypes["status"].(types.ObjectType)
+
+	status, diags := types.ObjectValueFrom(ctx, statusType.AttrTypes, statusDataGo{Success: "Ready"})
+	require.False(t, diags.HasError(), diags)
+
+	buildPrechecks := func(path string, ttl types.Int64) types.List {
+		api, diags := types.ObjectValueFrom(ctx, apiType.AttrTypes, precheckDataApi{
+			StatusLocator: types.StringValue("body.status"),
+			Status:        status,
+			Path:          types.StringValue(path),
+			Query:         types.MapNull(types.ListType{ElemType: types.StringType}),
+			Header:        types.MapNull(types.StringType),
+			DefaultDelay:  types.Int64Null(),
+			CacheTTL:      ttl,
+		})
+		require.False(t, diags.HasError(), diags)
+		prechecks, diags := types.ListValueFrom(ctx, elemType, []precheckData{
+			{
+				Api:    api,
+				Mutex:  types.StringNull(),
+				Action: types.ObjectNull(elemType.AttrTypes["action"].(types.ObjectType).AttrTypes),
+			},
+		})
+		require.False(t, diags.HasError(), diags)
+		return prechecks
+	}
+
+	cases := []struct {
+		name       string
+		prechecks  types.List
+		expectGets int
+	}{
+		{
+			name:       "first check polls",
+			prechecks:  buildPrechecks("/foos/1", types.Int64Value(60)),
+			expectGets: 1,
+		},
+		{
+			name:       "same check is cached",
+			prechecks:  buildPrechecks("/foos/1", types.Int64Value(60)),
+			expectGets: 0,
+		},
+		{
+			name:       "different path polls",
+			prechecks:  buildPrechecks("/foos/2", types.Int64Value(60)),
+			expectGets: 1,
+		},
+		{
+			name:       "no cache ttl polls",
+			prechecks:  buildPrechecks("/foos/1", types.Int64Null()),
+			expectGets: 1,
+		},
+	}
+
+	for _, tt := range cases {
+		gets = 0
+		unlock, diags := precheck(ctx, c, apiOption{BaseURL: *uRL}, "", nil, nil, tt.prechecks, types.DynamicNull())
+		require.False(t, diags.HasError(), diags)
+		require.Equal(t, tt.expectGets, gets, tt.name)
+		unlock()
+	}
+}
+
+func TestPrecheckReleaseOnFailure(t *testing.T) {
+	ctx := context.Background()
+
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path)
+		switch r.URL.Path {
+		case "/leases":
+			w.Write([]byte(`{"id": "l1"}`))
+		case "/leases/l1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.Header().Set("X-Provisioning-State", "Failed")
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	c, err := client.New(ctx, srv.URL, &client.BuildOption{})
+	require.NoError(t, err)
+	uRL, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	elemType := precheckAttribute("", true, "", false).GetType().(types.ListType).ElemType.(types.ObjectType)
+	apiType := elemType.AttrTypes["api"].(types.ObjectType)
+	actionType := elemType.AttrTypes["action"].(types.ObjectType)
+	statusType := apiType.AttrTypes["status"].(types.ObjectType)
+
+	action, diags := types.ObjectValueFrom(ctx, actionType.AttrTypes, precheckDataAction{
+		Method:        types.StringValue("POST"),
+		Path:          types.StringValue("/leases"),
+		Body:          types.StringNull(),
+		Query:         types.MapNull(types.ListType{ElemType: types.StringType}),
+		Header:        types.MapNull(types.StringType),
+		ReleaseMethod: types.StringValue("DELETE"),
+		ReleasePath:   types.StringValue("$(path)/$(body.id)"),
+		ReleaseBody:   types.StringNull(),
+	})
+	require.False(t, diags.HasError(), diags)
+	status, diags := types.ObjectValueFrom(ctx, statusType.AttrTypes, statusDataGo{
+		Success: "Ready",
+		Pending: []string{"Provisioning"},
+	})
+	require.False(t, diags.HasError(), diags)
+	api, diags := types.ObjectValueFrom(ctx, apiType.AttrTypes, precheckDataApi{
+		StatusLocator: types.StringValue("header.X-Provisioning-State"),
+		Status:        status,
+		Path:          types.StringValue("/foos/1"),
+		Query:         types.MapNull(types.ListType{ElemType: types.StringType}),
+		Header:        types.MapNull(types.StringType),
+		DefaultDelay:  types.Int64Null(),
+	})
+	require.False(t, diags.HasError(), diags)
+
+	const mutexKey = "precheck-release-on-failure"
+	prechecks, diags := types.ListValueFrom(ctx, elemType, []precheckData{
+		{
+			Api:    types.ObjectNull(apiType.AttrTypes),
+			Mutex:  types.StringNull(),
+			Action: action,
+		},
+		{
+			Api:    types.ObjectNull(apiType.AttrTypes),
+			Mutex:  types.StringValue(mutexKey),
+			Action: types.ObjectNull(actionType.AttrTypes),
+		},
+		{
+			Api:    api,
+			Mutex:  types.StringNull(),
+			Action: types.ObjectNull(actionType.AttrTypes),
+		},
+	})
+	require.False(t, diags.HasError(), diags)
+
+	_, diags = precheck(ctx, c, apiOption{BaseURL: *uRL}, "", nil, nil, prechecks, types.DynamicNull())
+	require.True(t, diags.HasError())
+	require.Equal(t, []string{"POST /leases", "GET /foos/1", "DELETE /leases/l1"}, calls)
+
+	// The mutex is released as well.
+	lockCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	require.NoError(t, locks.Lock(lockCtx, mutexKey))
+	locks.Unlock(mutexKey)
+}
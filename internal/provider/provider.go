@@ -4,12 +4,15 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/base64"
 	"fmt"
 	"net/url"
 	"os"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
@@ -27,10 +30,20 @@ import (
 	"github.com/magodo/terraform-provider-restful/internal/client"
 	"github.com/magodo/terraform-provider-restful/internal/defaults"
 	myvalidator "github.com/magodo/terraform-provider-restful/internal/validator"
+	"software.sslmate.com/src/go-pkcs12"
 )
 
 var _ provider.Provider = &Provider{}
 
+// envOtelTracesExporter is the standard OpenTelemetry environment variable that selects the trace exporter.
+const envOtelTracesExporter = "OTEL_TRACES_EXPORTER"
+
+// The modes of joining the base URL and the paths.
+const (
+	pathJoinModeSmart = "smart"
+	pathJoinModeRaw   = "raw"
+)
+
 type Provider struct {
 	client *client.Client
 	apiOpt apiOption
@@ -44,22 +57,46 @@ type providerData struct {
 
 type providerConfig struct {
 	BaseURL            types.String `tfsdk:"base_url"`
+	PathJoinMode       types.String `tfsdk:"path_join_mode"`
 	Client             types.Object `tfsdk:"client"`
 	Security           types.Object `tfsdk:"security"`
+	RequestSigning     types.Object `tfsdk:"request_signing"`
 	CreateMethod       types.String `tfsdk:"create_method"`
 	UpdateMethod       types.String `tfsdk:"update_method"`
 	DeleteMethod       types.String `tfsdk:"delete_method"`
 	MergePatchDisabled types.Bool   `tfsdk:"merge_patch_disabled"`
+	StrictReadTypes    types.Bool   `tfsdk:"strict_read_types"`
 	Query              types.Map    `tfsdk:"query"`
 	Header             types.Map    `tfsdk:"header"`
+	DefaultPollCreate  types.Object `tfsdk:"default_poll_create"`
+	DefaultPollUpdate  types.Object `tfsdk:"default_poll_update"`
+	DefaultPollDelete  types.Object `tfsdk:"default_poll_delete"`
+
+	DefaultPrecheckCreate types.List `tfsdk:"default_precheck_create"`
+	DefaultPrecheckUpdate types.List `tfsdk:"default_precheck_update"`
+	DefaultPrecheckDelete types.List `tfsdk:"default_precheck_delete"`
 }
 
 type clientData struct {
 	CookieEnabled          types.Bool   `tfsdk:"cookie_enabled"`
+	ForceHTTP1             types.Bool   `tfsdk:"force_http1"`
+	DisableKeepAlives      types.Bool   `tfsdk:"disable_keep_alives"`
+	MaxIdleConns           types.Int64  `tfsdk:"max_idle_conns"`
+	MaxConnsPerHost        types.Int64  `tfsdk:"max_conns_per_host"`
+	IdleConnTimeoutSec     types.Int64  `tfsdk:"idle_conn_timeout_sec"`
+	ExpectContinueTimeout  types.Int64  `tfsdk:"expect_continue_timeout_sec"`
+	ExpectContinueMinBytes types.Int64  `tfsdk:"expect_continue_min_body_bytes"`
+	TracingEnabled         types.Bool   `tfsdk:"tracing_enabled"`
+	ValuelessEmptyQuery    types.Bool   `tfsdk:"valueless_empty_query"`
+	RepeatedHeaders        types.List   `tfsdk:"repeated_headers"`
+	HARFile                types.String `tfsdk:"har_file"`
+	HARBodies              types.Bool   `tfsdk:"har_bodies"`
+	CircuitBreaker         types.Object `tfsdk:"circuit_breaker"`
 	TlsInsecureSkipVerify  types.Bool   `tfsdk:"tls_insecure_skip_verify"`
 	Certificates           types.List   `tfsdk:"certificates"`
 	RootCACertificates     types.List   `tfsdk:"root_ca_certificates"`
 	RootCACertificateFiles types.List   `tfsdk:"root_ca_certificate_files"`
+	RootCAAppend           types.Bool   `tfsdk:"root_ca_append"`
 	Retry                  types.Object `tfsdk:"retry"`
 }
 
@@ -68,6 +105,16 @@ type certificateData struct {
 	CertificateFile types.String `tfsdk:"certificate_file"`
 	Key             types.String `tfsdk:"key"`
 	KeyFile         types.String `tfsdk:"key_file"`
+	PKCS12          types.String `tfsdk:"pkcs12"`
+	PKCS12File      types.String `tfsdk:"pkcs12_file"`
+	PKCS12Password  types.String `tfsdk:"pkcs12_password"`
+	Host            types.String `tfsdk:"host"`
+}
+
+type circuitBreakerData struct {
+	Failures    types.Int64 `tfsdk:"failures"`
+	WindowSec   types.Int64 `tfsdk:"window_sec"`
+	CooldownSec types.Int64 `tfsdk:"cooldown_sec"`
 }
 
 type retryData struct {
@@ -75,12 +122,27 @@ type retryData struct {
 	Count        types.Int64 `tfsdk:"count"`
 	WaitInSec    types.Int64 `tfsdk:"wait_in_sec"`
 	MaxWaitInSec types.Int64 `tfsdk:"max_wait_in_sec"`
+
+	RetryOnConnectionError types.Bool `tfsdk:"retry_on_connection_error"`
 }
 
 type securityData struct {
 	HTTP   types.Object `tfsdk:"http"`
 	OAuth2 types.Object `tfsdk:"oauth2"`
 	APIKey types.Set    `tfsdk:"apikey"`
+	Vault  types.Object `tfsdk:"vault"`
+}
+
+type requestSigningData struct {
+	Algorithm        types.String `tfsdk:"algorithm"`
+	Secret           types.String `tfsdk:"secret"`
+	HeaderName       types.String `tfsdk:"header_name"`
+	IncludeTimestamp types.Bool   `tfsdk:"include_timestamp"`
+	TimestampHeader  types.String `tfsdk:"timestamp_header"`
+}
+
+type vaultData struct {
+	Address types.String `tfsdk:"address"`
 }
 
 type httpData struct {
@@ -193,6 +255,14 @@ func (*Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *p
 					}),
 				},
 			},
+			"path_join_mode": schema.StringAttribute{
+				Description:         "How to join the `base_url` and the paths. Possible values are `smart` and `raw`. `smart` removes the trailing slashes of the `base_url` and ensures a leading slash in the path. `raw` concatenates them verbatim, which is useful for the slash sensitive APIs. Defaults to `smart`.",
+				MarkdownDescription: "How to join the `base_url` and the paths. Possible values are `smart` and `raw`. `smart` removes the trailing slashes of the `base_url` and ensures a leading slash in the path. `raw` concatenates them verbatim, which is useful for the slash sensitive APIs. Defaults to `smart`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(pathJoinModeSmart, pathJoinModeRaw),
+				},
+			},
 			"client": schema.SingleNestedAttribute{
 				Description:         "The client configuration",
 				MarkdownDescription: "The client configuration",
@@ -203,6 +273,114 @@ func (*Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *p
 						MarkdownDescription: "Save cookies during API contracting. Defaults to `false`.",
 						Optional:            true,
 					},
+					"force_http1": schema.BoolAttribute{
+						Description:         "Whether to force using HTTP/1.1, i.e. disable HTTP/2. This is useful for servers that misbehave under HTTP/2. Defaults to `false`.",
+						MarkdownDescription: "Whether to force using HTTP/1.1, i.e. disable HTTP/2. This is useful for servers that misbehave under HTTP/2. Defaults to `false`.",
+						Optional:            true,
+					},
+					"disable_keep_alives": schema.BoolAttribute{
+						Description:         "Whether to disable HTTP keep-alives, i.e. only use the connection for a single request. This is useful for servers that don't handle connection reuse well. Defaults to `false`.",
+						MarkdownDescription: "Whether to disable HTTP keep-alives, i.e. only use the connection for a single request. This is useful for servers that don't handle connection reuse well. Defaults to `false`.",
+						Optional:            true,
+					},
+					"max_idle_conns": schema.Int64Attribute{
+						Description:         "The maximum number of idle (keep-alive) connections across all hosts. Zero means no limit. Defaults to `100`.",
+						MarkdownDescription: "The maximum number of idle (keep-alive) connections across all hosts. Zero means no limit. Defaults to `100`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"max_conns_per_host": schema.Int64Attribute{
+						Description:         "The maximum number of connections per host, including connections in the dialing, active, and idle states. On limit violation, dials will block. Zero means no limit. Defaults to `0`.",
+						MarkdownDescription: "The maximum number of connections per host, including connections in the dialing, active, and idle states. On limit violation, dials will block. Zero means no limit. Defaults to `0`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"idle_conn_timeout_sec": schema.Int64Attribute{
+						Description:         "The maximum amount of time in second an idle (keep-alive) connection will remain idle before closing itself. Zero means no limit. Defaults to `90`.",
+						MarkdownDescription: "The maximum amount of time in second an idle (keep-alive) connection will remain idle before closing itself. Zero means no limit. Defaults to `90`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+						},
+					},
+					"expect_continue_timeout_sec": schema.Int64Attribute{
+						Description:         "Send the `Expect: 100-continue` header for the requests with a body, and wait up to this amount of time in second for the server's first response headers before sending the body. This is useful for the upload endpoints that stall on large bodies without negotiating the `100-continue`. Defaults to not sending the header.",
+						MarkdownDescription: "Send the `Expect: 100-continue` header for the requests with a body, and wait up to this amount of time in second for the server's first response headers before sending the body. This is useful for the upload endpoints that stall on large bodies without negotiating the `100-continue`. Defaults to not sending the header.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"expect_continue_min_body_bytes": schema.Int64Attribute{
+						Description:         "Only send the `Expect: 100-continue` header for the requests whose body is larger than this size in bytes. The requests with a body of unknown size always send the header. Defaults to `0`.",
+						MarkdownDescription: "Only send the `Expect: 100-continue` header for the requests whose body is larger than this size in bytes. The requests with a body of unknown size always send the header. Defaults to `0`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(0),
+							int64validator.AlsoRequires(path.MatchRelative().AtParent().AtName("expect_continue_timeout_sec")),
+						},
+					},
+					"tracing_enabled": schema.BoolAttribute{
+						Description:         fmt.Sprintf("Whether to emit the OpenTelemetry spans for the API calls, with the method, host, path and status code as attributes. The spans are exported via OTLP/HTTP, which is configured by the standard `OTEL_EXPORTER_OTLP_*` environment variables. Defaults to `true` if the `%s` environment variable is `otlp`, otherwise `false`.", envOtelTracesExporter),
+						MarkdownDescription: fmt.Sprintf("Whether to emit the OpenTelemetry spans for the API calls, with the method, host, path and status code as attributes. The spans are exported via OTLP/HTTP, which is configured by the standard `OTEL_EXPORTER_OTLP_*` environment variables. Defaults to `true` if the `%s` environment variable is `otlp`, otherwise `false`.", envOtelTracesExporter),
+						Optional:            true,
+					},
+					"valueless_empty_query": schema.BoolAttribute{
+						Description:         "Whether to send the query parameters whose value is an empty string as valueless flags, e.g. `?force` rather than `?force=`. This is useful for APIs that expect the boolean flags in the query. Defaults to `false`.",
+						MarkdownDescription: "Whether to send the query parameters whose value is an empty string as valueless flags, e.g. `?force` rather than `?force=`. This is useful for APIs that expect the boolean flags in the query. Defaults to `false`.",
+						Optional:            true,
+					},
+					"repeated_headers": schema.ListAttribute{
+						Description:         "The names of the headers whose comma separated value is sent as repeated header lines, e.g. the `Accept` header with value `a, b` is sent as two `Accept` header lines with value `a` and `b`. This is useful for APIs that require the multi-valued headers in separate lines.",
+						MarkdownDescription: "The names of the headers whose comma separated value is sent as repeated header lines, e.g. the `Accept` header with value `a, b` is sent as two `Accept` header lines with value `a` and `b`. This is useful for APIs that require the multi-valued headers in separate lines.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"har_file": schema.StringAttribute{
+						Description:         "The path to a HAR (HTTP Archive) file, where all the API calls are recorded, which is useful for filing reproducible bug reports or building test fixtures. The `Authorization`, `Proxy-Authorization`, `Cookie` and `Set-Cookie` headers, as well as the API keys of the `security` block, are redacted. The request and response bodies are redacted as well, unless `har_bodies` is `true`.",
+						MarkdownDescription: "The path to a HAR (HTTP Archive) file, where all the API calls are recorded, which is useful for filing reproducible bug reports or building test fixtures. The `Authorization`, `Proxy-Authorization`, `Cookie` and `Set-Cookie` headers, as well as the API keys of the `security` block, are redacted. The request and response bodies are redacted as well, unless `har_bodies` is `true`.",
+						Optional:            true,
+					},
+					"har_bodies": schema.BoolAttribute{
+						Description:         "Whether to record the request and response bodies in the `har_file` as is. Note that the bodies can carry the secrets, e.g. the login requests, the tokens and the ephemeral resources, which are written to the disk in plain text. Defaults to `false`.",
+						MarkdownDescription: "Whether to record the request and response bodies in the `har_file` as is. Note that the bodies can carry the secrets, e.g. the login requests, the tokens and the ephemeral resources, which are written to the disk in plain text. Defaults to `false`.",
+						Optional:            true,
+					},
+					"circuit_breaker": schema.SingleNestedAttribute{
+						Description:         "The circuit breaker that fails the requests to a host fast, after a number of consecutive failures (i.e. connection errors and 5xx responses, including the retries) to it within a window, until a cooldown elapses. This is useful to avoid hammering a struggling API with a large plan.",
+						MarkdownDescription: "The circuit breaker that fails the requests to a host fast, after a number of consecutive failures (i.e. connection errors and 5xx responses, including the retries) to it within a window, until a cooldown elapses. This is useful to avoid hammering a struggling API with a large plan.",
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"failures": schema.Int64Attribute{
+								Description:         "The number of consecutive failures to a host that opens the circuit.",
+								MarkdownDescription: "The number of consecutive failures to a host that opens the circuit.",
+								Required:            true,
+								Validators: []validator.Int64{
+									int64validator.AtLeast(1),
+								},
+							},
+							"window_sec": schema.Int64Attribute{
+								Description:         "The window in second, within which the consecutive failures are counted. Defaults to `60`.",
+								MarkdownDescription: "The window in second, within which the consecutive failures are counted. Defaults to `60`.",
+								Optional:            true,
+								Validators: []validator.Int64{
+									int64validator.AtLeast(1),
+								},
+							},
+							"cooldown_sec": schema.Int64Attribute{
+								Description:         "The amount of time in second the circuit remains open, during which the requests to the host fail immediately. Defaults to `30`.",
+								MarkdownDescription: "The amount of time in second the circuit remains open, during which the requests to the host fail immediately. Defaults to `30`.",
+								Optional:            true,
+								Validators: []validator.Int64{
+									int64validator.AtLeast(1),
+								},
+							},
+						},
+					},
 					"tls_insecure_skip_verify": schema.BoolAttribute{
 						Description:         "Whether a client verifies the server's certificate chain and host name. Defaults to `false`.",
 						MarkdownDescription: "Whether a client verifies the server's certificate chain and host name. Defaults to `false`.",
@@ -270,6 +448,55 @@ func (*Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *p
 										),
 									},
 								},
+								"pkcs12": schema.StringAttribute{
+									Description:         "The base64 encoded PKCS#12 bundle (e.g. `.p12`, `.pfx`) that contains the client certificate, private key and optionally the CA chain for mTLS. Conflicts with `pkcs12_file`, `certificate`, `certificate_file`, `key` and `key_file`.",
+									MarkdownDescription: "The base64 encoded PKCS#12 bundle (e.g. `.p12`, `.pfx`) that contains the client certificate, private key and optionally the CA chain for mTLS. Conflicts with `pkcs12_file`, `certificate`, `certificate_file`, `key` and `key_file`.",
+									Optional:            true,
+									Sensitive:           true,
+									Validators: []validator.String{
+										stringvalidator.ConflictsWith(
+											path.MatchRelative().AtParent().AtName("pkcs12_file"),
+											path.MatchRelative().AtParent().AtName("certificate"),
+											path.MatchRelative().AtParent().AtName("certificate_file"),
+											path.MatchRelative().AtParent().AtName("key"),
+											path.MatchRelative().AtParent().AtName("key_file"),
+										),
+									},
+								},
+								"pkcs12_file": schema.StringAttribute{
+									Description:         "The path of the PKCS#12 bundle file (e.g. `.p12`, `.pfx`) that contains the client certificate, private key and optionally the CA chain for mTLS. Conflicts with `pkcs12`, `certificate`, `certificate_file`, `key` and `key_file`.",
+									MarkdownDescription: "The path of the PKCS#12 bundle file (e.g. `.p12`, `.pfx`) that contains the client certificate, private key and optionally the CA chain for mTLS. Conflicts with `pkcs12`, `certificate`, `certificate_file`, `key` and `key_file`.",
+									Optional:            true,
+									Validators: []validator.String{
+										stringvalidator.ConflictsWith(
+											path.MatchRelative().AtParent().AtName("pkcs12"),
+											path.MatchRelative().AtParent().AtName("certificate"),
+											path.MatchRelative().AtParent().AtName("certificate_file"),
+											path.MatchRelative().AtParent().AtName("key"),
+											path.MatchRelative().AtParent().AtName("key_file"),
+										),
+									},
+								},
+								"host": schema.StringAttribute{
+									Description:         "The host name (without port) of the server that this client certificate is presented to, which is useful when different upstreams require different client certificates. The certificates without `host` are presented to the other hosts.",
+									MarkdownDescription: "The host name (without port) of the server that this client certificate is presented to, which is useful when different upstreams require different client certificates. The certificates without `host` are presented to the other hosts.",
+									Optional:            true,
+									Validators: []validator.String{
+										stringvalidator.LengthAtLeast(1),
+									},
+								},
+								"pkcs12_password": schema.StringAttribute{
+									Description:         "The password of the PKCS#12 bundle. Requires `pkcs12` or `pkcs12_file`.",
+									MarkdownDescription: "The password of the PKCS#12 bundle. Requires `pkcs12` or `pkcs12_file`.",
+									Optional:            true,
+									Sensitive:           true,
+									Validators: []validator.String{
+										stringvalidator.AtLeastOneOf(
+											path.MatchRelative().AtParent().AtName("pkcs12"),
+											path.MatchRelative().AtParent().AtName("pkcs12_file"),
+										),
+									},
+								},
 							},
 						},
 					},
@@ -301,6 +528,11 @@ func (*Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *p
 							),
 						},
 					},
+					"root_ca_append": schema.BoolAttribute{
+						Description:         "Whether to append the root CA certificates specified by `root_ca_certificates` or `root_ca_certificate_files` to the host's root CA set, instead of replacing it. Defaults to `false`.",
+						MarkdownDescription: "Whether to append the root CA certificates specified by `root_ca_certificates` or `root_ca_certificate_files` to the host's root CA set, instead of replacing it. Defaults to `false`.",
+						Optional:            true,
+					},
 					"retry": schema.SingleNestedAttribute{
 						Description:         "The retry option for the client",
 						MarkdownDescription: "The retry option for the client",
@@ -327,6 +559,11 @@ func (*Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *p
 								MarkdownDescription: fmt.Sprintf("The maximum allowed retry wait time. Defaults to `%v`.", defaults.RetryMaxWaitTime.Seconds()),
 								Optional:            true,
 							},
+							"retry_on_connection_error": schema.BoolAttribute{
+								Description:         "Whether to retry on the connection errors (e.g. connection reset, DNS failure, TLS handshake timeout), where there is no response. Setting it to `false` makes the client fail fast on these errors, while still retrying on the `status_codes`. Defaults to `true`.",
+								MarkdownDescription: "Whether to retry on the connection errors (e.g. connection reset, DNS failure, TLS handshake timeout), where there is no response. Setting it to `false` makes the client fail fast on these errors, while still retrying on the `status_codes`. Defaults to `true`.",
+								Optional:            true,
+							},
 						},
 					},
 				},
@@ -609,6 +846,54 @@ func (*Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *p
 							),
 						},
 					},
+					"vault": schema.SingleNestedAttribute{
+						Description:         fmt.Sprintf("Configuration for reading the secrets from HashiCorp Vault. When specified, the `password`, `token`, `client_secret`, `refresh_token` and the API key `value` of the authentication scheme can be a reference in form of `%s<path>#<key>` (e.g. `%ssecret/data/myapp#client_secret`), which is read from the KV secrets engine (v1 or v2) when the provider is configured. The Vault token is read from the `%s` environment variable.", vaultRefPrefix, vaultRefPrefix, envVaultToken),
+						MarkdownDescription: fmt.Sprintf("Configuration for reading the secrets from HashiCorp Vault. When specified, the `password`, `token`, `client_secret`, `refresh_token` and the API key `value` of the authentication scheme can be a reference in form of `%s<path>#<key>` (e.g. `%ssecret/data/myapp#client_secret`), which is read from the KV secrets engine (v1 or v2) when the provider is configured. The Vault token is read from the `%s` environment variable.", vaultRefPrefix, vaultRefPrefix, envVaultToken),
+						Optional:            true,
+						Attributes: map[string]schema.Attribute{
+							"address": schema.StringAttribute{
+								Description:         fmt.Sprintf("The address of the Vault server. Defaults to the `%s` environment variable.", envVaultAddr),
+								MarkdownDescription: fmt.Sprintf("The address of the Vault server. Defaults to the `%s` environment variable.", envVaultAddr),
+								Optional:            true,
+							},
+						},
+					},
+				},
+			},
+			"request_signing": schema.SingleNestedAttribute{
+				Description:         "Sign each request with an HMAC signature of the request body, which is sent in a header. The signature is computed for every request (including retries), after the request body is finalized.",
+				MarkdownDescription: "Sign each request with an HMAC signature of the request body, which is sent in a header. The signature is computed for every request (including retries), after the request body is finalized.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"algorithm": schema.StringAttribute{
+						Description:         fmt.Sprintf("The signing algorithm. Possible values are `%s` and `%s`. Defaults to `%s`.", client.SigningAlgorithmHMACSHA256, client.SigningAlgorithmHMACSHA512, client.SigningAlgorithmHMACSHA256),
+						MarkdownDescription: fmt.Sprintf("The signing algorithm. Possible values are `%s` and `%s`. Defaults to `%s`.", client.SigningAlgorithmHMACSHA256, client.SigningAlgorithmHMACSHA512, client.SigningAlgorithmHMACSHA256),
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.OneOf(client.SigningAlgorithmHMACSHA256, client.SigningAlgorithmHMACSHA512),
+						},
+					},
+					"secret": schema.StringAttribute{
+						Description:         "The secret key used to compute the signature.",
+						MarkdownDescription: "The secret key used to compute the signature.",
+						Required:            true,
+						Sensitive:           true,
+					},
+					"header_name": schema.StringAttribute{
+						Description:         "The name of the header that contains the hex encoded signature. Defaults to `X-Signature`.",
+						MarkdownDescription: "The name of the header that contains the hex encoded signature. Defaults to `X-Signature`.",
+						Optional:            true,
+					},
+					"include_timestamp": schema.BoolAttribute{
+						Description:         "Whether to include the current Unix timestamp (in second) in the signed message, in form of `<timestamp>.<body>`. The timestamp is sent in the `timestamp_header`. Defaults to `false`.",
+						MarkdownDescription: "Whether to include the current Unix timestamp (in second) in the signed message, in form of `<timestamp>.<body>`. The timestamp is sent in the `timestamp_header`. Defaults to `false`.",
+						Optional:            true,
+					},
+					"timestamp_header": schema.StringAttribute{
+						Description:         "The name of the header that contains the timestamp, when `include_timestamp` is `true`. Defaults to `X-Timestamp`.",
+						MarkdownDescription: "The name of the header that contains the timestamp, when `include_timestamp` is `true`. Defaults to `X-Timestamp`.",
+						Optional:            true,
+					},
 				},
 			},
 			"create_method": schema.StringAttribute{
@@ -640,6 +925,17 @@ func (*Provider) Schema(ctx context.Context, req provider.SchemaRequest, resp *p
 				MarkdownDescription: "Whether to use a JSON Merge Patch as the request body in the PATCH update? Defaults to `false`. This is only effective when `update_method` is set to `PATCH`.",
 				Optional:            true,
 			},
+			"default_poll_create":     providerPollAttribute("Create"),
+			"default_poll_update":     providerPollAttribute("Update"),
+			"default_poll_delete":     providerPollAttribute("Delete"),
+			"default_precheck_create": providerPrecheckAttribute("Create", true, ""),
+			"default_precheck_update": providerPrecheckAttribute("Update", false, "By default, the `id` of the resource is used."),
+			"default_precheck_delete": providerPrecheckAttribute("Delete", false, "By default, the `id` of the resource is used."),
+			"strict_read_types": schema.BoolAttribute{
+				Description:         "Whether to raise an error when the type of the read response body doesn't match the type of the `body` in the state (e.g. a tuple has a different number of elements)? Defaults to `false`, which falls back to the implied type of the response body.",
+				MarkdownDescription: "Whether to raise an error when the type of the read response body doesn't match the type of the `body` in the state (e.g. a tuple has a different number of elements)? Defaults to `false`, which falls back to the implied type of the response body.",
+				Optional:            true,
+			},
 			"query": schema.MapAttribute{
 				Description:         "The query parameters that are applied to each request.",
 				MarkdownDescription: "The query parameters that are applied to each request.",
@@ -698,6 +994,32 @@ func (p *Provider) Init(ctx context.Context, config providerConfig) diag.Diagnos
 			clientOpt.Security = security
 		}
 
+		if sRaw := config.RequestSigning; !sRaw.IsNull() {
+			var d requestSigningData
+			if diags := sRaw.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
+				odiags = diags
+				return
+			}
+			clientOpt.RequestSigning = &client.RequestSigningOption{
+				Algorithm:        client.SigningAlgorithmHMACSHA256,
+				Secret:           d.Secret.ValueString(),
+				HeaderName:       "X-Signature",
+				IncludeTimestamp: d.IncludeTimestamp.ValueBool(),
+				TimestampHeader:  "X-Timestamp",
+			}
+			if !d.Algorithm.IsNull() {
+				clientOpt.RequestSigning.Algorithm = d.Algorithm.ValueString()
+			}
+			if !d.HeaderName.IsNull() {
+				clientOpt.RequestSigning.HeaderName = d.HeaderName.ValueString()
+			}
+			if !d.TimestampHeader.IsNull() {
+				clientOpt.RequestSigning.TimestampHeader = d.TimestampHeader.ValueString()
+			}
+		}
+
+		clientOpt.RawPathJoin = config.PathJoinMode.ValueString() == pathJoinModeRaw
+
 		var (
 			diags diag.Diagnostics
 			err   error
@@ -728,6 +1050,8 @@ func (p *Provider) Init(ctx context.Context, config providerConfig) diag.Diagnos
 			UpdateMethod:       "PUT",
 			DeleteMethod:       "DELETE",
 			MergePatchDisabled: false,
+			StrictReadTypes:    false,
+			RawPathJoin:        clientOpt.RawPathJoin,
 			Query:              map[string][]string{},
 			Header:             map[string]string{},
 		}
@@ -743,6 +1067,15 @@ func (p *Provider) Init(ctx context.Context, config providerConfig) diag.Diagnos
 		if !config.MergePatchDisabled.IsNull() {
 			p.apiOpt.MergePatchDisabled = config.MergePatchDisabled.ValueBool()
 		}
+		if !config.StrictReadTypes.IsNull() {
+			p.apiOpt.StrictReadTypes = config.StrictReadTypes.ValueBool()
+		}
+		p.apiOpt.DefaultPollCreate = config.DefaultPollCreate
+		p.apiOpt.DefaultPollUpdate = config.DefaultPollUpdate
+		p.apiOpt.DefaultPollDelete = config.DefaultPollDelete
+		p.apiOpt.DefaultPrecheckCreate = config.DefaultPrecheckCreate
+		p.apiOpt.DefaultPrecheckUpdate = config.DefaultPrecheckUpdate
+		p.apiOpt.DefaultPrecheckDelete = config.DefaultPrecheckDelete
 		if !config.Query.IsNull() {
 			queries := map[string][]string{}
 			for k, values := range config.Query.Elements() {
@@ -757,7 +1090,7 @@ func (p *Provider) Init(ctx context.Context, config providerConfig) diag.Diagnos
 				}
 				queries[k] = vs
 			}
-			p.apiOpt.Query = queries
+			p.apiOpt.Query = client.Query(queries).Dedup()
 		}
 		if !config.Header.IsNull() {
 			headers := map[string]string{}
@@ -775,6 +1108,229 @@ func (p *Provider) Init(ctx context.Context, config providerConfig) diag.Diagnos
 	return odiags
 }
 
+// providerPollAttribute is the provider counterpart of the pollAttribute, which is used as the default polling option of the `restful_resource`.
+func providerPollAttribute(s string) schema.SingleNestedAttribute {
+	statusAttribute := func(desc string) schema.SingleNestedAttribute {
+		return schema.SingleNestedAttribute{
+			Description:         desc,
+			MarkdownDescription: desc,
+			Required:            true,
+			Attributes: map[string]schema.Attribute{
+				"success": schema.StringAttribute{
+					Description:         "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+					MarkdownDescription: "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+					Required:            true,
+				},
+				"pending": schema.ListAttribute{
+					Description:         "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+					MarkdownDescription: "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+					Optional:            true,
+					ElementType:         types.StringType,
+				},
+			},
+		}
+	}
+	return schema.SingleNestedAttribute{
+		Description:         fmt.Sprintf("The default polling option for the %q operation of the `restful_resource`, which is used when the resource doesn't specify the `poll_%s`. The attributes are the same as the `poll_%s` of the `restful_resource`.", s, strings.ToLower(s), strings.ToLower(s)),
+		MarkdownDescription: fmt.Sprintf("The default polling option for the %q operation of the `restful_resource`, which is used when the resource doesn't specify the `poll_%s`. The attributes are the same as the `poll_%s` of the `restful_resource`.", s, strings.ToLower(s), strings.ToLower(s)),
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"status_locator": schema.StringAttribute{
+				Description:         "Specifies how to discover the status property. The format is either `code` or `scope.path`, where `scope` can be either `header` or `body`, and the `path` is using the gjson syntax.",
+				MarkdownDescription: "Specifies how to discover the status property. The format is either `code` or `scope.path`, where `scope` can be either `header` or `body`, and the `path` is using the [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md).",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsParsable("status_locator", func(s string) error {
+						return validateLocator(s)
+					}),
+				},
+			},
+			"status": statusAttribute("The expected status sentinels for each polling state."),
+			"url_locator": schema.StringAttribute{
+				Description:         "Specifies how to discover the polling url. The format can be one of `header.path`, `body.path`, `link.rel` or `exact.value`.",
+				MarkdownDescription: "Specifies how to discover the polling url. The format can be one of `header.path`, `body.path`, `link.rel` or `exact.value`.",
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsParsable("url_locator", func(s string) error {
+						return validateLocator(s)
+					}),
+				},
+			},
+			"header": schema.MapAttribute{
+				Description:         "The header parameters. This overrides the `header` set in the resource block.",
+				MarkdownDescription: "The header parameters. This overrides the `header` set in the resource block.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"default_delay_sec": schema.Int64Attribute{
+				Description:         "The interval between two pollings if there is no `Retry-After` in the response header, in second. Defaults to `10`.",
+				MarkdownDescription: "The interval between two pollings if there is no `Retry-After` in the response header, in second. Defaults to `10`.",
+				Optional:            true,
+			},
+			"retry_status_codes": schema.ListAttribute{
+				Description:         "The status codes of the polling response that are regarded as transient errors, which keep the polling going instead of failing it. The polling still fails after 10 transient responses in a row.",
+				MarkdownDescription: "The status codes of the polling response that are regarded as transient errors, which keep the polling going instead of failing it. The polling still fails after 10 transient responses in a row.",
+				Optional:            true,
+				ElementType:         types.Int64Type,
+			},
+			"conditions": schema.ListNestedAttribute{
+				Description:         "The extra status conditions, which all need to be satisfied together with the `status_locator` and `status` for the polling to be done.",
+				MarkdownDescription: "The extra status conditions, which all need to be satisfied together with the `status_locator` and `status` for the polling to be done.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"status_locator": schema.StringAttribute{
+							Description:         "Specifies how to discover the status property of this condition, in the same format as the `status_locator`.",
+							MarkdownDescription: "Specifies how to discover the status property of this condition, in the same format as the `status_locator`.",
+							Required:            true,
+							Validators: []validator.String{
+								myvalidator.StringIsParsable("status_locator", func(s string) error {
+									return validateLocator(s)
+								}),
+							},
+						},
+						"status": statusAttribute("The expected status sentinels for each polling state of this condition."),
+					},
+					Validators: []validator.Object{
+						codeStatusValidator{},
+					},
+				},
+			},
+			"abort_if": schema.ListNestedAttribute{
+				Description:         "The conditions that abort the polling with an error once any of them is matched, regardless of the `status`. This is useful when the operation has already failed (e.g. indicated by a nested property) while the status is still pending.",
+				MarkdownDescription: "The conditions that abort the polling with an error once any of them is matched, regardless of the `status`. This is useful when the operation has already failed (e.g. indicated by a nested property) while the status is still pending.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"locator": schema.StringAttribute{
+							Description:         "Specifies how to discover the value to check, in the same format as the `status_locator`.",
+							MarkdownDescription: "Specifies how to discover the value to check, in the same format as the `status_locator`.",
+							Required:            true,
+							Validators: []validator.String{
+								myvalidator.StringIsParsable("locator", func(s string) error {
+									return validateLocator(s)
+								}),
+							},
+						},
+						"values": schema.ListAttribute{
+							Description:         "The values that abort the polling.",
+							MarkdownDescription: "The values that abort the polling.",
+							Required:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+		Validators: []validator.Object{
+			codeStatusValidator{},
+		},
+	}
+}
+
+// providerPrecheckAttribute is the provider counterpart of the precheckAttribute, which is used as the default prechecks of the `restful_resource`.
+func providerPrecheckAttribute(s string, pathIsRequired bool, suffixDesc string) schema.ListNestedAttribute {
+	pathDesc := "The path used to query readiness, relative to the `base_url` of the provider."
+	if suffixDesc != "" {
+		pathDesc += " " + suffixDesc
+	}
+
+	return schema.ListNestedAttribute{
+		Description:         fmt.Sprintf("An array of default prechecks that need to pass prior to the %q operation of the `restful_resource`, which are used when the resource doesn't specify the `precheck_%s`. Exactly one of `mutex`, `api` or `action` should be specified.", s, strings.ToLower(s)),
+		MarkdownDescription: fmt.Sprintf("An array of default prechecks that need to pass prior to the %q operation of the `restful_resource`, which are used when the resource doesn't specify the `precheck_%s`. Exactly one of `mutex`, `api` or `action` should be specified.", s, strings.ToLower(s)),
+		Optional:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"mutex": schema.StringAttribute{
+					Description:         "The name of the mutex, which implies the resource will keep waiting until this mutex is held",
+					MarkdownDescription: "The name of the mutex, which implies the resource will keep waiting until this mutex is held",
+					Optional:            true,
+					Validators: []validator.String{
+						stringvalidator.ExactlyOneOf(
+							path.MatchRelative().AtParent().AtName("api"),
+							path.MatchRelative().AtParent().AtName("action"),
+						),
+					},
+				},
+				"api": schema.SingleNestedAttribute{
+					Description:         "Keeps waiting until the specified API meets the success status",
+					MarkdownDescription: "Keeps waiting until the specified API meets the success status",
+					Optional:            true,
+					Attributes: map[string]schema.Attribute{
+						"status_locator": schema.StringAttribute{
+							Description:         "Specifies how to discover the status property. The format is either `code` or `scope.path`, where `scope` can be either `header` or `body`, and the `path` is using the gjson syntax.",
+							MarkdownDescription: "Specifies how to discover the status property. The format is either `code` or `scope.path`, where `scope` can be either `header` or `body`, and the `path` is using the [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md).",
+							Required:            true,
+							Validators: []validator.String{
+								myvalidator.StringIsParsable("status_locator", func(s string) error {
+									return validateLocator(s)
+								}),
+							},
+						},
+						"status": schema.SingleNestedAttribute{
+							Description:         "The expected status sentinels for each polling state.",
+							MarkdownDescription: "The expected status sentinels for each polling state.",
+							Required:            true,
+							Attributes: map[string]schema.Attribute{
+								"success": schema.StringAttribute{
+									Description:         "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+									MarkdownDescription: "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+									Required:            true,
+								},
+								"pending": schema.ListAttribute{
+									Description:         "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+									MarkdownDescription: "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+									Optional:            true,
+									ElementType:         types.StringType,
+								},
+							},
+						},
+						"path": schema.StringAttribute{
+							Description:         pathDesc,
+							MarkdownDescription: pathDesc,
+							Required:            pathIsRequired,
+							Optional:            !pathIsRequired,
+						},
+						"query": schema.MapAttribute{
+							Description:         "The query parameters. This overrides the `query` set in the resource block.",
+							MarkdownDescription: "The query parameters. This overrides the `query` set in the resource block.",
+							ElementType:         types.ListType{ElemType: types.StringType},
+							Optional:            true,
+						},
+						"header": schema.MapAttribute{
+							Description:         "The header parameters. This overrides the `header` set in the resource block.",
+							MarkdownDescription: "The header parameters. This overrides the `header` set in the resource block.",
+							ElementType:         types.StringType,
+							Optional:            true,
+						},
+						"default_delay_sec": schema.Int64Attribute{
+							Description:         "The interval between two pollings if there is no `Retry-After` in the response header, in second. Defaults to `10`.",
+							MarkdownDescription: "The interval between two pollings if there is no `Retry-After` in the response header, in second. Defaults to `10`.",
+							Optional:            true,
+						},
+						"cache_ttl_sec": schema.Int64Attribute{
+							Description:         "Once the check passes, the other checks with the same effective request (i.e. the URL, header, status locator and status) are regarded as passed without polling for this amount of time in second, within the same provider process (e.g. a single apply). This is useful when many resources share the same readiness check. Defaults to not caching.",
+							MarkdownDescription: "Once the check passes, the other checks with the same effective request (i.e. the URL, header, status locator and status) are regarded as passed without polling for this amount of time in second, within the same provider process (e.g. a single apply). This is useful when many resources share the same readiness check. Defaults to not caching.",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
+					},
+					Validators: []validator.Object{
+						objectvalidator.ExactlyOneOf(
+							path.MatchRelative().AtParent().AtName("mutex"),
+							path.MatchRelative().AtParent().AtName("action"),
+						),
+						codeStatusValidator{},
+					},
+				},
+				"action": precheckActionAttribute(),
+			},
+		},
+	}
+}
+
 func (c clientData) ToClientBuildOption(ctx context.Context) (*client.BuildOption, diag.Diagnostics) {
 	var diags diag.Diagnostics
 	var clientOpt client.BuildOption
@@ -804,6 +1360,17 @@ func (c clientData) ToClientBuildOption(ctx context.Context) (*client.BuildOptio
 	}
 	if len(caCerts) != 0 {
 		caPool := x509.NewCertPool()
+		if c.RootCAAppend.ValueBool() {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				diags.AddError(
+					"Failed to build client option",
+					fmt.Sprintf("loading system cert pool: %v", err),
+				)
+				return nil, diags
+			}
+			caPool = pool
+		}
 		for _, cert := range caCerts {
 			caPool.AppendCertsFromPEM(cert)
 		}
@@ -812,12 +1379,56 @@ func (c clientData) ToClientBuildOption(ctx context.Context) (*client.BuildOptio
 
 	if !c.Certificates.IsNull() {
 		var certs []tls.Certificate
+		hostCerts := map[string][]tls.Certificate{}
 		for _, e := range c.Certificates.Elements() {
 			obj := e.(types.Object)
 			var cd certificateData
 			if diags := obj.As(ctx, &cd, basetypes.ObjectAsOptions{}); diags.HasError() {
 				return nil, diags
 			}
+			addCert := func(cert tls.Certificate) {
+				if cd.Host.IsNull() {
+					certs = append(certs, cert)
+					return
+				}
+				host := strings.ToLower(cd.Host.ValueString())
+				hostCerts[host] = append(hostCerts[host], cert)
+			}
+
+			if !cd.PKCS12.IsNull() || !cd.PKCS12File.IsNull() {
+				var pfxB []byte
+				var err error
+				switch {
+				case !cd.PKCS12.IsNull():
+					pfxB, err = base64.StdEncoding.DecodeString(cd.PKCS12.ValueString())
+					if err != nil {
+						diags.AddError(
+							"Failed to build client option",
+							fmt.Sprintf("base64 decoding pkcs12: %v", err),
+						)
+						return nil, diags
+					}
+				case !cd.PKCS12File.IsNull():
+					pfxB, err = os.ReadFile(cd.PKCS12File.ValueString())
+					if err != nil {
+						diags.AddError(
+							"Failed to build client option",
+							fmt.Sprintf("reading %s: %v", cd.PKCS12File.ValueString(), err),
+						)
+						return nil, diags
+					}
+				}
+				cert, err := pkcs12Certificate(pfxB, cd.PKCS12Password.ValueString())
+				if err != nil {
+					diags.AddError(
+						"Failed to build client option",
+						fmt.Sprintf("decoding pkcs12: %v", err),
+					)
+					return nil, diags
+				}
+				addCert(*cert)
+				continue
+			}
 
 			var certB, keyB []byte
 			var err error
@@ -858,12 +1469,65 @@ func (c clientData) ToClientBuildOption(ctx context.Context) (*client.BuildOptio
 				)
 				return nil, diags
 			}
-			certs = append(certs, cert)
+			addCert(cert)
 		}
 		clientOpt.TLSConfig.Certificates = certs
+		if len(hostCerts) != 0 {
+			clientOpt.HostCertificates = hostCerts
+		}
 	}
 
 	clientOpt.CookieEnabled = c.CookieEnabled.ValueBool()
+	clientOpt.ForceHTTP1 = c.ForceHTTP1.ValueBool()
+	clientOpt.DisableKeepAlives = c.DisableKeepAlives.ValueBool()
+	if !c.MaxIdleConns.IsNull() {
+		v := int(c.MaxIdleConns.ValueInt64())
+		clientOpt.MaxIdleConns = &v
+	}
+	if !c.MaxConnsPerHost.IsNull() {
+		v := int(c.MaxConnsPerHost.ValueInt64())
+		clientOpt.MaxConnsPerHost = &v
+	}
+	if !c.IdleConnTimeoutSec.IsNull() {
+		v := time.Duration(c.IdleConnTimeoutSec.ValueInt64()) * time.Second
+		clientOpt.IdleConnTimeout = &v
+	}
+	clientOpt.Tracing = os.Getenv(envOtelTracesExporter) == "otlp"
+	if !c.TracingEnabled.IsNull() {
+		clientOpt.Tracing = c.TracingEnabled.ValueBool()
+	}
+	if !c.ValuelessEmptyQuery.IsNull() {
+		clientOpt.ValuelessEmptyQuery = c.ValuelessEmptyQuery.ValueBool()
+	}
+	for _, name := range c.RepeatedHeaders.Elements() {
+		clientOpt.RepeatedHeaders = append(clientOpt.RepeatedHeaders, name.(types.String).ValueString())
+	}
+	clientOpt.HARFile = c.HARFile.ValueString()
+	clientOpt.HARBodies = c.HARBodies.ValueBool()
+	if !c.CircuitBreaker.IsNull() {
+		var cb circuitBreakerData
+		if diags := c.CircuitBreaker.As(ctx, &cb, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return nil, diags
+		}
+		opt := client.CircuitBreakerOption{
+			Failures: int(cb.Failures.ValueInt64()),
+			Window:   time.Minute,
+			Cooldown: 30 * time.Second,
+		}
+		if !cb.WindowSec.IsNull() {
+			opt.Window = time.Duration(cb.WindowSec.ValueInt64()) * time.Second
+		}
+		if !cb.CooldownSec.IsNull() {
+			opt.Cooldown = time.Duration(cb.CooldownSec.ValueInt64()) * time.Second
+		}
+		clientOpt.CircuitBreaker = &opt
+	}
+	if !c.ExpectContinueTimeout.IsNull() {
+		clientOpt.ExpectContinue = &client.ExpectContinueOption{
+			Timeout:      time.Duration(c.ExpectContinueTimeout.ValueInt64()) * time.Second,
+			MinBodyBytes: c.ExpectContinueMinBytes.ValueInt64(),
+		}
+	}
 
 	if !c.Retry.IsNull() {
 		retryOpt, diags := populateRetry(ctx, c.Retry)
@@ -876,6 +1540,24 @@ func (c clientData) ToClientBuildOption(ctx context.Context) (*client.BuildOptio
 	return &clientOpt, nil
 }
 
+// pkcs12Certificate decodes a PKCS#12 bundle into a tls.Certificate. The CA chain, if any, is appended
+// to the certificate chain so that it is sent to the server during the handshake.
+func pkcs12Certificate(b []byte, password string) (*tls.Certificate, error) {
+	key, leaf, caCerts, err := pkcs12.DecodeChain(b, password)
+	if err != nil {
+		return nil, err
+	}
+	cert := tls.Certificate{
+		Certificate: [][]byte{leaf.Raw},
+		PrivateKey:  key,
+		Leaf:        leaf,
+	}
+	for _, ca := range caCerts {
+		cert.Certificate = append(cert.Certificate, ca.Raw)
+	}
+	return &cert, nil
+}
+
 func populateRetry(ctx context.Context, retryObj basetypes.ObjectValue) (*client.RetryOption, diag.Diagnostics) {
 	var retry retryData
 	if diags := retryObj.As(ctx, &retry, basetypes.ObjectAsOptions{}); diags.HasError() {
@@ -907,10 +1589,11 @@ func populateRetry(ctx context.Context, retryObj basetypes.ObjectValue) (*client
 	}
 
 	return &client.RetryOption{
-		StatusCodes: statusCodes,
-		Count:       count,
-		WaitTime:    waitTime,
-		MaxWaitTime: maxWaitTime,
+		StatusCodes:            statusCodes,
+		Count:                  count,
+		WaitTime:               waitTime,
+		MaxWaitTime:            maxWaitTime,
+		NoConnectionErrorRetry: !retry.RetryOnConnectionError.IsNull() && !retry.RetryOnConnectionError.ValueBool(),
 	}, nil
 }
 
@@ -919,6 +1602,30 @@ func populateSecurity(ctx context.Context, secRaw basetypes.ObjectValue) (client
 	if diags := secRaw.As(ctx, &sec, basetypes.ObjectAsOptions{}); diags.HasError() {
 		return nil, diags
 	}
+
+	// secret returns the value of a secret field, which is read from Vault if it is a Vault reference.
+	secret := func(v types.String) (string, diag.Diagnostics) { return v.ValueString(), nil }
+	if !sec.Vault.IsNull() {
+		var vault vaultData
+		if diags := sec.Vault.As(ctx, &vault, basetypes.ObjectAsOptions{}); diags.HasError() {
+			return nil, diags
+		}
+		reader, err := newVaultReader(vault.Address.ValueString())
+		if err != nil {
+			var diags diag.Diagnostics
+			diags.AddError("Failed to build the Vault client", err.Error())
+			return nil, diags
+		}
+		secret = func(v types.String) (string, diag.Diagnostics) {
+			var diags diag.Diagnostics
+			out, err := reader.Resolve(ctx, v.ValueString())
+			if err != nil {
+				diags.AddError("Failed to read secret from Vault", err.Error())
+			}
+			return out, diags
+		}
+	}
+
 	switch {
 	case !sec.HTTP.IsNull():
 		var http httpData
@@ -931,9 +1638,13 @@ func populateSecurity(ctx context.Context, secRaw basetypes.ObjectValue) (client
 			if diags := http.Basic.As(ctx, &basic, basetypes.ObjectAsOptions{}); diags.HasError() {
 				return nil, diags
 			}
+			password, diags := secret(basic.Password)
+			if diags.HasError() {
+				return nil, diags
+			}
 			opt := client.HTTPBasicOption{
 				Username: basic.Username.ValueString(),
-				Password: basic.Password.ValueString(),
+				Password: password,
 			}
 			return opt, nil
 		case !http.Token.IsNull():
@@ -941,8 +1652,12 @@ func populateSecurity(ctx context.Context, secRaw basetypes.ObjectValue) (client
 			if diags := http.Token.As(ctx, &token, basetypes.ObjectAsOptions{}); diags.HasError() {
 				return nil, diags
 			}
+			tk, diags := secret(token.Token)
+			if diags.HasError() {
+				return nil, diags
+			}
 			opt := client.HTTPTokenOption{
-				Token:  token.Token.ValueString(),
+				Token:  tk,
 				Scheme: token.Scheme.ValueString(),
 			}
 			return opt, nil
@@ -958,10 +1673,14 @@ func populateSecurity(ctx context.Context, secRaw basetypes.ObjectValue) (client
 			if diags := apikeyObj.As(ctx, &apikey, basetypes.ObjectAsOptions{}); diags.HasError() {
 				return nil, diags
 			}
+			value, diags := secret(apikey.Value)
+			if diags.HasError() {
+				return nil, diags
+			}
 			opt = append(opt, client.APIKeyAuthOpt{
 				Name:  apikey.Name.ValueString(),
 				In:    client.APIKeyAuthIn(apikey.In.ValueString()),
-				Value: apikey.Value.ValueString(),
+				Value: value,
 			})
 		}
 		return opt, nil
@@ -976,12 +1695,20 @@ func populateSecurity(ctx context.Context, secRaw basetypes.ObjectValue) (client
 			if diags := oauth2.Password.As(ctx, &password, basetypes.ObjectAsOptions{}); diags.HasError() {
 				return nil, diags
 			}
+			pwd, diags := secret(password.Password)
+			if diags.HasError() {
+				return nil, diags
+			}
+			clientSecret, diags := secret(password.ClientSecret)
+			if diags.HasError() {
+				return nil, diags
+			}
 			opt := client.OAuth2PasswordOption{
 				TokenURL:     password.TokenUrl.ValueString(),
 				Username:     password.Username.ValueString(),
-				Password:     password.Password.ValueString(),
+				Password:     pwd,
 				ClientId:     password.ClientID.ValueString(),
-				ClientSecret: password.ClientSecret.ValueString(),
+				ClientSecret: clientSecret,
 				AuthStyle:    client.OAuth2AuthStyle(password.In.ValueString()),
 			}
 			if !password.Scopes.IsNull() {
@@ -1001,10 +1728,14 @@ func populateSecurity(ctx context.Context, secRaw basetypes.ObjectValue) (client
 			if diags := oauth2.ClientCredentials.As(ctx, &cc, basetypes.ObjectAsOptions{}); diags.HasError() {
 				return nil, diags
 			}
+			clientSecret, diags := secret(cc.ClientSecret)
+			if diags.HasError() {
+				return nil, diags
+			}
 			opt := client.OAuth2ClientCredentialOption{
 				TokenURL:     cc.TokenUrl.ValueString(),
 				ClientId:     cc.ClientID.ValueString(),
-				ClientSecret: cc.ClientSecret.ValueString(),
+				ClientSecret: clientSecret,
 				AuthStyle:    client.OAuth2AuthStyle(cc.In.ValueString()),
 			}
 			if !cc.Scopes.IsNull() {
@@ -1041,11 +1772,19 @@ func populateSecurity(ctx context.Context, secRaw basetypes.ObjectValue) (client
 				return nil, diags
 			}
 
+			rt, diags := secret(refreshToken.RefreshToken)
+			if diags.HasError() {
+				return nil, diags
+			}
+			clientSecret, diags := secret(refreshToken.ClientSecret)
+			if diags.HasError() {
+				return nil, diags
+			}
 			opt := client.OAuth2RefreshTokenOption{
 				TokenURL:     refreshToken.TokenUrl.ValueString(),
-				RefreshToken: refreshToken.RefreshToken.ValueString(),
+				RefreshToken: rt,
 				ClientId:     refreshToken.ClientID.ValueString(),
-				ClientSecret: refreshToken.ClientSecret.ValueString(),
+				ClientSecret: clientSecret,
 				AuthStyle:    client.OAuth2AuthStyle(refreshToken.In.ValueString()),
 				TokenType:    refreshToken.TokenType.ValueString(),
 			}
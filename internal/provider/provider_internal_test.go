@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+	"software.sslmate.com/src/go-pkcs12"
+)
+
+// The provider level default polling options are decoded as the resource's pollData, hence they shall have the same type.
+func TestProviderPollAttributeType(t *testing.T) {
+	require.Equal(t,
+		pollAttribute("").GetType().(types.ObjectType).AttrTypes,
+		providerPollAttribute("").GetType().(types.ObjectType).AttrTypes,
+	)
+}
+
+// The provider level default prechecks are decoded as the resource's precheckData, hence they shall have the same type.
+func TestProviderPrecheckAttributeType(t *testing.T) {
+	require.Equal(t,
+		precheckAttribute("", true, "", false).GetType().(types.ListType).ElemType,
+		providerPrecheckAttribute("", true, "").GetType().(types.ListType).ElemType,
+	)
+}
+
+func TestPKCS12Certificate(t *testing.T) {
+	newCert := func(cn string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey) (*x509.Certificate, *ecdsa.PrivateKey) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		tmpl := &x509.Certificate{
+			SerialNumber:          big.NewInt(1),
+			Subject:               pkix.Name{CommonName: cn},
+			NotBefore:             time.Now(),
+			NotAfter:              time.Now().Add(time.Hour),
+			IsCA:                  parent == nil,
+			BasicConstraintsValid: true,
+		}
+		if parent == nil {
+			parent, parentKey = tmpl, key
+		}
+		b, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+		require.NoError(t, err)
+		cert, err := x509.ParseCertificate(b)
+		require.NoError(t, err)
+		return cert, key
+	}
+	ca, caKey := newCert("ca", nil, nil)
+	leaf, leafKey := newCert("client", ca, caKey)
+
+	pfx, err := pkcs12.Modern.Encode(leafKey, leaf, []*x509.Certificate{ca}, "secret")
+	require.NoError(t, err)
+
+	cert, err := pkcs12Certificate(pfx, "secret")
+	require.NoError(t, err)
+	require.Equal(t, [][]byte{leaf.Raw, ca.Raw}, cert.Certificate)
+	require.Equal(t, leaf, cert.Leaf)
+	require.True(t, leafKey.Equal(cert.PrivateKey))
+
+	_, err = pkcs12Certificate(pfx, "wrong")
+	require.Error(t, err)
+}
+
+func TestClientDataToClientBuildOption(t *testing.T) {
+	maxIdleConns, maxConnsPerHost, idleConnTimeout := 0, 10, 30*time.Second
+
+	cases := []struct {
+		name                    string
+		input                   clientData
+		expectForceHTTP1        bool
+		expectDisableKeepAlives bool
+		expectMaxIdleConns      *int
+		expectMaxConnsPerHost   *int
+		expectIdleConnTimeout   *time.Duration
+	}{
+		{
+			name:  "defaults",
+			input: clientData{},
+		},
+		{
+			name: "force http1 and disable keep alives",
+			input: clientData{
+				ForceHTTP1:        types.BoolValue(true),
+				DisableKeepAlives: types.BoolValue(true),
+			},
+			expectForceHTTP1:        true,
+			expectDisableKeepAlives: true,
+		},
+		{
+			name: "connection pool",
+			input: clientData{
+				MaxIdleConns:       types.Int64Value(0),
+				MaxConnsPerHost:    types.Int64Value(10),
+				IdleConnTimeoutSec: types.Int64Value(30),
+			},
+			expectMaxIdleConns:    &maxIdleConns,
+			expectMaxConnsPerHost: &maxConnsPerHost,
+			expectIdleConnTimeout: &idleConnTimeout,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			opt, diags := tt.input.ToClientBuildOption(context.Background())
+			require.False(t, diags.HasError(), diags)
+			require.Equal(t, tt.expectForceHTTP1, opt.ForceHTTP1)
+			require.Equal(t, tt.expectDisableKeepAlives, opt.DisableKeepAlives)
+			require.Equal(t, tt.expectMaxIdleConns, opt.MaxIdleConns)
+			require.Equal(t, tt.expectMaxConnsPerHost, opt.MaxConnsPerHost)
+			require.Equal(t, tt.expectIdleConnTimeout, opt.IdleConnTimeout)
+		})
+	}
+}
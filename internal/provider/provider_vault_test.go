@@ -0,0 +1,68 @@
+package provider_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+func TestProvider_VaultAPIKey(t *testing.T) {
+	vault := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" || r.URL.Path != "/v1/secret/data/app" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data": {"data": {"apikey": "s3cret"}}}`))
+	}))
+	defer vault.Close()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Api-Key") != "s3cret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name": "foo"}`))
+	}))
+	defer api.Close()
+
+	t.Setenv("VAULT_TOKEN", "token")
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+  security = {
+    apikey = [
+      {
+        name  = "X-Api-Key"
+        in    = "header"
+        value = "vault:secret/data/app#apikey"
+      },
+    ]
+    vault = {
+      address = %q
+    }
+  }
+}
+
+resource "restful_resource" "test" {
+  path          = "/items/1"
+  create_method = "PUT"
+  body = {
+    name = "foo"
+  }
+}
+`, api.URL, vault.URL),
+				Check: resource.TestCheckResourceAttr("restful_resource.test", "output.name", "foo"),
+			},
+		},
+	})
+}
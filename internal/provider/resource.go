@@ -1,16 +1,26 @@
 package provider
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
+	"slices"
 	"strings"
+	"time"
 
 	jsonpatch "github.com/evanphx/json-patch"
+	"github.com/go-resty/resty/v2"
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/boolvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	tfpath "github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -23,6 +33,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/magodo/terraform-provider-restful/internal/client"
+	"github.com/magodo/terraform-provider-restful/internal/defaults"
 	"github.com/magodo/terraform-provider-restful/internal/dynamic"
 	"github.com/magodo/terraform-provider-restful/internal/exparam"
 	myvalidator "github.com/magodo/terraform-provider-restful/internal/validator"
@@ -37,14 +48,23 @@ type Resource struct {
 var _ resource.Resource = &Resource{}
 var _ resource.ResourceWithUpgradeState = &Resource{}
 
+// pkForceNewOutput is the private state key of the `force_new_output_attrs` that have changed during refresh.
+const pkForceNewOutput = "force_new_output"
+
+// pkLastModified is the private state key of the `Last-Modified` of the last read (or write) response.
+const pkLastModified = "last_modified"
+
 type resourceData struct {
-	ID types.String `tfsdk:"id"`
+	ID    types.String `tfsdk:"id"`
+	IdURL types.String `tfsdk:"id_url"`
 
 	Path types.String `tfsdk:"path"`
 
 	CreateSelector       types.String `tfsdk:"create_selector"`
 	ReadSelector         types.String `tfsdk:"read_selector"`
+	ReadSelectorSingle   types.Bool   `tfsdk:"read_selector_expect_single"`
 	ReadResponseTemplate types.String `tfsdk:"read_response_template"`
+	ReadBodyMap          types.Map    `tfsdk:"read_body_map"`
 
 	ReadPath   types.String `tfsdk:"read_path"`
 	UpdatePath types.String `tfsdk:"update_path"`
@@ -58,8 +78,12 @@ type resourceData struct {
 	PrecheckUpdate types.List `tfsdk:"precheck_update"`
 	PrecheckDelete types.List `tfsdk:"precheck_delete"`
 
-	Body       types.Dynamic `tfsdk:"body"`
-	DeleteBody types.Dynamic `tfsdk:"delete_body"`
+	Body                types.Dynamic `tfsdk:"body"`
+	BodyFile            types.String  `tfsdk:"body_file"`
+	BodyFileContentType types.String  `tfsdk:"body_file_content_type"`
+	BodySchema          types.String  `tfsdk:"body_schema"`
+	BodySchemaFile      types.String  `tfsdk:"body_schema_file"`
+	DeleteBody          types.Dynamic `tfsdk:"delete_body"`
 
 	UpdateBodyPatches types.List `tfsdk:"update_body_patches"`
 
@@ -67,8 +91,24 @@ type resourceData struct {
 	PollUpdate types.Object `tfsdk:"poll_update"`
 	PollDelete types.Object `tfsdk:"poll_delete"`
 
+	AutoPollOn202 types.Bool `tfsdk:"auto_poll_on_202"`
+
+	DeleteOnCreateFailure types.Bool `tfsdk:"delete_on_create_failure"`
+
+	WaitForCreate types.Object `tfsdk:"wait_for_create"`
+	WaitForUpdate types.Object `tfsdk:"wait_for_update"`
+	WaitForDelete types.Object `tfsdk:"wait_for_delete"`
+
+	ReadAfterWriteRetry types.Object `tfsdk:"read_after_write_retry"`
+	IdempotencyKey      types.Object `tfsdk:"idempotency_key"`
+	LogLevel            types.String `tfsdk:"log_level"`
+
 	WriteOnlyAttributes types.List `tfsdk:"write_only_attrs"`
+	Normalize           types.Map  `tfsdk:"normalize"`
+	SendAttrs           types.Set  `tfsdk:"send_attrs"`
+	OmitNullBodyAttrs   types.Bool `tfsdk:"omit_null_body_attrs"`
 	MergePatchDisabled  types.Bool `tfsdk:"merge_patch_disabled"`
+	UseLastModified     types.Bool `tfsdk:"use_last_modified"`
 
 	Query       types.Map `tfsdk:"query"`
 	CreateQuery types.Map `tfsdk:"create_query"`
@@ -82,11 +122,47 @@ type resourceData struct {
 	UpdateHeader types.Map `tfsdk:"update_header"`
 	DeleteHeader types.Map `tfsdk:"delete_header"`
 
-	CheckExistance types.Bool `tfsdk:"check_existance"`
-	ForceNewAttrs  types.Set  `tfsdk:"force_new_attrs"`
-	OutputAttrs    types.Set  `tfsdk:"output_attrs"`
+	RemoveQuery   types.Set `tfsdk:"remove_query"`
+	RemoveHeaders types.Set `tfsdk:"remove_headers"`
+
+	CheckExistance           types.Bool `tfsdk:"check_existance"`
+	AdoptExisting            types.Bool `tfsdk:"adopt_existing"`
+	EnsureExistsBeforeUpdate types.Bool `tfsdk:"ensure_exists_before_update"`
+	RefreshAfterWrite        types.Bool `tfsdk:"refresh_after_write"`
+
+	DryRun              types.Object `tfsdk:"dry_run"`
+	ForceNewAttrs       types.Set    `tfsdk:"force_new_attrs"`
+	ForceNewOutputAttrs types.Set    `tfsdk:"force_new_output_attrs"`
+	OutputAttrs         types.Set    `tfsdk:"output_attrs"`
+	OutputTypeHints     types.Map    `tfsdk:"output_type_hints"`
+	OutputAliases       types.Map    `tfsdk:"output_aliases"`
+	OutputSort          types.List   `tfsdk:"output_sort"`
+
+	Output       types.Dynamic `tfsdk:"output"`
+	OutputRaw    types.String  `tfsdk:"output_raw"`
+	OutputSHA256 types.String  `tfsdk:"output_sha256"`
+
+	LastRequestDurationMs types.Int64 `tfsdk:"last_request_duration_ms"`
+}
+
+// refreshAfterWrite tells whether to read the resource back after create and update, which defaults to true.
+func (d resourceData) refreshAfterWrite() bool {
+	return d.RefreshAfterWrite.IsNull() || d.RefreshAfterWrite.ValueBool()
+}
+
+type dryRunData struct {
+	Query  types.Map `tfsdk:"query"`
+	Header types.Map `tfsdk:"header"`
+}
+
+type readAfterWriteRetryData struct {
+	Attempts types.Int64 `tfsdk:"attempts"`
+	Interval types.Int64 `tfsdk:"interval_sec"`
+}
 
-	Output types.Dynamic `tfsdk:"output"`
+type idempotencyKeyData struct {
+	Header types.String `tfsdk:"header"`
+	Value  types.String `tfsdk:"value"`
 }
 
 type bodyPatchData struct {
@@ -100,11 +176,26 @@ type pollData struct {
 	UrlLocator    types.String `tfsdk:"url_locator"`
 	Header        types.Map    `tfsdk:"header"`
 	DefaultDelay  types.Int64  `tfsdk:"default_delay_sec"`
+
+	RetryStatusCodes types.List `tfsdk:"retry_status_codes"`
+	Conditions       types.List `tfsdk:"conditions"`
+	AbortIf          types.List `tfsdk:"abort_if"`
+}
+
+type pollConditionData struct {
+	StatusLocator types.String `tfsdk:"status_locator"`
+	Status        types.Object `tfsdk:"status"`
+}
+
+type pollAbortIfData struct {
+	Locator types.String `tfsdk:"locator"`
+	Values  []string     `tfsdk:"values"`
 }
 
 type precheckData struct {
-	Api   types.Object `tfsdk:"api"`
-	Mutex types.String `tfsdk:"mutex"`
+	Api    types.Object `tfsdk:"api"`
+	Mutex  types.String `tfsdk:"mutex"`
+	Action types.Object `tfsdk:"action"`
 }
 
 type precheckDataApi struct {
@@ -114,6 +205,18 @@ type precheckDataApi struct {
 	Query         types.Map    `tfsdk:"query"`
 	Header        types.Map    `tfsdk:"header"`
 	DefaultDelay  types.Int64  `tfsdk:"default_delay_sec"`
+	CacheTTL      types.Int64  `tfsdk:"cache_ttl_sec"`
+}
+
+type precheckDataAction struct {
+	Method        types.String `tfsdk:"method"`
+	Path          types.String `tfsdk:"path"`
+	Body          types.String `tfsdk:"body"`
+	Query         types.Map    `tfsdk:"query"`
+	Header        types.Map    `tfsdk:"header"`
+	ReleaseMethod types.String `tfsdk:"release_method"`
+	ReleasePath   types.String `tfsdk:"release_path"`
+	ReleaseBody   types.String `tfsdk:"release_body"`
 }
 
 type statusDataGo struct {
@@ -125,10 +228,12 @@ func (r *Resource) Metadata(ctx context.Context, req resource.MetadataRequest, r
 	resp.TypeName = req.ProviderTypeName + "_resource"
 }
 
-const paramFuncDescription = "Supported functions include: `escape` (URL path escape, by default applied), `unescape` (URL path unescape), `base` (filepath base), `url_path` (path segment of a URL), `trim_path` (trim `path`)."
+const paramFuncDescription = "Supported functions include: `escape` (URL path escape, by default applied), `unescape` (URL path unescape), `base` (filepath base), `url_path` (path segment of a URL), `trim_path` (trim `path`), `lower` (to lower case), `upper` (to upper case), `base64encode` (standard base64 encode), `base64decode` (standard base64 decode), `json` (descend into the JSON-encoded string values along the body param path, e.g. `$json(body.a.b)` reads `b` from the JSON string at `a`)."
 
 const pathDescription = "This can be a string literal, or combined by following params: path param: `$(path)` expanded to `path`, body param: `$(body.x.y.z)` expands to the `x.y.z` property of the API body. Especially for the body param, it can add a chain of functions (applied from left to right), in the form of `$f1.f2(body)`. " + paramFuncDescription
 
+const headerParamDescription = " Additionally, the header param: `$(header.Name)` expands to the value of the `Name` header of the response, e.g. `$url_path(header.Location)`."
+
 func operationOverridableAttrDescription(attr string, opkind string) string {
 	return fmt.Sprintf("The %[1]s parameters that are applied to each %[2]s request. This overrides the `%[1]s` set in the resource block.", attr, opkind)
 }
@@ -145,8 +250,8 @@ func precheckAttribute(s string, pathIsRequired bool, suffixDesc string, statusL
 	}
 
 	return schema.ListNestedAttribute{
-		Description:         fmt.Sprintf("An array of prechecks that need to pass prior to the %q operation. Exactly one of `mutex` or `api` should be specified.", s),
-		MarkdownDescription: fmt.Sprintf("An array of prechecks that need to pass prior to the %q operation. Exactly one of `mutex` or `api` should be specified.", s),
+		Description:         fmt.Sprintf("An array of prechecks that need to pass prior to the %q operation. Exactly one of `mutex`, `api` or `action` should be specified.", s),
+		MarkdownDescription: fmt.Sprintf("An array of prechecks that need to pass prior to the %q operation. Exactly one of `mutex`, `api` or `action` should be specified.", s),
 		Optional:            true,
 		NestedObject: schema.NestedAttributeObject{
 			Attributes: map[string]schema.Attribute{
@@ -157,6 +262,7 @@ func precheckAttribute(s string, pathIsRequired bool, suffixDesc string, statusL
 					Validators: []validator.String{
 						stringvalidator.ExactlyOneOf(
 							path.MatchRelative().AtParent().AtName("api"),
+							path.MatchRelative().AtParent().AtName("action"),
 						),
 					},
 				},
@@ -181,13 +287,13 @@ func precheckAttribute(s string, pathIsRequired bool, suffixDesc string, statusL
 							Required:            true,
 							Attributes: map[string]schema.Attribute{
 								"success": schema.StringAttribute{
-									Description:         "The expected status sentinel for suceess status.",
-									MarkdownDescription: "The expected status sentinel for suceess status.",
+									Description:         "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+									MarkdownDescription: "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
 									Required:            true,
 								},
 								"pending": schema.ListAttribute{
-									Description:         "The expected status sentinels for pending status.",
-									MarkdownDescription: "The expected status sentinels for pending status.",
+									Description:         "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+									MarkdownDescription: "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
 									Optional:            true,
 									ElementType:         types.StringType,
 								},
@@ -218,15 +324,100 @@ func precheckAttribute(s string, pathIsRequired bool, suffixDesc string, statusL
 							Computed:            true,
 							Default:             int64default.StaticInt64(10),
 						},
+						"cache_ttl_sec": schema.Int64Attribute{
+							Description:         "Once the check passes, the other checks with the same effective request (i.e. the URL, header, status locator and status) are regarded as passed without polling for this amount of time in second, within the same provider process (e.g. a single apply). This is useful when many resources share the same readiness check. Defaults to not caching.",
+							MarkdownDescription: "Once the check passes, the other checks with the same effective request (i.e. the URL, header, status locator and status) are regarded as passed without polling for this amount of time in second, within the same provider process (e.g. a single apply). This is useful when many resources share the same readiness check. Defaults to not caching.",
+							Optional:            true,
+							Validators: []validator.Int64{
+								int64validator.AtLeast(1),
+							},
+						},
 					},
 					Validators: []validator.Object{
 						objectvalidator.ExactlyOneOf(
 							path.MatchRelative().AtParent().AtName("mutex"),
+							path.MatchRelative().AtParent().AtName("action"),
 						),
+						codeStatusValidator{},
 					},
 				},
+				"action": precheckActionAttribute(),
+			},
+		},
+	}
+}
+
+func precheckActionAttribute() schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description:         "Issues the specified API call (e.g. acquiring a lease) and expects a successful response, optionally with a release call issued after the operation",
+		MarkdownDescription: "Issues the specified API call (e.g. acquiring a lease) and expects a successful response, optionally with a release call issued after the operation",
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"method": schema.StringAttribute{
+				Description:         "The HTTP method of the action. Possible values are `POST`, `PUT`, `PATCH`, `DELETE`.",
+				MarkdownDescription: "The HTTP method of the action. Possible values are `POST`, `PUT`, `PATCH`, `DELETE`.",
+				Required:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("POST", "PUT", "PATCH", "DELETE"),
+				},
+			},
+			"path": schema.StringAttribute{
+				Description:         "The path of the action, relative to the `base_url` of the provider.",
+				MarkdownDescription: "The path of the action, relative to the `base_url` of the provider.",
+				Required:            true,
+			},
+			"body": schema.StringAttribute{
+				Description:         "The JSON encoded request body of the action.",
+				MarkdownDescription: "The JSON encoded request body of the action.",
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsJSON(),
+				},
+			},
+			"query": schema.MapAttribute{
+				Description:         "The query parameters. This overrides the `query` set in the resource block.",
+				MarkdownDescription: "The query parameters. This overrides the `query` set in the resource block.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"header": schema.MapAttribute{
+				Description:         "The header parameters. This overrides the `header` set in the resource block.",
+				MarkdownDescription: "The header parameters. This overrides the `header` set in the resource block.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"release_method": schema.StringAttribute{
+				Description:         "The HTTP method of the release call, which is issued after the operation, regardless of its result. Possible values are `POST`, `PUT`, `PATCH`, `DELETE`.",
+				MarkdownDescription: "The HTTP method of the release call, which is issued after the operation, regardless of its result. Possible values are `POST`, `PUT`, `PATCH`, `DELETE`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("POST", "PUT", "PATCH", "DELETE"),
+				},
+			},
+			"release_path": schema.StringAttribute{
+				Description:         "The path of the release call. Defaults to the `path` of the action. " + pathDescription + " The body param references the response body of the action.",
+				MarkdownDescription: "The path of the release call. Defaults to the `path` of the action. " + pathDescription + " The body param references the response body of the action.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("release_method")),
+				},
+			},
+			"release_body": schema.StringAttribute{
+				Description:         "The JSON encoded request body of the release call.",
+				MarkdownDescription: "The JSON encoded request body of the release call.",
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsJSON(),
+					stringvalidator.AlsoRequires(path.MatchRelative().AtParent().AtName("release_method")),
+				},
 			},
 		},
+		Validators: []validator.Object{
+			objectvalidator.ExactlyOneOf(
+				path.MatchRelative().AtParent().AtName("mutex"),
+				path.MatchRelative().AtParent().AtName("api"),
+			),
+		},
 	}
 }
 
@@ -252,21 +443,21 @@ func pollAttribute(s string) schema.SingleNestedAttribute {
 				Required:            true,
 				Attributes: map[string]schema.Attribute{
 					"success": schema.StringAttribute{
-						Description:         "The expected status sentinel for suceess status.",
-						MarkdownDescription: "The expected status sentinel for suceess status.",
+						Description:         "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+						MarkdownDescription: "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
 						Required:            true,
 					},
 					"pending": schema.ListAttribute{
-						Description:         "The expected status sentinels for pending status.",
-						MarkdownDescription: "The expected status sentinels for pending status.",
+						Description:         "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+						MarkdownDescription: "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
 						Optional:            true,
 						ElementType:         types.StringType,
 					},
 				},
 			},
 			"url_locator": schema.StringAttribute{
-				Description:         "Specifies how to discover the polling url. The format can be one of `header.path` (use the property at `path` in response header), `body.path` (use the property at `path` in response body) or `exact.value` (use the exact `value`). When absent, the current operation's URL is used for polling, execpt `Create` where it fallbacks to use the resource id as the polling URL.",
-				MarkdownDescription: "Specifies how to discover the polling url. The format can be one of `header.path` (use the property at `path` in response header), `body.path` (use the property at `path` in response body) or `exact.value` (use the exact `value`). When absent, the current operation's URL is used for polling, execpt `Create` where it fallbacks to use the resource id as the polling URL.",
+				Description:         "Specifies how to discover the polling url. The format can be one of `header.path` (use the property at `path` in response header), `body.path` (use the property at `path` in response body), `link.rel` (use the target URL of the relation type `rel` in the `Link` response header) or `exact.value` (use the exact `value`). When absent, the current operation's URL is used for polling, execpt `Create` where it fallbacks to use the resource id as the polling URL.",
+				MarkdownDescription: "Specifies how to discover the polling url. The format can be one of `header.path` (use the property at `path` in response header), `body.path` (use the property at `path` in response body), `link.rel` (use the target URL of the relation type `rel` in the `Link` response header) or `exact.value` (use the exact `value`). When absent, the current operation's URL is used for polling, execpt `Create` where it fallbacks to use the resource id as the polling URL.",
 				Optional:            true,
 				Validators: []validator.String{
 					myvalidator.StringIsParsable("url_locator", func(s string) error {
@@ -287,6 +478,80 @@ func pollAttribute(s string) schema.SingleNestedAttribute {
 				Computed:            true,
 				Default:             int64default.StaticInt64(10),
 			},
+			"retry_status_codes": schema.ListAttribute{
+				Description:         "The status codes of the polling response that are regarded as transient errors, which keep the polling going instead of failing it. The polling still fails after 10 transient responses in a row.",
+				MarkdownDescription: "The status codes of the polling response that are regarded as transient errors, which keep the polling going instead of failing it. The polling still fails after 10 transient responses in a row.",
+				Optional:            true,
+				ElementType:         types.Int64Type,
+			},
+			"conditions": schema.ListNestedAttribute{
+				Description:         "The extra status conditions, which all need to be satisfied together with the `status_locator` and `status` for the polling to be done. This is useful when the completion is expressed across multiple properties. The polling keeps going as long as each of the conditions is either succeeded or pending.",
+				MarkdownDescription: "The extra status conditions, which all need to be satisfied together with the `status_locator` and `status` for the polling to be done. This is useful when the completion is expressed across multiple properties. The polling keeps going as long as each of the conditions is either succeeded or pending.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"status_locator": schema.StringAttribute{
+							Description:         "Specifies how to discover the status property of this condition, in the same format as the `status_locator`.",
+							MarkdownDescription: "Specifies how to discover the status property of this condition, in the same format as the `status_locator`.",
+							Required:            true,
+							Validators: []validator.String{
+								myvalidator.StringIsParsable("status_locator", func(s string) error {
+									return validateLocator(s)
+								}),
+							},
+						},
+						"status": schema.SingleNestedAttribute{
+							Description:         "The expected status sentinels for each polling state of this condition.",
+							MarkdownDescription: "The expected status sentinels for each polling state of this condition.",
+							Required:            true,
+							Attributes: map[string]schema.Attribute{
+								"success": schema.StringAttribute{
+									Description:         "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+									MarkdownDescription: "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+									Required:            true,
+								},
+								"pending": schema.ListAttribute{
+									Description:         "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+									MarkdownDescription: "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+									Optional:            true,
+									ElementType:         types.StringType,
+								},
+							},
+						},
+					},
+					Validators: []validator.Object{
+						codeStatusValidator{},
+					},
+				},
+			},
+			"abort_if": schema.ListNestedAttribute{
+				Description:         "The conditions that abort the polling with an error once any of them is matched, regardless of the `status`. This is useful when the operation has already failed (e.g. indicated by a nested property) while the status is still pending.",
+				MarkdownDescription: "The conditions that abort the polling with an error once any of them is matched, regardless of the `status`. This is useful when the operation has already failed (e.g. indicated by a nested property) while the status is still pending.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"locator": schema.StringAttribute{
+							Description:         "Specifies how to discover the value to check, in the same format as the `status_locator`.",
+							MarkdownDescription: "Specifies how to discover the value to check, in the same format as the `status_locator`.",
+							Required:            true,
+							Validators: []validator.String{
+								myvalidator.StringIsParsable("locator", func(s string) error {
+									return validateLocator(s)
+								}),
+							},
+						},
+						"values": schema.ListAttribute{
+							Description:         "The values that abort the polling, which are compared case insensitively. For the `code` locator, these can also be status code patterns, e.g. `5xx`.",
+							MarkdownDescription: "The values that abort the polling, which are compared case insensitively. For the `code` locator, these can also be status code patterns, e.g. `5xx`.",
+							Required:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+		Validators: []validator.Object{
+			codeStatusValidator{},
 		},
 	}
 }
@@ -305,6 +570,14 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"id_url": schema.StringAttribute{
+				Description:         "The absolute URL of the Resource, which is the `id` joined with the `base_url` of the provider.",
+				MarkdownDescription: "The absolute URL of the Resource, which is the `id` joined with the `base_url` of the provider.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 			"path": schema.StringAttribute{
 				Description:         "The path used to create the resource, relative to the `base_url` of the provider.",
 				MarkdownDescription: "The path used to create the resource, relative to the `base_url` of the provider.",
@@ -315,19 +588,27 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 			},
 
 			"create_selector": schema.StringAttribute{
-				Description:         "A selector in gjson query syntax, that is used when create returns a collection of resources, to select exactly one member resource of from it. By default, the whole response body is used as the body.",
-				MarkdownDescription: "A selector in [gjson query syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md#queries) query syntax, that is used when create returns a collection of resources, to select exactly one member resource of from it. By default, the whole response body is used as the body.",
+				Description:         "A selector in gjson query syntax, that is used when create returns a collection of resources, to select exactly one member resource of from it, or when create returns the resource inside an envelope (e.g. `data`), to unwrap it. By default, the whole response body is used as the body. The expression can contain parameters in the form of `$(body.x.y.z)`, which expands to the `x.y.z` property of the request body (i.e. the `body`). Specially, the param can add a chain of functions (applied from left to right), in the form of `$f1.f2(body)`. " + paramFuncDescription,
+				MarkdownDescription: "A selector in [gjson query syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md#queries) query syntax, that is used when create returns a collection of resources, to select exactly one member resource of from it, or when create returns the resource inside an envelope (e.g. `data`), to unwrap it. By default, the whole response body is used as the body. The expression can contain parameters in the form of `$(body.x.y.z)`, which expands to the `x.y.z` property of the request body (i.e. the `body`). Specially, the param can add a chain of functions (applied from left to right), in the form of `$f1.f2(body)`. " + paramFuncDescription,
 				Optional:            true,
 			},
 			"read_selector": schema.StringAttribute{
-				Description:         "A selector expression in gjson query syntax, that is used when read returns a collection of resources, to select exactly one member resource of from it. By default, the whole response body is used as the body. The expression can contain parameters in the form of `$(body.x.y.z)`, which expands to the `x.y.z` property of the `output` of the resource state. Specially, the param can add a chain of functions (applied from left to right), in the form of `$f1.f2(body)`. " + paramFuncDescription,
-				MarkdownDescription: "A selector expression in [gjson query syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md#queries), that is used when read returns a collection of resources, to select exactly one member resource of from it. By default, the whole response body is used as the body. The expression can contain parameters in the form of `$(body.x.y.z)`, which expands to the `x.y.z` property of the `output` of the resource state. Specially, the param can add a chain of functions (applied from left to right), in the form of `$f1.f2(body)`. " + paramFuncDescription,
+				Description:         "A selector expression in gjson query syntax, that is used when read returns a collection of resources, to select exactly one member resource of from it, or when read returns the resource inside an envelope, to unwrap it. This is typically the same as the `create_selector` for an enveloped API. By default, the whole response body is used as the body. The expression can contain parameters in the form of `$(body.x.y.z)`, which expands to the `x.y.z` property of the `output` of the resource state. Specially, the param can add a chain of functions (applied from left to right), in the form of `$f1.f2(body)`. " + paramFuncDescription,
+				MarkdownDescription: "A selector expression in [gjson query syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md#queries), that is used when read returns a collection of resources, to select exactly one member resource of from it, or when read returns the resource inside an envelope, to unwrap it. This is typically the same as the `create_selector` for an enveloped API. By default, the whole response body is used as the body. The expression can contain parameters in the form of `$(body.x.y.z)`, which expands to the `x.y.z` property of the `output` of the resource state. Specially, the param can add a chain of functions (applied from left to right), in the form of `$f1.f2(body)`. " + paramFuncDescription,
+				Optional:            true,
+			},
+			"read_selector_expect_single": schema.BoolAttribute{
+				Description:         "Whether to raise an error when the `read_selector` matches more than one member resource? By default, the first match is used silently. Defaults to `false`.",
+				MarkdownDescription: "Whether to raise an error when the `read_selector` matches more than one member resource? By default, the first match is used silently. Defaults to `false`.",
 				Optional:            true,
+				Validators: []validator.Bool{
+					boolvalidator.AlsoRequires(path.MatchRoot("read_selector")),
+				},
 			},
 
 			"read_path": schema.StringAttribute{
-				Description:         "The API path used to read the resource, which is used as the `id`. The `path` is used as the `id` instead if `read_path` is absent. " + pathDescription,
-				MarkdownDescription: "The API path used to read the resource, which is used as the `id`. The `path` is used as the `id` instead if `read_path` is absent. " + pathDescription,
+				Description:         "The API path used to read the resource, which is used as the `id`. The `path` is used as the `id` instead if `read_path` is absent. " + pathDescription + headerParamDescription,
+				MarkdownDescription: "The API path used to read the resource, which is used as the `id`. The `path` is used as the `id` instead if `read_path` is absent. " + pathDescription + headerParamDescription,
 				Optional:            true,
 				Validators: []validator.String{
 					myvalidator.StringIsPathBuilder(),
@@ -338,7 +619,7 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				MarkdownDescription: "The API path used to update the resource. The `id` is used instead if `update_path` is absent. " + pathDescription,
 				Optional:            true,
 				Validators: []validator.String{
-					myvalidator.StringIsPathBuilder(),
+					myvalidator.StringIsPathBuilderWithoutHeader(),
 				},
 			},
 			"delete_path": schema.StringAttribute{
@@ -346,7 +627,7 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				MarkdownDescription: "The API path used to delete the resource. The `id` is used instead if `delete_path` is absent. " + pathDescription,
 				Optional:            true,
 				Validators: []validator.String{
-					myvalidator.StringIsPathBuilder(),
+					myvalidator.StringIsPathBuilderWithoutHeader(),
 				},
 			},
 
@@ -356,6 +637,40 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Required:            true,
 			},
 
+			"body_file": schema.StringAttribute{
+				Description:         "The path to a file, whose content is sent as the raw payload for the `Create`/`Update` call instead of the `body`, e.g. uploading a binary artifact. The `body` is still used to track the properties of the resource read back. As changing the file content doesn't change this attribute, consider to add the file hash (e.g. `filesha256()`) to the `body` to update the resource on content change.",
+				MarkdownDescription: "The path to a file, whose content is sent as the raw payload for the `Create`/`Update` call instead of the `body`, e.g. uploading a binary artifact. The `body` is still used to track the properties of the resource read back. As changing the file content doesn't change this attribute, consider to add the file hash (e.g. `filesha256()`) to the `body` to update the resource on content change.",
+				Optional:            true,
+			},
+
+			"body_file_content_type": schema.StringAttribute{
+				Description:         "The `Content-Type` of the `body_file`. Defaults to `application/octet-stream`.",
+				MarkdownDescription: "The `Content-Type` of the `body_file`. Defaults to `application/octet-stream`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("body_file")),
+				},
+			},
+
+			"body_schema": schema.StringAttribute{
+				Description:         "The JSON Schema that the `body` is validated against during validation, so that the malformed payloads are caught before any API call. Conflicts with `body_schema_file`.",
+				MarkdownDescription: "The [JSON Schema](https://json-schema.org/) that the `body` is validated against during validation, so that the malformed payloads are caught before any API call. Conflicts with `body_schema_file`.",
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsJSON(),
+					stringvalidator.ConflictsWith(path.MatchRoot("body_schema_file")),
+				},
+			},
+
+			"body_schema_file": schema.StringAttribute{
+				Description:         "The path of the JSON Schema file that the `body` is validated against during validation, so that the malformed payloads are caught before any API call. Conflicts with `body_schema`.",
+				MarkdownDescription: "The path of the [JSON Schema](https://json-schema.org/) file that the `body` is validated against during validation, so that the malformed payloads are caught before any API call. Conflicts with `body_schema`.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("body_schema")),
+				},
+			},
+
 			"delete_body": schema.DynamicAttribute{
 				Description:         "The payload for the `Delete` call.",
 				MarkdownDescription: "The payload for the `Delete` call.",
@@ -388,9 +703,84 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Optional:            true,
 			},
 
-			"poll_create": pollAttribute("Create"),
-			"poll_update": pollAttribute("Update"),
-			"poll_delete": pollAttribute("Delete"),
+			"read_body_map": schema.MapAttribute{
+				Description:         "The mapping for relocating the properties in the response of reading (after selector), from the response paths (the keys) to the `body` paths (the values), in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md). E.g. `{\"properties.displayName\" = \"name\"}`. This is a simpler alternative to `read_response_template` for the case where only a few properties are nested differently.",
+				MarkdownDescription: "The mapping for relocating the properties in the response of reading (after selector), from the response paths (the keys) to the `body` paths (the values), in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md). E.g. `{\"properties.displayName\" = \"name\"}`. This is a simpler alternative to `read_response_template` for the case where only a few properties are nested differently.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ConflictsWith(path.MatchRoot("read_response_template")),
+				},
+			},
+
+			"poll_create":     pollAttribute("Create"),
+			"poll_update":     pollAttribute("Update"),
+			"poll_delete":     pollAttribute("Delete"),
+			"wait_for_create": waitForAttribute("`Create`", "By default, the `id` of this resource is used."),
+			"wait_for_update": waitForAttribute("`Update`", "By default, the `id` of this resource is used."),
+			"wait_for_delete": waitForDeleteAttribute(),
+			"auto_poll_on_202": schema.BoolAttribute{
+				Description:         "Whether to automatically poll for completion when the `Create`/`Update`/`Delete` call returns `202 Accepted` and the corresponding polling option is absent. The polling URL is discovered from the `Operation-Location`, `Azure-AsyncOperation` or `Location` response header (in this order). The operation status monitor of the former two keeps being polled until the `status` in its body is `Succeeded`, while `NotStarted`, `Running` and `InProgress` are regarded as pending. The `Location` keeps being polled until it returns a `2xx` other than `202`, which is regarded as pending. No polling happens if none of the headers is returned. Defaults to `false`.",
+				MarkdownDescription: "Whether to automatically poll for completion when the `Create`/`Update`/`Delete` call returns `202 Accepted` and the corresponding polling option is absent. The polling URL is discovered from the `Operation-Location`, `Azure-AsyncOperation` or `Location` response header (in this order). The operation status monitor of the former two keeps being polled until the `status` in its body is `Succeeded`, while `NotStarted`, `Running` and `InProgress` are regarded as pending. The `Location` keeps being polled until it returns a `2xx` other than `202`, which is regarded as pending. No polling happens if none of the headers is returned. Defaults to `false`.",
+				Optional:            true,
+			},
+
+			"log_level": schema.StringAttribute{
+				Description:         "The log level at which the request and response dumps of this resource are emitted, which are emitted at the `DEBUG` level by default. Possible values are `INFO`, `WARN` and `ERROR`. For example, setting it to `INFO` makes them visible with `TF_LOG=INFO`, without the debug logs of the other resources. This is useful for debugging a single resource.",
+				MarkdownDescription: "The log level at which the request and response dumps of this resource are emitted, which are emitted at the `DEBUG` level by default. Possible values are `INFO`, `WARN` and `ERROR`. For example, setting it to `INFO` makes them visible with `TF_LOG=INFO`, without the debug logs of the other resources. This is useful for debugging a single resource.",
+				Optional:            true,
+				Validators: []validator.String{
+					stringvalidator.OneOf("INFO", "WARN", "ERROR"),
+				},
+			},
+
+			"read_after_write_retry": schema.SingleNestedAttribute{
+				Description:         "Retry the read that is issued right after the creation and update, until it returns a `2xx` status. This is useful for APIs that are not yet able to read the resource right after a successful write. It doesn't apply to the refresh.",
+				MarkdownDescription: "Retry the read that is issued right after the creation and update, until it returns a `2xx` status. This is useful for APIs that are not yet able to read the resource right after a successful write. It doesn't apply to the refresh.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"attempts": schema.Int64Attribute{
+						Description:         "The maximum number of reads, including the first one.",
+						MarkdownDescription: "The maximum number of reads, including the first one.",
+						Required:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+					"interval_sec": schema.Int64Attribute{
+						Description:         "The interval between two reads in seconds. Defaults to `10`.",
+						MarkdownDescription: "The interval between two reads in seconds. Defaults to `10`.",
+						Optional:            true,
+						Validators: []validator.Int64{
+							int64validator.AtLeast(1),
+						},
+					},
+				},
+			},
+
+			"idempotency_key": schema.SingleNestedAttribute{
+				Description:         "Send an idempotency key on the create request, so that the API can deduplicate the creation when the request is retried (e.g. after a timeout). The same key is used for all the retries of the create request.",
+				MarkdownDescription: "Send an idempotency key on the create request, so that the API can deduplicate the creation when the request is retried (e.g. after a timeout). The same key is used for all the retries of the create request.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"header": schema.StringAttribute{
+						Description:         "The header name of the idempotency key. Defaults to `Idempotency-Key`.",
+						MarkdownDescription: "The header name of the idempotency key. Defaults to `Idempotency-Key`.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+					"value": schema.StringAttribute{
+						Description:         "The idempotency key. Defaults to a random UUID that is generated for each create.",
+						MarkdownDescription: "The idempotency key. Defaults to a random UUID that is generated for each create.",
+						Optional:            true,
+						Validators: []validator.String{
+							stringvalidator.LengthAtLeast(1),
+						},
+					},
+				},
+			},
 
 			"precheck_create": precheckAttribute("Create", true, "", false),
 			"precheck_update": precheckAttribute("Update", false, "By default, the `id` of this resource is used.", true),
@@ -426,9 +816,34 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"normalize": schema.MapAttribute{
+				Description:         "A map of `body` attribute paths (in gjson syntax) to the kinds of normalization that the server applies to them. During refresh, if the string value read at the path is equivalent to the one in the state after both being normalized, the one in the state is kept, to avoid the perpetual diffs. Possible kinds are `lowercase` (case insensitive), `cidr` (the masked CIDR or the IP address, e.g. `10.0.0.1/24` equals to `10.0.0.0/24`), `trim` (without the leading and trailing white spaces) and `json` (the JSON encoded string, regardless of the key order and white spaces). The `output` still reflects the values read.",
+				MarkdownDescription: "A map of `body` attribute paths (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to the kinds of normalization that the server applies to them. During refresh, if the string value read at the path is equivalent to the one in the state after both being normalized, the one in the state is kept, to avoid the perpetual diffs. Possible kinds are `lowercase` (case insensitive), `cidr` (the masked CIDR or the IP address, e.g. `10.0.0.1/24` equals to `10.0.0.0/24`), `trim` (without the leading and trailing white spaces) and `json` (the JSON encoded string, regardless of the key order and white spaces). The `output` still reflects the values read.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(normalizeLowercase, normalizeCIDR, normalizeTrim, normalizeJSON)),
+				},
+			},
+			"send_attrs": schema.SetAttribute{
+				Description:         "A set of `body` attribute paths (in gjson syntax) that will be sent in the create and update requests. If this is not specified, the whole `body` is sent. The `update_body_patches` are applied after this.",
+				MarkdownDescription: "A set of `body` attribute paths (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) that will be sent in the create and update requests. If this is not specified, the whole `body` is sent. The `update_body_patches` are applied after this.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"omit_null_body_attrs": schema.BoolAttribute{
+				Description:         "Whether to remove the null valued attributes (recursively) from the request body of the create and update requests, for APIs that reject the explicit `null` values. The `body` in the state still keeps them. Defaults to `false`.",
+				MarkdownDescription: "Whether to remove the null valued attributes (recursively) from the request body of the create and update requests, for APIs that reject the explicit `null` values. The `body` in the state still keeps them. Defaults to `false`.",
+				Optional:            true,
+			},
 			"merge_patch_disabled": schema.BoolAttribute{
-				Description:         "Whether to use a JSON Merge Patch as the request body in the PATCH update? This is only effective when `update_method` is set to `PATCH`. This overrides the `merge_patch_disabled` set in the provider block (defaults to `false`).",
-				MarkdownDescription: "Whether to use a JSON Merge Patch as the request body in the PATCH update? This is only effective when `update_method` is set to `PATCH`. This overrides the `merge_patch_disabled` set in the provider block (defaults to `false`).",
+				Description:         "Whether to use a JSON Merge Patch as the request body in the PATCH update? This is only effective when `update_method` is set to `PATCH`. The JSON Merge Patch is sent with the `Content-Type: application/merge-patch+json` header, unless the `Content-Type` is set in the `update_header` or `header`. This overrides the `merge_patch_disabled` set in the provider block (defaults to `false`).",
+				MarkdownDescription: "Whether to use a JSON Merge Patch as the request body in the PATCH update? This is only effective when `update_method` is set to `PATCH`. The JSON Merge Patch is sent with the `Content-Type: application/merge-patch+json` header, unless the `Content-Type` is set in the `update_header` or `header`. This overrides the `merge_patch_disabled` set in the provider block (defaults to `false`).",
+				Optional:            true,
+			},
+			"use_last_modified": schema.BoolAttribute{
+				Description:         "Whether to send the `Last-Modified` of the last read response (or the create/update response, if the resource is not read back) as the `If-Unmodified-Since` header in the update and delete requests, unless the header is set explicitly. This is the optimistic concurrency control for APIs that don't support the `ETag`, where a `412` response means the resource has been modified out of band. Defaults to `false`.",
+				MarkdownDescription: "Whether to send the `Last-Modified` of the last read response (or the create/update response, if the resource is not read back) as the `If-Unmodified-Since` header in the update and delete requests, unless the header is set explicitly. This is the optimistic concurrency control for APIs that don't support the `ETag`, where a `412` response means the resource has been modified out of band. Defaults to `false`.",
 				Optional:            true,
 			},
 			"query": schema.MapAttribute{
@@ -456,8 +871,8 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Optional:            true,
 			},
 			"delete_query": schema.MapAttribute{
-				Description:         operationOverridableAttrDescription("query", "delete"),
-				MarkdownDescription: operationOverridableAttrDescription("query", "delete"),
+				Description:         operationOverridableAttrDescription("query", "delete") + " The values can contain `$(body.x.y.z)` parameter that reference property from the `state.output`.",
+				MarkdownDescription: operationOverridableAttrDescription("query", "delete") + " The values can contain `$(body.x.y.z)` parameter that reference property from the `state.output`.",
 				ElementType:         types.ListType{ElemType: types.StringType},
 				Optional:            true,
 			},
@@ -467,6 +882,18 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				ElementType:         types.StringType,
 				Optional:            true,
 			},
+			"remove_query": schema.SetAttribute{
+				Description:         "The names of the query parameters that are removed from each request, after the `query` set in the provider block, the resource block and the operation specific blocks are applied. This is useful when the API doesn't tolerate some of the shared query parameters.",
+				MarkdownDescription: "The names of the query parameters that are removed from each request, after the `query` set in the provider block, the resource block and the operation specific blocks are applied. This is useful when the API doesn't tolerate some of the shared query parameters.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"remove_headers": schema.SetAttribute{
+				Description:         "The names of the headers that are removed from each request, after the `header` set in the provider block, the resource block and the operation specific blocks are applied. The names are case insensitive. This is useful when the API doesn't tolerate some of the shared headers.",
+				MarkdownDescription: "The names of the headers that are removed from each request, after the `header` set in the provider block, the resource block and the operation specific blocks are applied. The names are case insensitive. This is useful when the API doesn't tolerate some of the shared headers.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
 			"create_header": schema.MapAttribute{
 				Description:         operationOverridableAttrDescription("header", "create"),
 				MarkdownDescription: operationOverridableAttrDescription("header", "create"),
@@ -496,9 +923,59 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				MarkdownDescription: "Whether to check resource already existed? Defaults to `false`.",
 				Optional:            true,
 			},
+			"adopt_existing": schema.BoolAttribute{
+				Description:         "Whether to adopt the resource into the state, instead of erroring, when the existence check finds it already existed? In this case, the create call is skipped, and the resource at `path` is read into the state. This is only effective when `check_existance` is `true`. Defaults to `false`.",
+				MarkdownDescription: "Whether to adopt the resource into the state, instead of erroring, when the existence check finds it already existed? In this case, the create call is skipped, and the resource at `path` is read into the state. This is only effective when `check_existance` is `true`. Defaults to `false`.",
+				Optional:            true,
+			},
+			"delete_on_create_failure": schema.BoolAttribute{
+				Description:         "Whether to delete the resource if the creation fails after the `Create` call succeeds and the resource `id` is determined, e.g. the polling or the read after creation fails. The resource is deleted with its delete configuration (e.g. `delete_method`, `delete_path` and `poll_delete`), and removed from the state, instead of being kept as tainted. This is useful for APIs where the half-created resources cost money. Defaults to `false`.",
+				MarkdownDescription: "Whether to delete the resource if the creation fails after the `Create` call succeeds and the resource `id` is determined, e.g. the polling or the read after creation fails. The resource is deleted with its delete configuration (e.g. `delete_method`, `delete_path` and `poll_delete`), and removed from the state, instead of being kept as tainted. This is useful for APIs where the half-created resources cost money. Defaults to `false`.",
+				Optional:            true,
+			},
+			"refresh_after_write": schema.BoolAttribute{
+				Description:         "Whether to read the resource back after creation and update? If `false`, the response of the `Create` call (after `create_selector`) or the `Update` call is used as the `output` directly, which is useful for the eventually consistent APIs whose read right after a write can return stale data. If the `Update` call returns an empty body (or isn't issued), the `output` is kept unchanged. Note that in this case the `read_selector` and `read_response_template` don't apply to the `output` until the next refresh. Defaults to `true`.",
+				MarkdownDescription: "Whether to read the resource back after creation and update? If `false`, the response of the `Create` call (after `create_selector`) or the `Update` call is used as the `output` directly, which is useful for the eventually consistent APIs whose read right after a write can return stale data. If the `Update` call returns an empty body (or isn't issued), the `output` is kept unchanged. Note that in this case the `read_selector` and `read_response_template` don't apply to the `output` until the next refresh. Defaults to `true`.",
+				Optional:            true,
+			},
+			"ensure_exists_before_update": schema.BoolAttribute{
+				Description:         "Whether to check the resource still exists before updating it? If the resource doesn't exist (i.e. status code is 404), an error is raised instead of issuing the update. Defaults to `false`.",
+				MarkdownDescription: "Whether to check the resource still exists before updating it? If the resource doesn't exist (i.e. status code is 404), an error is raised instead of issuing the update. Defaults to `false`.",
+				Optional:            true,
+			},
+			"dry_run": schema.SingleNestedAttribute{
+				Description:         "Validate the `body` during plan, by sending the create/update request with the specified query parameters and/or headers, which are expected to make the API only validate the request (e.g. `?validateOnly=true`). Any non-2xx response is raised as a plan error. Note this makes a network call at plan time, and only takes effect when the `body` is fully known. As Terraform plans the resource again right before applying it, the dry-run request is also sent during apply.",
+				MarkdownDescription: "Validate the `body` during plan, by sending the create/update request with the specified query parameters and/or headers, which are expected to make the API only validate the request (e.g. `?validateOnly=true`). Any non-2xx response is raised as a plan error. Note this makes a network call at plan time, and only takes effect when the `body` is fully known. As Terraform plans the resource again right before applying it, the dry-run request is also sent during apply.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"query": schema.MapAttribute{
+						Description:         "The query parameters that are added to the dry-run request.",
+						MarkdownDescription: "The query parameters that are added to the dry-run request.",
+						ElementType:         types.ListType{ElemType: types.StringType},
+						Optional:            true,
+						Validators: []validator.Map{
+							mapvalidator.AtLeastOneOf(
+								path.MatchRelative().AtParent().AtName("header"),
+							),
+						},
+					},
+					"header": schema.MapAttribute{
+						Description:         "The header parameters that are added to the dry-run request.",
+						MarkdownDescription: "The header parameters that are added to the dry-run request.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
 			"force_new_attrs": schema.SetAttribute{
-				Description:         "A set of `body` attribute paths (in gjson syntax) whose value once changed, will trigger a replace of this resource. Note this only take effects when the `body` is a unknown before apply. Technically, we do a JSON merge patch and check whether the attribute path appear in the merge patch.",
-				MarkdownDescription: "A set of `body` attribute paths (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) whose value once changed, will trigger a replace of this resource. Note this only take effects when the `body` is a unknown before apply. Technically, we do a JSON merge patch and check whether the attribute path appear in the merge patch.",
+				Description:         "A set of `body` attribute paths (in gjson syntax) whose value once changed, will trigger a replace of this resource. Note this only takes effect when the `body` is fully known before apply, regardless of whether the changed value is set by the user or not. Technically, we do a JSON merge patch and check whether the attribute path appear in the merge patch.",
+				MarkdownDescription: "A set of `body` attribute paths (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) whose value once changed, will trigger a replace of this resource. Note this only takes effect when the `body` is fully known before apply, regardless of whether the changed value is set by the user or not. Technically, we do a JSON merge patch and check whether the attribute path appear in the merge patch.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"force_new_output_attrs": schema.SetAttribute{
+				Description:         "A set of `output` attribute paths (in gjson syntax) whose value once changed during refresh, will trigger a replace of this resource. This is useful for immutable attributes that only appear in the response, e.g. a server assigned backend id.",
+				MarkdownDescription: "A set of `output` attribute paths (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) whose value once changed during refresh, will trigger a replace of this resource. This is useful for immutable attributes that only appear in the response, e.g. a server assigned backend id.",
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
@@ -508,11 +985,60 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 				Optional:            true,
 				ElementType:         types.StringType,
 			},
+			"output_type_hints": schema.MapAttribute{
+				Description:         outputTypeHintsDescription,
+				MarkdownDescription: outputTypeHintsMarkdownDescription,
+				Optional:            true,
+				ElementType:         types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.ValueStringsAre(stringvalidator.OneOf(outputTypeString, outputTypeNumber, outputTypeBool)),
+				},
+			},
+			"output_aliases": schema.MapAttribute{
+				Description:         "A map of alias paths to the source paths (both in gjson syntax) in the response, which adds the aliases to the `output` with the values copied from the sources. This is useful to expose stable names regardless of the response keys, which can be changed across API versions. The aliases are added after `output_attrs`, so the sources don't need to be kept in the `output`. Sources that don't exist in the response are ignored.",
+				MarkdownDescription: "A map of alias paths to the source paths (both in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) in the response, which adds the aliases to the `output` with the values copied from the sources. This is useful to expose stable names regardless of the response keys, which can be changed across API versions. The aliases are added after `output_attrs`, so the sources don't need to be kept in the `output`. Sources that don't exist in the response are ignored.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"output_sort": schema.ListNestedAttribute{
+				Description:         "A list of arrays in the `output` to be sorted, so that the order of the array elements is stable across reads. This is useful for the APIs that return arrays in a nondeterministic order. Elements are ordered by number if the keys of both are numbers, otherwise by the string representation of the keys.",
+				MarkdownDescription: "A list of arrays in the `output` to be sorted, so that the order of the array elements is stable across reads. This is useful for the APIs that return arrays in a nondeterministic order. Elements are ordered by number if the keys of both are numbers, otherwise by the string representation of the keys.",
+				Optional:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"path": schema.StringAttribute{
+							Description:         "The path (in gjson syntax) to the array in the `output`. Use `@this` for the `output` itself.",
+							MarkdownDescription: "The path (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to the array in the `output`. Use `@this` for the `output` itself.",
+							Required:            true,
+						},
+						"key": schema.StringAttribute{
+							Description:         "The path (in gjson syntax) to the sort key, relative to each array element. Use `@this` for the element itself.",
+							MarkdownDescription: "The path (in [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md)) to the sort key, relative to each array element. Use `@this` for the element itself.",
+							Required:            true,
+						},
+					},
+				},
+			},
 			"output": schema.DynamicAttribute{
 				Description:         "The response body after reading the resource.",
 				MarkdownDescription: "The response body after reading the resource.",
 				Computed:            true,
 			},
+			"output_raw": schema.StringAttribute{
+				Description:         "The raw JSON of the `output`, which keeps the exact response body (after `read_selector`, `read_response_template`, `output_attrs` and `output_type_hints`) without round-tripping through the dynamic type.",
+				MarkdownDescription: "The raw JSON of the `output`, which keeps the exact response body (after `read_selector`, `read_response_template`, `output_attrs` and `output_type_hints`) without round-tripping through the dynamic type.",
+				Computed:            true,
+			},
+			"output_sha256": schema.StringAttribute{
+				Description:         "The hex encoded SHA256 hash of the `output_raw`, whose object keys are sorted beforehand. It only changes when the `output` changes, which is useful to trigger the changes of the downstream resources (e.g. via `replace_triggered_by`).",
+				MarkdownDescription: "The hex encoded SHA256 hash of the `output_raw`, whose object keys are sorted beforehand. It only changes when the `output` changes, which is useful to trigger the changes of the downstream resources (e.g. via `replace_triggered_by`).",
+				Computed:            true,
+			},
+			"last_request_duration_ms": schema.Int64Attribute{
+				Description:         "The duration of the last HTTP request issued for this resource, in millisecond. It is the `Create`/`Update` call during apply, or the `Read` call during refresh. It is only meant for performance debugging, and never triggers a plan diff.",
+				MarkdownDescription: "The duration of the last HTTP request issued for this resource, in millisecond. It is the `Create`/`Update` call during apply, or the `Read` call during refresh. It is only meant for performance debugging, and never triggers a plan diff.",
+				Computed:            true,
+			},
 		},
 	}
 }
@@ -533,6 +1059,14 @@ func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConf
 			)
 			return
 		}
+		if dynamic.IsFullyKnown(config.Body) && !config.BodySchema.IsUnknown() && !config.BodySchemaFile.IsUnknown() {
+			if err := ValidateBodySchema(config.BodySchema.ValueString(), config.BodySchemaFile.ValueString(), b); err != nil {
+				resp.Diagnostics.AddError(
+					"Invalid configuration",
+					fmt.Sprintf("The `body` doesn't conform to the JSON Schema: %v", err),
+				)
+			}
+		}
 		if !config.WriteOnlyAttributes.IsUnknown() && !config.WriteOnlyAttributes.IsNull() {
 			for _, ie := range config.WriteOnlyAttributes.Elements() {
 				ie := ie.(types.String)
@@ -554,26 +1088,71 @@ func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 		// If the entire plan is null, the resource is planned for destruction.
 		return
 	}
-	if req.State.Raw.IsNull() {
-		// If the entire state is null, the resource is planned for creation.
-		return
-	}
 
 	var plan resourceData
 	if diags := req.Plan.Get(ctx, &plan); diags.HasError() {
 		resp.Diagnostics.Append(diags...)
 		return
 	}
-	var state resourceData
-	if diags := req.State.Get(ctx, &state); diags.HasError() {
-		resp.Diagnostics.Append(diags...)
-		return
+
+	if req.State.Raw.IsNull() {
+		// If the entire state is null, the resource is planned for creation.
+		resp.Diagnostics.Append(r.dryRun(ctx, plan, nil)...)
+		return
+	}
+	var state resourceData
+	if diags := req.State.Get(ctx, &state); diags.HasError() {
+		resp.Diagnostics.Append(diags...)
+		return
 	}
 
 	defer func() {
 		resp.Plan.Set(ctx, plan)
 	}()
 
+	defer func() {
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if len(resp.RequiresReplace) != 0 {
+			resp.Diagnostics.Append(r.dryRun(ctx, plan, nil)...)
+			return
+		}
+		resp.Diagnostics.Append(r.dryRun(ctx, plan, &state)...)
+	}()
+
+	if !plan.ForceNewOutputAttrs.IsUnknown() && !plan.ForceNewOutputAttrs.IsNull() {
+		b, diags := req.Private.GetKey(ctx, pkForceNewOutput)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		if b != nil {
+			var changedAttrs []string
+			if err := json.Unmarshal(b, &changedAttrs); err != nil {
+				resp.Diagnostics.AddError(
+					"ModifyPlan failed",
+					fmt.Sprintf("unmarshaling private data %q: %v", pkForceNewOutput, err),
+				)
+				return
+			}
+			var forceNewOutputAttrs []types.String
+			if diags := plan.ForceNewOutputAttrs.ElementsAs(ctx, &forceNewOutputAttrs, false); diags.HasError() {
+				resp.Diagnostics.Append(diags...)
+				return
+			}
+			for _, attr := range forceNewOutputAttrs {
+				if attr.IsUnknown() || !slices.Contains(changedAttrs, attr.ValueString()) {
+					continue
+				}
+				// Mark the output as unknown so that it has a diff, which is required for Terraform to replace the resource.
+				plan.Output = types.DynamicUnknown()
+				resp.RequiresReplace = append(resp.RequiresReplace, tfpath.Root("output"))
+				break
+			}
+		}
+	}
+
 	if !plan.ForceNewAttrs.IsUnknown() && dynamic.IsFullyKnown(plan.Body) {
 		var forceNewAttrs []types.String
 		if diags := plan.ForceNewAttrs.ElementsAs(ctx, &forceNewAttrs, false); diags != nil {
@@ -601,6 +1180,7 @@ func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 					"ModifyPlan failed",
 					fmt.Sprintf("marshaling state body: %v", err),
 				)
+				return
 			}
 
 			modifiedJson, err := dynamic.ToJSON(plan.Body)
@@ -609,6 +1189,7 @@ func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 					"ModifyPlan failed",
 					fmt.Sprintf("marshaling plan body: %v", err),
 				)
+				return
 			}
 
 			patch, err := jsonpatch.CreateMergePatch(originJson, modifiedJson)
@@ -619,7 +1200,7 @@ func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 			for _, attr := range knownForceNewAttrs {
 				result := gjson.Get(string(patch), attr)
 				if result.Exists() {
-					resp.RequiresReplace = []tfpath.Path{tfpath.Root("body")}
+					resp.RequiresReplace = append(resp.RequiresReplace, tfpath.Root("body"))
 					break
 				}
 			}
@@ -627,6 +1208,125 @@ func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanReques
 	}
 }
 
+// dryRun sends the create (if state is nil) or update request with the `dry_run` query parameters and headers,
+// to let the API validate the planned body, which is built in the same way as the create or update does.
+func (r *Resource) dryRun(ctx context.Context, plan resourceData, state *resourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.DryRun.IsNull() || plan.DryRun.IsUnknown() || !dynamic.IsFullyKnown(plan.Body) || plan.Path.IsUnknown() {
+		return nil
+	}
+	c := r.p.client
+	if c == nil {
+		return nil
+	}
+	c.SetLoggerContext(client.WithLogLevel(ctx, plan.LogLevel.ValueString()))
+
+	var d dryRunData
+	if diags := plan.DryRun.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return diags
+	}
+	for _, v := range []attr.Value{d.Query, d.Header} {
+		tv, err := v.ToTerraformValue(ctx)
+		if err != nil || !tv.IsFullyKnown() {
+			return nil
+		}
+	}
+	var query map[string][]string
+	if diags := d.Query.ElementsAs(ctx, &query, false); diags.HasError() {
+		return diags
+	}
+	var header map[string]string
+	if diags := d.Header.ElementsAs(ctx, &header, false); diags.HasError() {
+		return diags
+	}
+
+	body, err := dynamic.ToJSON(plan.Body)
+	if err != nil {
+		diags.AddError(
+			"Dry run failure",
+			fmt.Sprintf("marshaling plan body: %v", err),
+		)
+		return diags
+	}
+
+	var response *resty.Response
+	if state == nil {
+		opt, odiags := r.p.apiOpt.ForResourceCreate(ctx, plan)
+		diags.Append(odiags...)
+		if diags.HasError() {
+			return diags
+		}
+		for k, v := range query {
+			opt.Query[k] = v
+		}
+		for k, v := range header {
+			opt.Header[k] = v
+		}
+		body, odiags = sendBody(ctx, plan.SendAttrs, plan.OmitNullBodyAttrs.ValueBool(), body)
+		diags.Append(odiags...)
+		if diags.HasError() {
+			return diags
+		}
+		response, err = c.Create(ctx, plan.Path.ValueString(), string(body), *opt)
+	} else {
+		var stateBody []byte
+		stateBody, err = dynamic.ToJSON(state.Body)
+		if err != nil {
+			diags.AddError(
+				"Dry run failure",
+				fmt.Sprintf("marshaling state body: %v", err),
+			)
+			return diags
+		}
+		if string(stateBody) == string(body) {
+			return nil
+		}
+
+		opt, odiags := r.p.apiOpt.ForResourceUpdate(ctx, plan)
+		diags.Append(odiags...)
+		if diags.HasError() {
+			return diags
+		}
+		for k, v := range query {
+			opt.Query[k] = v
+		}
+		for k, v := range header {
+			opt.Header[k] = v
+		}
+
+		if plan.UpdatePath.IsUnknown() || plan.UpdateBodyPatches.IsUnknown() {
+			return nil
+		}
+		body, odiags = buildUpdateBody(ctx, *opt, plan, *state, body)
+		diags.Append(odiags...)
+		if diags.HasError() {
+			return diags
+		}
+		path, odiags := buildUpdatePath(plan, *state)
+		diags.Append(odiags...)
+		if diags.HasError() {
+			return diags
+		}
+		response, err = c.Update(ctx, path, string(body), *opt)
+	}
+	if err != nil {
+		diags.AddError(
+			"Error to call dry run",
+			err.Error(),
+		)
+		return diags
+	}
+	if !response.IsSuccess() {
+		diags.AddError(
+			fmt.Sprintf("Dry run API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
+			string(response.Body()),
+		)
+		return diags
+	}
+	return nil
+}
+
 func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -657,6 +1357,9 @@ func (r Resource) Create(ctx context.Context, req resource.CreateRequest, resp *
 		return
 	}
 
+	ctx = client.WithLogLevel(ctx, plan.LogLevel.ValueString())
+	c.SetLoggerContext(ctx)
+
 	tflog.Info(ctx, "Create a resource", map[string]interface{}{"path": plan.Path.ValueString()})
 
 	opt, diags := r.p.apiOpt.ForResourceCreate(ctx, plan)
@@ -680,17 +1383,79 @@ func (r Resource) Create(ctx context.Context, req resource.CreateRequest, resp *
 			return
 		}
 		if response.StatusCode() != http.StatusNotFound {
-			resp.Diagnostics.AddError(
-				"Resource already exists",
-				fmt.Sprintf("A resource with the ID %q already exists - to be managed via Terraform this resource needs to be imported into the State. Please see the resource documentation for %q for more information.", plan.Path.ValueString(), `restful_resource`),
-			)
+			if !plan.AdoptExisting.ValueBool() {
+				resp.Diagnostics.AddError(
+					"Resource already exists",
+					fmt.Sprintf("A resource with the ID %q already exists - to be managed via Terraform this resource needs to be imported into the State. Please see the resource documentation for %q for more information.", plan.Path.ValueString(), `restful_resource`),
+				)
+				return
+			}
+			if !response.IsSuccess() {
+				resp.Diagnostics.AddError(
+					"Existance check failed",
+					fmt.Sprintf("Read API returns %d%s: %s", response.StatusCode(), client.RetrySummary(response), string(response.Body())),
+				)
+				return
+			}
+
+			tflog.Info(ctx, "Adopt the existing resource", map[string]interface{}{"path": plan.Path.ValueString()})
+
+			// Adopt the existing resource by skipping the create call, and reading it back as the create does.
+			reqBody, err := dynamic.ToJSON(plan.Body)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error to marshal body",
+					err.Error(),
+				)
+				return
+			}
+			b, resourceId, diags := locateCreatedResource(plan, reqBody, response)
+			resp.Diagnostics.Append(diags...)
+			if diags.HasError() {
+				return
+			}
+			plan.ID = types.StringValue(resourceId)
+			plan.LastRequestDurationMs = types.Int64Value(response.Time().Milliseconds())
+			output, err := dynamic.FromJSONImplied(b)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Evaluating `output` during Read",
+					err.Error(),
+				)
+				return
+			}
+			plan.Output = output
+
+			diags = resp.State.Set(ctx, plan)
+			resp.Diagnostics.Append(diags...)
+			if diags.HasError() {
+				return
+			}
+
+			rreq := resource.ReadRequest{
+				State:        resp.State,
+				ProviderMeta: req.ProviderMeta,
+			}
+			rresp := resource.ReadResponse{
+				State:       resp.State,
+				Private:     resp.Private,
+				Diagnostics: resp.Diagnostics,
+			}
+			r.read(ctx, rreq, &rresp, false)
+
+			resp.State = rresp.State
+			resp.Diagnostics = rresp.Diagnostics
 			return
 		}
 	}
 
 	// Precheck
-	if !plan.PrecheckCreate.IsNull() {
-		unlockFunc, diags := precheck(ctx, c, r.p.apiOpt, "", opt.Header, opt.Query, plan.PrecheckCreate, basetypes.NewDynamicNull())
+	precheckCreate := plan.PrecheckCreate
+	if precheckCreate.IsNull() {
+		precheckCreate = r.p.apiOpt.DefaultPrecheckCreate
+	}
+	if !precheckCreate.IsNull() {
+		unlockFunc, diags := precheck(ctx, c, r.p.apiOpt, "", opt.Header, opt.Query, precheckCreate, basetypes.NewDynamicNull())
 		if diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
@@ -707,7 +1472,31 @@ func (r Resource) Create(ctx context.Context, req resource.CreateRequest, resp *
 		)
 		return
 	}
-	response, err := c.Create(ctx, plan.Path.ValueString(), string(b), *opt)
+	reqBody := b
+	sb, diags := sendBody(ctx, plan.SendAttrs, plan.OmitNullBodyAttrs.ValueBool(), b)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	if !plan.IdempotencyKey.IsNull() {
+		var d idempotencyKeyData
+		if diags := plan.IdempotencyKey.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+		header, key := buildIdempotencyKey(d)
+		opt.Header[header] = key
+	}
+	var response *resty.Response
+	if !plan.BodyFile.IsNull() {
+		response, err = c.OperationWithFile(ctx, plan.Path.ValueString(), plan.BodyFile.ValueString(), bodyFileContentType(plan.BodyFileContentType), client.OperationOption{
+			Method: opt.Method,
+			Query:  opt.Query,
+			Header: opt.Header,
+		})
+	} else {
+		response, err = c.Create(ctx, plan.Path.ValueString(), string(sb), *opt)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error to call create",
@@ -717,38 +1506,25 @@ func (r Resource) Create(ctx context.Context, req resource.CreateRequest, resp *
 	}
 	if !response.IsSuccess() {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Create API returns %d", response.StatusCode()),
+			fmt.Sprintf("Create API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 			string(response.Body()),
 		)
 		return
 	}
 
-	b = response.Body()
+	plan.LastRequestDurationMs = types.Int64Value(response.Time().Milliseconds())
 
-	if sel := plan.CreateSelector.ValueString(); sel != "" {
-		bodyLocator := client.BodyLocator(sel)
-		sb, ok := bodyLocator.LocateValueInResp(*response)
-		if !ok {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("`create_selector` failed to select from the response"),
-				string(response.Body()),
-			)
+	if plan.UseLastModified.ValueBool() {
+		resp.Diagnostics.Append(recordLastModified(ctx, resp.Private, response.Header())...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-		b = []byte(sb)
 	}
 
-	// Construct the resource id, which is used as the path to read the resource later on. By default, it is the same as the "path", unless "read_path" is specified.
-	resourceId := plan.Path.ValueString()
-	if !plan.ReadPath.IsNull() {
-		resourceId, err = exparam.ExpandBodyOrPath(plan.ReadPath.ValueString(), plan.Path.ValueString(), b)
-		if err != nil {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("Failed to build the path for reading the resource"),
-				fmt.Sprintf("Can't build resource id with `read_path`: %q, `path`: %q, `body`: %q: %v", plan.ReadPath.ValueString(), plan.Path.ValueString(), string(b), err),
-			)
-			return
-		}
+	b, resourceId, diags := locateCreatedResource(plan, reqBody, response)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
 	}
 
 	// Set resource ID
@@ -772,10 +1548,24 @@ func (r Resource) Create(ctx context.Context, req resource.CreateRequest, resp *
 		return
 	}
 
+	// Compensate the failure of the later steps by deleting the just created resource, instead of leaving it tainted.
+	if plan.DeleteOnCreateFailure.ValueBool() {
+		defer func() {
+			if resp.Diagnostics.HasError() {
+				r.deleteOnCreateFailure(ctx, resp)
+			}
+		}()
+	}
+
 	// For LRO, wait for completion
-	if !plan.PollCreate.IsNull() {
+	var pollOpt *client.PollOption
+	pollCreate := plan.PollCreate
+	if pollCreate.IsNull() {
+		pollCreate = r.p.apiOpt.DefaultPollCreate
+	}
+	if !pollCreate.IsNull() {
 		var d pollData
-		if diags := plan.PollCreate.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
+		if diags := pollCreate.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
 		}
@@ -789,7 +1579,12 @@ func (r Resource) Create(ctx context.Context, req resource.CreateRequest, resp *
 			// As it will be used to poll the resource status.
 			response.Request.URL = resourceId
 		}
-		p, err := client.NewPollableForPoll(*response, *opt)
+		pollOpt = opt
+	} else if plan.AutoPollOn202.ValueBool() && response.StatusCode() == http.StatusAccepted {
+		pollOpt = r.p.apiOpt.ForAutoPoll(*response, opt.Header, opt.Query)
+	}
+	if pollOpt != nil {
+		p, err := client.NewPollableForPoll(*response, *pollOpt)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Create: Failed to build poller from the response of the initiated request",
@@ -806,22 +1601,166 @@ func (r Resource) Create(ctx context.Context, req resource.CreateRequest, resp *
 		}
 	}
 
+	diags = waitForBlock(ctx, c, r.p.apiOpt, "wait_for_create", plan.WaitForCreate, waitForOption{
+		DefaultPath: resourceId,
+		Header:      opt.Header,
+		Query:       opt.Query,
+		RespBody:    b,
+		RespHeader:  response.Header(),
+		Output:      output,
+	})
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	// Use the create response as the `output` directly, instead of reading the resource back.
+	if !plan.refreshAfterWrite() {
+		output, outputRaw, diags := r.buildOutput(ctx, plan, b)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		plan.Output = output
+		plan.OutputRaw = types.StringValue(outputRaw)
+		plan.OutputSHA256 = types.StringValue(HashJSON(outputRaw))
+
+		idURL, err := c.AbsoluteURL(plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Building `id_url`",
+				err.Error(),
+			)
+			return
+		}
+		plan.IdURL = types.StringValue(idURL)
+
+		diags = resp.State.Set(ctx, plan)
+		resp.Diagnostics.Append(diags...)
+		return
+	}
+
 	rreq := resource.ReadRequest{
 		State:        resp.State,
 		ProviderMeta: req.ProviderMeta,
 	}
 	rresp := resource.ReadResponse{
 		State:       resp.State,
+		Private:     resp.Private,
 		Diagnostics: resp.Diagnostics,
 	}
 	r.read(ctx, rreq, &rresp, false)
 
 	*resp = resource.CreateResponse{
 		State:       rresp.State,
+		Private:     resp.Private,
 		Diagnostics: rresp.Diagnostics,
 	}
 }
 
+// locateCreatedResource locates the created resource in the response of the create call (or the existence check for
+// the adopted resource), which is selected by the `create_selector` if any. It returns the body of the resource, and
+// the resource id, which is used as the path to read the resource later on. By default, the resource id is the same as
+// the "path", unless "read_path" is specified.
+func locateCreatedResource(plan resourceData, reqBody []byte, response *resty.Response) ([]byte, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	b := response.Body()
+	if sel := plan.CreateSelector.ValueString(); sel != "" {
+		sel, err := exparam.ExpandBody(sel, reqBody)
+		if err != nil {
+			diags.AddError(
+				"Create failure",
+				fmt.Sprintf("Failed to expand the create selector: %v", err),
+			)
+			return nil, "", diags
+		}
+		bodyLocator := client.BodyLocator(sel)
+		sb, ok := bodyLocator.LocateValueInResp(*response)
+		if !ok {
+			diags.AddError(
+				fmt.Sprintf("`create_selector` failed to select from the response"),
+				string(response.Body()),
+			)
+			return nil, "", diags
+		}
+		b = []byte(sb)
+	}
+
+	resourceId := plan.Path.ValueString()
+	if !plan.ReadPath.IsNull() {
+		var err error
+		resourceId, err = exparam.ExpandBodyOrPath(plan.ReadPath.ValueString(), plan.Path.ValueString(), b, response.Header())
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("Failed to build the path for reading the resource"),
+				fmt.Sprintf("Can't build resource id with `read_path`: %q, `path`: %q, `body`: %q: %v", plan.ReadPath.ValueString(), plan.Path.ValueString(), string(b), err),
+			)
+			return nil, "", diags
+		}
+	}
+	return b, resourceId, diags
+}
+
+// buildIdempotencyKey returns the header name and the value of the idempotency key for the create request.
+// The value defaults to a random UUID, so that each create gets a new key. It is set once per create, hence the retries of the request reuse it.
+func buildIdempotencyKey(d idempotencyKeyData) (string, string) {
+	header := "Idempotency-Key"
+	if !d.Header.IsNull() {
+		header = d.Header.ValueString()
+	}
+	if !d.Value.IsNull() {
+		return header, d.Value.ValueString()
+	}
+	return header, uuid.NewString()
+}
+
+// recordLastModified records the `Last-Modified` of the response in the private state, or removes the recorded one if
+// the response has none, as it might be out of date.
+func recordLastModified(ctx context.Context, private interface {
+	SetKey(context.Context, string, []byte) diag.Diagnostics
+}, header http.Header) diag.Diagnostics {
+	v := header.Get("Last-Modified")
+	if v == "" {
+		return private.SetKey(ctx, pkLastModified, nil)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return diag.Diagnostics{
+			diag.NewErrorDiagnostic(
+				"Recording `Last-Modified`",
+				fmt.Sprintf("marshaling private data %q: %v", pkLastModified, err),
+			),
+		}
+	}
+	return private.SetKey(ctx, pkLastModified, b)
+}
+
+// setIfUnmodifiedSince sets the `Last-Modified` recorded in the private state as the `If-Unmodified-Since` header, unless the
+// header is set explicitly. It returns the value set, which is empty if nothing is set.
+func setIfUnmodifiedSince(ctx context.Context, private interface {
+	GetKey(context.Context, string) ([]byte, diag.Diagnostics)
+}, header client.Header) (string, diag.Diagnostics) {
+	for k := range header {
+		if strings.EqualFold(k, "If-Unmodified-Since") {
+			return "", nil
+		}
+	}
+	b, diags := private.GetKey(ctx, pkLastModified)
+	if diags.HasError() || b == nil {
+		return "", diags
+	}
+	var v string
+	if err := json.Unmarshal(b, &v); err != nil {
+		diags.AddError(
+			"Setting `If-Unmodified-Since`",
+			fmt.Sprintf("unmarshaling private data %q: %v", pkLastModified, err),
+		)
+		return "", diags
+	}
+	header["If-Unmodified-Since"] = v
+	return v, diags
+}
+
 func (r Resource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
 	r.p.client.SetLoggerContext(ctx)
 	r.read(ctx, req, resp, true)
@@ -837,6 +1776,9 @@ func (r Resource) read(ctx context.Context, req resource.ReadRequest, resp *reso
 		return
 	}
 
+	ctx = client.WithLogLevel(ctx, state.LogLevel.ValueString())
+	c.SetLoggerContext(ctx)
+
 	if updateBody {
 		tflog.Info(ctx, "Read a resource", map[string]interface{}{"id": state.ID.ValueString()})
 	}
@@ -847,7 +1789,23 @@ func (r Resource) read(ctx context.Context, req resource.ReadRequest, resp *reso
 		return
 	}
 
-	response, err := c.Read(ctx, state.ID.ValueString(), *opt)
+	var response *resty.Response
+	var err error
+	if !updateBody && !state.ReadAfterWriteRetry.IsNull() {
+		// This is the read right after the creation or update.
+		var d readAfterWriteRetryData
+		if diags := state.ReadAfterWriteRetry.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
+			resp.Diagnostics.Append(diags...)
+			return
+		}
+		interval := defaults.PollDefaultDelay
+		if !d.Interval.IsNull() {
+			interval = time.Duration(d.Interval.ValueInt64()) * time.Second
+		}
+		response, err = readUntilSuccess(ctx, c, state.ID.ValueString(), *opt, int(d.Attempts.ValueInt64()), interval)
+	} else {
+		response, err = c.Read(ctx, state.ID.ValueString(), *opt)
+	}
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error to call read",
@@ -861,12 +1819,19 @@ func (r Resource) read(ctx context.Context, req resource.ReadRequest, resp *reso
 	}
 	if !response.IsSuccess() {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Read API returns %d", response.StatusCode()),
+			fmt.Sprintf("Read API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 			string(response.Body()),
 		)
 		return
 	}
 
+	if state.UseLastModified.ValueBool() && resp.Private != nil {
+		resp.Diagnostics.Append(recordLastModified(ctx, resp.Private, response.Header())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	b := response.Body()
 
 	if sel := state.ReadSelector.ValueString(); sel != "" {
@@ -886,6 +1851,15 @@ func (r Resource) read(ctx context.Context, req resource.ReadRequest, resp *reso
 			)
 			return
 		}
+		if state.ReadSelectorSingle.ValueBool() {
+			if n := CountSelectorMatches(response.Body(), sel); n > 1 {
+				resp.Diagnostics.AddError(
+					"Read failure",
+					fmt.Sprintf("The read selector %q matches %d member resources, while exactly one is expected", sel, n),
+				)
+				return
+			}
+		}
 		bodyLocator := client.BodyLocator(sel)
 		sb, ok := bodyLocator.LocateValueInResp(*response)
 		// This means the tracked resource selected (filtered) from the response now disappears (deleted out of band).
@@ -896,6 +1870,24 @@ func (r Resource) read(ctx context.Context, req resource.ReadRequest, resp *reso
 		b = []byte(sb)
 	}
 
+	if !state.ReadBodyMap.IsNull() {
+		var m map[string]string
+		diags = state.ReadBodyMap.ElementsAs(ctx, &m, false)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+		sb, err := RemapJSON(string(b), m)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Read failure",
+				fmt.Sprintf("Failed to remap the read response by `read_body_map`: %v", err),
+			)
+			return
+		}
+		b = []byte(sb)
+	}
+
 	if tpl := state.ReadResponseTemplate.ValueString(); tpl != "" {
 		sb, err := exparam.ExpandBody(tpl, b)
 		if err != nil {
@@ -944,16 +1936,53 @@ func (r Resource) read(ctx context.Context, req resource.ReadRequest, resp *reso
 			b = []byte(pb)
 		}
 
-		var body types.Dynamic
-		if body, err = dynamic.FromJSON(b, state.Body.UnderlyingValue().Type(ctx)); err != nil {
-			// An error might occur here during refresh, when the type of the state doesn't match the remote,
-			// e.g. a tuple field has different number of elements.
-			// In this case, we fallback to the implied types, to make the refresh proceed and return a reasonable plan diff.
-			if body, err = dynamic.FromJSONImplied(b); err != nil {
-				resp.Diagnostics.AddError(
-					"Evaluating `body` during Read",
-					err.Error(),
-				)
+		// Keep the values in the state that only differ from the read response by the server normalization.
+		// This only applies to the body, the output is built from the read response as is.
+		bb := b
+		if !state.Normalize.IsNull() {
+			var normalize map[string]string
+			diags = state.Normalize.ElementsAs(ctx, &normalize, false)
+			resp.Diagnostics.Append(diags...)
+			if diags.HasError() {
+				return
+			}
+			stateBody, err := dynamic.ToJSON(state.Body)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Read failure",
+					fmt.Sprintf("marshal state body: %v", err),
+				)
+				return
+			}
+			nb, err := NormalizeBody(string(stateBody), string(b), normalize)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Read failure",
+					fmt.Sprintf("Failed to normalize the read response by `normalize`: %v", err),
+				)
+				return
+			}
+			bb = []byte(nb)
+		}
+
+		var body types.Dynamic
+		if body, err = dynamic.FromJSON(bb, state.Body.UnderlyingValue().Type(ctx)); err != nil {
+			// An error might occur here during refresh, when the type of the state doesn't match the remote,
+			// e.g. a tuple field has different number of elements.
+			// In this case, we fallback to the implied types, to make the refresh proceed and return a reasonable plan diff,
+			// unless the user explicitly asks to fail on such type mismatch.
+			if r.p.apiOpt.StrictReadTypes {
+				resp.Diagnostics.AddError(
+					"Evaluating `body` during Read",
+					fmt.Sprintf("The type of the response body doesn't match the type of the `body` in the state: %v", err),
+				)
+				return
+			}
+			if body, err = dynamic.FromJSONImplied(bb); err != nil {
+				resp.Diagnostics.AddError(
+					"Evaluating `body` during Read",
+					err.Error(),
+				)
 				return
 			}
 		}
@@ -961,33 +1990,84 @@ func (r Resource) read(ctx context.Context, req resource.ReadRequest, resp *reso
 	}
 
 	// Set output
-	if !state.OutputAttrs.IsNull() {
-		var outputAttrs []string
-		diags = state.OutputAttrs.ElementsAs(ctx, &outputAttrs, false)
+	output, outputRaw, diags := r.buildOutput(ctx, state, b)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	// Record the `force_new_output_attrs` that have changed during refresh, which will trigger a replace during plan.
+	if updateBody && resp.Private != nil && !state.ForceNewOutputAttrs.IsNull() && !state.Output.IsNull() && !state.Output.IsUnknown() {
+		var forceNewOutputAttrs []string
+		diags = state.ForceNewOutputAttrs.ElementsAs(ctx, &forceNewOutputAttrs, false)
 		resp.Diagnostics.Append(diags...)
 		if diags.HasError() {
 			return
 		}
-		fb, err := FilterAttrsInJSON(string(b), outputAttrs)
+		prevOutput, err := dynamic.ToJSON(state.Output)
 		if err != nil {
 			resp.Diagnostics.AddError(
-				"Filter `output` during Read",
-				err.Error(),
+				"Read failure",
+				fmt.Sprintf("marshaling state output: %v", err),
 			)
 			return
 		}
-		b = []byte(fb)
+		changedAttrs := ChangedAttrsInJSON(string(prevOutput), outputRaw, forceNewOutputAttrs)
+		if len(changedAttrs) != 0 {
+			// Keep the changed attributes recorded by previous refreshes, e.g. during `terraform apply -refresh-only`.
+			var prevChangedAttrs []string
+			pb, diags := req.Private.GetKey(ctx, pkForceNewOutput)
+			resp.Diagnostics.Append(diags...)
+			if diags.HasError() {
+				return
+			}
+			if pb != nil {
+				if err := json.Unmarshal(pb, &prevChangedAttrs); err != nil {
+					resp.Diagnostics.AddError(
+						"Read failure",
+						fmt.Sprintf("unmarshaling private data %q: %v", pkForceNewOutput, err),
+					)
+					return
+				}
+			}
+			for _, attr := range prevChangedAttrs {
+				if !slices.Contains(changedAttrs, attr) {
+					changedAttrs = append(changedAttrs, attr)
+				}
+			}
+			pb, err = json.Marshal(changedAttrs)
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Read failure",
+					fmt.Sprintf("marshaling private data %q: %v", pkForceNewOutput, err),
+				)
+				return
+			}
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, pkForceNewOutput, pb)...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
 	}
 
-	output, err := dynamic.FromJSONImplied(b)
+	state.Output = output
+	state.OutputRaw = types.StringValue(outputRaw)
+	state.OutputSHA256 = types.StringValue(HashJSON(outputRaw))
+
+	// Only a real refresh records the read duration, the read following a `Create`/`Update` keeps the duration of that call.
+	if updateBody {
+		state.LastRequestDurationMs = types.Int64Value(response.Time().Milliseconds())
+	}
+
+	idURL, err := c.AbsoluteURL(state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Evaluating `output` during Read",
+			"Building `id_url` during Read",
 			err.Error(),
 		)
 		return
 	}
-	state.Output = output
+	state.IdURL = types.StringValue(idURL)
 
 	diags = resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
@@ -996,6 +2076,124 @@ func (r Resource) read(ctx context.Context, req resource.ReadRequest, resp *reso
 	}
 }
 
+// bodyFileContentType returns the `Content-Type` of the `body_file`, which defaults to `application/octet-stream`.
+func bodyFileContentType(contentType types.String) string {
+	if contentType.IsNull() {
+		return "application/octet-stream"
+	}
+	return contentType.ValueString()
+}
+
+// sendBody returns the request body that only contains the `send_attrs`, if specified.
+// The null valued attributes are removed afterwards if omitNull is true.
+func sendBody(ctx context.Context, sendAttrs types.Set, omitNull bool, b []byte) ([]byte, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	if !sendAttrs.IsNull() {
+		var attrs []string
+		diags.Append(sendAttrs.ElementsAs(ctx, &attrs, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		fb, err := FilterAttrsInJSON(string(b), attrs)
+		if err != nil {
+			diags.AddError(
+				"Filter `body` by `send_attrs`",
+				err.Error(),
+			)
+			return nil, diags
+		}
+		b = []byte(fb)
+	}
+	if omitNull {
+		nb, err := RemoveNullsInJSON(string(b))
+		if err != nil {
+			diags.AddError(
+				"Remove null attributes from `body`",
+				err.Error(),
+			)
+			return nil, diags
+		}
+		b = []byte(nb)
+	}
+	return b, nil
+}
+
+// buildOutput builds the `output` from the response body, with the `output_attrs` and `output_type_hints` applied.
+// It also returns the raw JSON of the `output`.
+func (r Resource) buildOutput(ctx context.Context, d resourceData, b []byte) (types.Dynamic, string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	// Keep the original response, as the alias sources might be filtered out by the `output_attrs`.
+	rb := b
+
+	if !d.OutputAttrs.IsNull() {
+		var outputAttrs []string
+		diags.Append(d.OutputAttrs.ElementsAs(ctx, &outputAttrs, false)...)
+		if diags.HasError() {
+			return types.Dynamic{}, "", diags
+		}
+		fb, err := FilterAttrsInJSON(string(b), outputAttrs)
+		if err != nil {
+			diags.AddError(
+				"Filter `output`",
+				err.Error(),
+			)
+			return types.Dynamic{}, "", diags
+		}
+		b = []byte(fb)
+	}
+
+	if !d.OutputAliases.IsNull() {
+		var outputAliases map[string]string
+		diags.Append(d.OutputAliases.ElementsAs(ctx, &outputAliases, false)...)
+		if diags.HasError() {
+			return types.Dynamic{}, "", diags
+		}
+		ab, err := AliasAttrsInJSON(string(b), string(rb), outputAliases)
+		if err != nil {
+			diags.AddError(
+				"Alias `output` attributes",
+				err.Error(),
+			)
+			return types.Dynamic{}, "", diags
+		}
+		b = []byte(ab)
+	}
+
+	b, ds := coerceOutputTypes(ctx, d.OutputTypeHints, b)
+	diags.Append(ds...)
+	if diags.HasError() {
+		return types.Dynamic{}, "", diags
+	}
+
+	if !d.OutputSort.IsNull() {
+		sorts, ds := expandOutputSorts(ctx, d.OutputSort)
+		diags.Append(ds...)
+		if diags.HasError() {
+			return types.Dynamic{}, "", diags
+		}
+		sb, err := SortArraysInJSON(string(b), sorts)
+		if err != nil {
+			diags.AddError(
+				"Sort `output`",
+				err.Error(),
+			)
+			return types.Dynamic{}, "", diags
+		}
+		b = []byte(sb)
+	}
+
+	output, err := dynamic.FromJSONImplied(b)
+	if err != nil {
+		diags.AddError(
+			"Evaluating `output`",
+			err.Error(),
+		)
+		return types.Dynamic{}, "", diags
+	}
+	return output, string(b), diags
+}
+
 func (r Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	c := r.p.client
 	c.SetLoggerContext(ctx)
@@ -1016,9 +2214,16 @@ func (r Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *
 		return
 	}
 
+	ctx = client.WithLogLevel(ctx, plan.LogLevel.ValueString())
+	c.SetLoggerContext(ctx)
+
 	// Temporarily set the output here, so that the Read at the end can
 	// expand the `$(body)` parameters.
 	plan.Output = state.Output
+	plan.OutputRaw = state.OutputRaw
+	plan.OutputSHA256 = state.OutputSHA256
+	// Keeps the last duration in case no update call is issued (e.g. only the non-body attributes changed).
+	plan.LastRequestDurationMs = state.LastRequestDurationMs
 
 	opt, diags := r.p.apiOpt.ForResourceUpdate(ctx, plan)
 	resp.Diagnostics.Append(diags...)
@@ -1043,97 +2248,89 @@ func (r Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *
 		return
 	}
 
-	// Invoke API to Update the resource only when there are changes in the body (regardless of the TF type diff).
-	if string(stateBody) != string(planBody) {
-		// Precheck
-		if !plan.PrecheckUpdate.IsNull() {
-			unlockFunc, diags := precheck(ctx, c, r.p.apiOpt, state.ID.ValueString(), opt.Header, opt.Query, plan.PrecheckUpdate, state.Output)
+	// The response body of the update call, which is used as the `output` when `refresh_after_write` is `false`.
+	var updateRespBody []byte
+
+	// Invoke API to Update the resource only when there are changes in the body (regardless of the TF type diff), or in the body file.
+	if string(stateBody) != string(planBody) || !plan.BodyFile.Equal(state.BodyFile) || !plan.BodyFileContentType.Equal(state.BodyFileContentType) {
+		if plan.EnsureExistsBeforeUpdate.ValueBool() {
+			opt, diags := r.p.apiOpt.ForResourceRead(ctx, plan)
+			resp.Diagnostics.Append(diags...)
 			if diags.HasError() {
-				resp.Diagnostics.Append(diags...)
 				return
 			}
-			defer unlockFunc()
-		}
-
-		if opt.Method == "PATCH" && !opt.MergePatchDisabled {
-			stateBodyJSON, err := dynamic.ToJSON(state.Body)
+			response, err := c.Read(ctx, state.ID.ValueString(), *opt)
 			if err != nil {
 				resp.Diagnostics.AddError(
-					"Update failure",
-					fmt.Sprintf("Error to marshal state body: %v", err),
+					"Existance check failed",
+					err.Error(),
 				)
 				return
 			}
-			b, err := jsonpatch.CreateMergePatch(stateBodyJSON, planBody)
-			if err != nil {
+			if response.StatusCode() == http.StatusNotFound {
 				resp.Diagnostics.AddError(
-					"Update failure",
-					fmt.Sprintf("failed to create a merge patch: %s", err.Error()),
+					"Resource doesn't exist",
+					fmt.Sprintf("The resource with the ID %q doesn't exist anymore, it might have been deleted out of band. Please refresh the state to get it recreated.", state.ID.ValueString()),
 				)
 				return
 			}
-			planBody = b
-		}
-
-		// Optionally patch the body.
-		var patches []bodyPatchData
-		if diags := plan.UpdateBodyPatches.ElementsAs(ctx, &patches, false); diags.HasError() {
-			resp.Diagnostics.Append(diags...)
-			return
-		}
-		if len(patches) != 0 {
-			stateOutput, err := dynamic.ToJSON(state.Output)
-			if err != nil {
+			if !response.IsSuccess() {
 				resp.Diagnostics.AddError(
-					"Read failure",
-					fmt.Sprintf("marshal state output: %v", err),
+					"Existance check failed",
+					fmt.Sprintf("Read API returns %d%s: %s", response.StatusCode(), client.RetrySummary(response), string(response.Body())),
 				)
 				return
 			}
-			planBodyStr := string(planBody)
-			for i, patch := range patches {
-				pv, err := exparam.ExpandBody(patch.RawJSON.ValueString(), stateOutput)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						fmt.Sprintf("Failed to expand the %d-th patch for expression params", i),
-						err.Error(),
-					)
-					return
-				}
-
-				planBodyStr, err = sjson.SetRaw(planBodyStr, patch.Path.ValueString(), pv)
-				if err != nil {
-					resp.Diagnostics.AddError(
-						fmt.Sprintf("Failed to set json for the %d-th patch for expression params", i),
-						err.Error(),
-					)
-					return
-				}
-			}
-			planBody = []byte(planBodyStr)
 		}
 
-		path := plan.ID.ValueString()
-		if !plan.UpdatePath.IsNull() {
-			output, err := dynamic.ToJSON(state.Output)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Failed to marshal json for `output`",
-					err.Error(),
-				)
+		// Precheck
+		precheckUpdate := plan.PrecheckUpdate
+		if precheckUpdate.IsNull() {
+			precheckUpdate = r.p.apiOpt.DefaultPrecheckUpdate
+		}
+		if !precheckUpdate.IsNull() {
+			unlockFunc, diags := precheck(ctx, c, r.p.apiOpt, state.ID.ValueString(), opt.Header, opt.Query, precheckUpdate, state.Output)
+			if diags.HasError() {
+				resp.Diagnostics.Append(diags...)
 				return
 			}
-			path, err = exparam.ExpandBodyOrPath(plan.UpdatePath.ValueString(), plan.Path.ValueString(), output)
-			if err != nil {
-				resp.Diagnostics.AddError(
-					"Failed to build the path for updating the resource",
-					fmt.Sprintf("Can't build path with `update_path`: %q, `path`: %q, `body`: %q", plan.UpdatePath.ValueString(), plan.Path.ValueString(), output),
-				)
+			defer unlockFunc()
+		}
+
+		planBody, diags = buildUpdateBody(ctx, *opt, plan, state, planBody)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		path, diags := buildUpdatePath(plan, state)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+
+		// The precondition only applies to this call, not to the later polls and waits sharing the same header.
+		callOpt := *opt
+		var lastModified string
+		if plan.UseLastModified.ValueBool() {
+			callOpt.Header = opt.Header.Clone()
+			lastModified, diags = setIfUnmodifiedSince(ctx, req.Private, callOpt.Header)
+			resp.Diagnostics.Append(diags...)
+			if diags.HasError() {
 				return
 			}
 		}
 
-		response, err := c.Update(ctx, path, string(planBody), *opt)
+		var response *resty.Response
+		if !plan.BodyFile.IsNull() {
+			response, err = c.OperationWithFile(ctx, path, plan.BodyFile.ValueString(), bodyFileContentType(plan.BodyFileContentType), client.OperationOption{
+				Method: callOpt.Method,
+				Query:  callOpt.Query,
+				Header: callOpt.Header,
+			})
+		} else {
+			response, err = c.Update(ctx, path, string(planBody), callOpt)
+		}
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error to call update",
@@ -1141,18 +2338,39 @@ func (r Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *
 			)
 			return
 		}
+		if response.StatusCode() == http.StatusPreconditionFailed && lastModified != "" {
+			resp.Diagnostics.AddError(
+				"Update conflict",
+				fmt.Sprintf("The resource has been modified since %s, when it was last read. Please refresh the state to pick up the remote changes, then apply again.\n\n%s", lastModified, string(response.Body())),
+			)
+			return
+		}
 		if !response.IsSuccess() {
 			resp.Diagnostics.AddError(
-				fmt.Sprintf("Update API returns %d", response.StatusCode()),
+				fmt.Sprintf("Update API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 				string(response.Body()),
 			)
 			return
 		}
+		plan.LastRequestDurationMs = types.Int64Value(response.Time().Milliseconds())
+		updateRespBody = response.Body()
+
+		if plan.UseLastModified.ValueBool() {
+			resp.Diagnostics.Append(recordLastModified(ctx, resp.Private, response.Header())...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
 
 		// For LRO, wait for completion
-		if !plan.PollUpdate.IsNull() {
+		var pollOpt *client.PollOption
+		pollUpdate := plan.PollUpdate
+		if pollUpdate.IsNull() {
+			pollUpdate = r.p.apiOpt.DefaultPollUpdate
+		}
+		if !pollUpdate.IsNull() {
 			var d pollData
-			if diags := plan.PollUpdate.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
+			if diags := pollUpdate.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
 				resp.Diagnostics.Append(diags...)
 				return
 			}
@@ -1162,7 +2380,12 @@ func (r Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *
 				resp.Diagnostics.Append(diags...)
 				return
 			}
-			p, err := client.NewPollableForPoll(*response, *opt)
+			pollOpt = opt
+		} else if plan.AutoPollOn202.ValueBool() && response.StatusCode() == http.StatusAccepted {
+			pollOpt = r.p.apiOpt.ForAutoPoll(*response, opt.Header, opt.Query)
+		}
+		if pollOpt != nil {
+			p, err := client.NewPollableForPoll(*response, *pollOpt)
 			if err != nil {
 				resp.Diagnostics.AddError(
 					"Update: Failed to build poller from the response of the initiated request",
@@ -1178,6 +2401,19 @@ func (r Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *
 				return
 			}
 		}
+
+		diags = waitForBlock(ctx, c, r.p.apiOpt, "wait_for_update", plan.WaitForUpdate, waitForOption{
+			DefaultPath: state.ID.ValueString(),
+			Header:      opt.Header,
+			Query:       opt.Query,
+			RespBody:    response.Body(),
+			RespHeader:  response.Header(),
+			Output:      state.Output,
+		})
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
 	}
 
 	diags = resp.State.Set(ctx, plan)
@@ -1186,22 +2422,147 @@ func (r Resource) Update(ctx context.Context, req resource.UpdateRequest, resp *
 		return
 	}
 
+	// The resource is updated in place, reset the changed `force_new_output_attrs` recorded during refresh.
+	diags = resp.Private.SetKey(ctx, pkForceNewOutput, nil)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
+	// Use the update response as the `output` directly, instead of reading the resource back.
+	if !plan.refreshAfterWrite() {
+		if len(bytes.TrimSpace(updateRespBody)) != 0 {
+			output, outputRaw, diags := r.buildOutput(ctx, plan, updateRespBody)
+			resp.Diagnostics.Append(diags...)
+			if diags.HasError() {
+				return
+			}
+			plan.Output = output
+			plan.OutputRaw = types.StringValue(outputRaw)
+			plan.OutputSHA256 = types.StringValue(HashJSON(outputRaw))
+			diags = resp.State.Set(ctx, plan)
+			resp.Diagnostics.Append(diags...)
+		}
+		return
+	}
+
 	rreq := resource.ReadRequest{
 		State:        resp.State,
 		ProviderMeta: req.ProviderMeta,
 	}
 	rresp := resource.ReadResponse{
 		State:       resp.State,
+		Private:     resp.Private,
 		Diagnostics: resp.Diagnostics,
 	}
 	r.read(ctx, rreq, &rresp, false)
 
 	*resp = resource.UpdateResponse{
 		State:       rresp.State,
+		Private:     resp.Private,
 		Diagnostics: rresp.Diagnostics,
 	}
 }
 
+// buildUpdateBody builds the body sent by the update call from the planned body, which is the subset of the attributes to send,
+// or the merge patch against the state body for `PATCH`, with the `update_body_patches` applied.
+func buildUpdateBody(ctx context.Context, opt client.UpdateOption, plan, state resourceData, planBody []byte) ([]byte, diag.Diagnostics) {
+	planBody, diags := sendBody(ctx, plan.SendAttrs, plan.OmitNullBodyAttrs.ValueBool(), planBody)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	if opt.Method == "PATCH" && !opt.MergePatchDisabled {
+		stateBodyJSON, err := dynamic.ToJSON(state.Body)
+		if err != nil {
+			diags.AddError(
+				"Update failure",
+				fmt.Sprintf("Error to marshal state body: %v", err),
+			)
+			return nil, diags
+		}
+		// Compare against the same subset of the state body, so that the unsent attributes are not patched to null.
+		stateBodyJSON, odiags := sendBody(ctx, plan.SendAttrs, plan.OmitNullBodyAttrs.ValueBool(), stateBodyJSON)
+		diags.Append(odiags...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		b, err := jsonpatch.CreateMergePatch(stateBodyJSON, planBody)
+		if err != nil {
+			diags.AddError(
+				"Update failure",
+				fmt.Sprintf("failed to create a merge patch: %s", err.Error()),
+			)
+			return nil, diags
+		}
+		planBody = b
+	}
+
+	// Optionally patch the body.
+	var patches []bodyPatchData
+	if odiags := plan.UpdateBodyPatches.ElementsAs(ctx, &patches, false); odiags.HasError() {
+		diags.Append(odiags...)
+		return nil, diags
+	}
+	if len(patches) != 0 {
+		stateOutput, err := dynamic.ToJSON(state.Output)
+		if err != nil {
+			diags.AddError(
+				"Read failure",
+				fmt.Sprintf("marshal state output: %v", err),
+			)
+			return nil, diags
+		}
+		planBodyStr := string(planBody)
+		for i, patch := range patches {
+			pv, err := exparam.ExpandBody(patch.RawJSON.ValueString(), stateOutput)
+			if err != nil {
+				diags.AddError(
+					fmt.Sprintf("Failed to expand the %d-th patch for expression params", i),
+					err.Error(),
+				)
+				return nil, diags
+			}
+
+			planBodyStr, err = sjson.SetRaw(planBodyStr, patch.Path.ValueString(), pv)
+			if err != nil {
+				diags.AddError(
+					fmt.Sprintf("Failed to set json for the %d-th patch for expression params", i),
+					err.Error(),
+				)
+				return nil, diags
+			}
+		}
+		planBody = []byte(planBodyStr)
+	}
+	return planBody, diags
+}
+
+// buildUpdatePath builds the path of the update call, which is the resource id, unless `update_path` is specified.
+func buildUpdatePath(plan, state resourceData) (string, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	path := state.ID.ValueString()
+	if !plan.UpdatePath.IsNull() {
+		output, err := dynamic.ToJSON(state.Output)
+		if err != nil {
+			diags.AddError(
+				"Failed to marshal json for `output`",
+				err.Error(),
+			)
+			return "", diags
+		}
+		path, err = exparam.ExpandBodyOrPath(plan.UpdatePath.ValueString(), plan.Path.ValueString(), output, nil)
+		if err != nil {
+			diags.AddError(
+				"Failed to build the path for updating the resource",
+				fmt.Sprintf("Can't build path with `update_path`: %q, `path`: %q, `body`: %q", plan.UpdatePath.ValueString(), plan.Path.ValueString(), output),
+			)
+			return "", diags
+		}
+	}
+	return path, diags
+}
+
 func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
 	c := r.p.client
 	c.SetLoggerContext(ctx)
@@ -1213,6 +2574,9 @@ func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 		return
 	}
 
+	ctx = client.WithLogLevel(ctx, state.LogLevel.ValueString())
+	c.SetLoggerContext(ctx)
+
 	tflog.Info(ctx, "Delete a resource", map[string]interface{}{"id": state.ID.ValueString()})
 
 	opt, diags := r.p.apiOpt.ForResourceDelete(ctx, state)
@@ -1222,8 +2586,12 @@ func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 	}
 
 	// Precheck
-	if !state.PrecheckDelete.IsNull() {
-		unlockFunc, diags := precheck(ctx, c, r.p.apiOpt, state.ID.ValueString(), opt.Header, opt.Query, state.PrecheckDelete, state.Output)
+	precheckDelete := state.PrecheckDelete
+	if precheckDelete.IsNull() {
+		precheckDelete = r.p.apiOpt.DefaultPrecheckDelete
+	}
+	if !precheckDelete.IsNull() {
+		unlockFunc, diags := precheck(ctx, c, r.p.apiOpt, state.ID.ValueString(), opt.Header, opt.Query, precheckDelete, state.Output)
 		if diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
@@ -1241,7 +2609,7 @@ func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 			)
 			return
 		}
-		path, err = exparam.ExpandBodyOrPath(state.DeletePath.ValueString(), state.Path.ValueString(), output)
+		path, err = exparam.ExpandBodyOrPath(state.DeletePath.ValueString(), state.Path.ValueString(), output, nil)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Failed to build the path for deleting the resource",
@@ -1263,7 +2631,19 @@ func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 		body = string(b)
 	}
 
-	response, err := c.Delete(ctx, path, body, *opt)
+	// The precondition only applies to this call, not to the later polls and waits sharing the same header.
+	callOpt := *opt
+	var lastModified string
+	if state.UseLastModified.ValueBool() {
+		callOpt.Header = opt.Header.Clone()
+		lastModified, diags = setIfUnmodifiedSince(ctx, req.Private, callOpt.Header)
+		resp.Diagnostics.Append(diags...)
+		if diags.HasError() {
+			return
+		}
+	}
+
+	response, err := c.Delete(ctx, path, body, callOpt)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error to call delete",
@@ -1272,6 +2652,14 @@ func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 		return
 	}
 
+	if response.StatusCode() == http.StatusPreconditionFailed && lastModified != "" {
+		resp.Diagnostics.AddError(
+			"Delete conflict",
+			fmt.Sprintf("The resource has been modified since %s, when it was last read. Please refresh the state to check the remote changes, then destroy again.\n\n%s", lastModified, string(response.Body())),
+		)
+		return
+	}
+
 	if strings.EqualFold(opt.Method, "DELETE") {
 		if response.StatusCode() == http.StatusNotFound {
 			return
@@ -1280,16 +2668,21 @@ func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 
 	if !response.IsSuccess() {
 		resp.Diagnostics.AddError(
-			fmt.Sprintf("Delete API returns %d", response.StatusCode()),
+			fmt.Sprintf("Delete API returns %d%s", response.StatusCode(), client.RetrySummary(response)),
 			string(response.Body()),
 		)
 		return
 	}
 
 	// For LRO, wait for completion
-	if !state.PollDelete.IsNull() {
+	var pollOpt *client.PollOption
+	pollDelete := state.PollDelete
+	if pollDelete.IsNull() {
+		pollDelete = r.p.apiOpt.DefaultPollDelete
+	}
+	if !pollDelete.IsNull() {
 		var d pollData
-		if diags := state.PollDelete.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
+		if diags := pollDelete.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
 			resp.Diagnostics.Append(diags...)
 			return
 		}
@@ -1298,7 +2691,12 @@ func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 			resp.Diagnostics.Append(diags...)
 			return
 		}
-		p, err := client.NewPollableForPoll(*response, *opt)
+		pollOpt = opt
+	} else if state.AutoPollOn202.ValueBool() && response.StatusCode() == http.StatusAccepted {
+		pollOpt = r.p.apiOpt.ForAutoPoll(*response, opt.Header, opt.Query)
+	}
+	if pollOpt != nil {
+		p, err := client.NewPollableForPoll(*response, *pollOpt)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Delete: Failed to build poller from the response of the initiated request",
@@ -1315,9 +2713,72 @@ func (r Resource) Delete(ctx context.Context, req resource.DeleteRequest, resp *
 		}
 	}
 
+	// The resource is polled with the read options, as it is expected to be gone.
+	ropt, diags := r.p.apiOpt.ForResourceRead(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+	diags = waitForBlock(ctx, c, r.p.apiOpt, "wait_for_delete", state.WaitForDelete, waitForOption{
+		DefaultPath:   state.ID.ValueString(),
+		Header:        ropt.Header,
+		Query:         ropt.Query,
+		RespBody:      response.Body(),
+		RespHeader:    response.Header(),
+		Output:        state.Output,
+		GoneAsSuccess: true,
+	})
+	resp.Diagnostics.Append(diags...)
+	if diags.HasError() {
+		return
+	}
+
 	return
 }
 
+// deleteOnCreateFailure deletes the resource whose creation fails after the `Create` call, with the delete configuration
+// of the resource, and removes it from the state once it is deleted.
+func (r Resource) deleteOnCreateFailure(ctx context.Context, resp *resource.CreateResponse) {
+	var id types.String
+	resp.State.GetAttribute(ctx, path.Root("id"), &id)
+	tflog.Info(ctx, "Delete the resource for the failed creation", map[string]interface{}{"id": id.ValueString()})
+
+	dresp := resource.DeleteResponse{State: resp.State}
+	r.Delete(ctx, resource.DeleteRequest{State: resp.State}, &dresp)
+	if dresp.Diagnostics.HasError() {
+		resp.Diagnostics.AddError(
+			"Failed to delete the resource for the failed creation",
+			fmt.Sprintf("The resource %q is kept in the state as tainted, see the other errors for details.", id.ValueString()),
+		)
+		resp.Diagnostics.Append(dresp.Diagnostics...)
+		return
+	}
+	resp.State.RemoveResource(ctx)
+	resp.Diagnostics.AddWarning(
+		"Resource deleted for the failed creation",
+		fmt.Sprintf("The resource %q is deleted as `delete_on_create_failure` is set, it will be created again by the next apply.", id.ValueString()),
+	)
+}
+
+// readUntilSuccess reads the resource until it returns a 2xx status, or the attempts are exhausted, in which case the last response is returned.
+func readUntilSuccess(ctx context.Context, c *client.Client, path string, opt client.ReadOption, attempts int, interval time.Duration) (*resty.Response, error) {
+	for i := 1; ; i++ {
+		response, err := c.Read(ctx, path, opt)
+		if err != nil {
+			return nil, err
+		}
+		if response.IsSuccess() || i >= attempts {
+			return response, nil
+		}
+		tflog.Debug(ctx, "Resource is not readable yet", map[string]interface{}{"path": path, "status": response.StatusCode(), "interval": interval.String()})
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
 type importSpec struct {
 	// Id is the resource id. Required.
 	Id string `json:"id"`
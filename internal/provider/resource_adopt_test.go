@@ -0,0 +1,166 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// adoptServer is an API that creates the resources via PUT, which records the number of the create calls.
+type adoptServer struct {
+	mu      sync.Mutex
+	creates int
+	items   map[string]map[string]any
+}
+
+func (s *adoptServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	item, ok := s.items[r.URL.Path]
+	switch r.Method {
+	case http.MethodGet:
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodPut:
+		var update map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if !ok {
+			s.creates++
+		}
+		s.items[r.URL.Path] = update
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(update)
+	case http.MethodDelete:
+		delete(s.items, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestResource_AdoptExisting(t *testing.T) {
+	addr := "restful_resource.test"
+	srv := &adoptServer{
+		items: map[string]map[string]any{
+			"/items/existing": {"name": "existing"},
+		},
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	checkCreates := func(expect int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if srv.creates != expect {
+				return fmt.Errorf("expect %d create calls, got %d", expect, srv.creates)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: adoptConfig(ts.URL, "/items/existing", "existing"),
+				Check:  checkCreates(0),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("id"), knownvalue.StringExact("/items/existing")),
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output").AtMapKey("name"), knownvalue.StringExact("existing")),
+				},
+			},
+			{
+				Config: adoptConfig(ts.URL, "/items/new", "new"),
+				Check:  checkCreates(1),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("id"), knownvalue.StringExact("/items/new")),
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output").AtMapKey("name"), knownvalue.StringExact("new")),
+				},
+			},
+		},
+	})
+}
+
+func adoptConfig(url, path, name string) string {
+	return fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path            = %q
+  create_method   = "PUT"
+  check_existance = true
+  adopt_existing  = true
+  body = {
+    name = %q
+  }
+}
+`, url, path, name)
+}
+
+func TestResource_AdoptExistingReadPath(t *testing.T) {
+	addr := "restful_resource.test"
+	srv := &adoptServer{
+		items: map[string]map[string]any{
+			"/items/existing": {"data": map[string]any{"id": "abc"}},
+			"/items/abc":      {"name": "existing"},
+		},
+	}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path            = "/items/existing"
+  create_method   = "PUT"
+  create_selector = "data"
+  read_path       = "/items/$(body.id)"
+  check_existance = true
+  adopt_existing  = true
+  body = {
+    name = "existing"
+  }
+}
+`, ts.URL),
+				Check: func(*terraform.State) error {
+					srv.mu.Lock()
+					defer srv.mu.Unlock()
+					if srv.creates != 0 {
+						return fmt.Errorf("expect no create call, got %d", srv.creates)
+					}
+					return nil
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("id"), knownvalue.StringExact("/items/abc")),
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output").AtMapKey("name"), knownvalue.StringExact("existing")),
+				},
+			},
+		},
+	})
+}
@@ -77,7 +77,7 @@ func TestResource_Azure_ResourceGroup(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_delete", "create_method"},
+				ImportStateVerifyIgnore: []string{"poll_delete", "create_method", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.resourceGroupImportStateIdFunc(addr),
 			},
 			{
@@ -90,7 +90,7 @@ func TestResource_Azure_ResourceGroup(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_delete", "create_method"},
+				ImportStateVerifyIgnore: []string{"poll_delete", "create_method", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.resourceGroupCompleteImportStateIdFunc(addr),
 			},
 		},
@@ -115,7 +115,7 @@ func TestResource_Azure_ResourceGroup_updatePath(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_delete", "create_method", "update_path"},
+				ImportStateVerifyIgnore: []string{"poll_delete", "create_method", "update_path", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.resourceGroupUpdatePathImportStateIdFunc(addr),
 			},
 			{
@@ -128,7 +128,7 @@ func TestResource_Azure_ResourceGroup_updatePath(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_delete", "create_method", "update_path"},
+				ImportStateVerifyIgnore: []string{"poll_delete", "create_method", "update_path", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.resourceGroupUpdatePathCompleteImportStateIdFunc(addr),
 			},
 		},
@@ -153,7 +153,7 @@ func TestResource_Azure_VirtualNetwork(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "create_method"},
+				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "create_method", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.vnetImportStateIdFunc(addr),
 			},
 			{
@@ -166,7 +166,7 @@ func TestResource_Azure_VirtualNetwork(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "create_method"},
+				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "create_method", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.vnetImportStateIdFunc(addr),
 			},
 		},
@@ -191,7 +191,7 @@ func TestResource_Azure_VirtualNetwork_Precheck(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "precheck_create", "precheck_update", "precheck_delete", "create_method"},
+				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "precheck_create", "precheck_update", "precheck_delete", "create_method", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.vnetImportStateIdFunc(addr),
 			},
 			{
@@ -204,7 +204,7 @@ func TestResource_Azure_VirtualNetwork_Precheck(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "precheck_create", "precheck_update", "precheck_delete", "create_method"},
+				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "precheck_create", "precheck_update", "precheck_delete", "create_method", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.vnetImportStateIdFunc(addr),
 			},
 		},
@@ -229,7 +229,7 @@ func TestResource_Azure_VirtualNetwork_SimplePoll(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "create_method"},
+				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "create_method", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.vnetImportStateIdFunc(addr),
 			},
 			{
@@ -242,7 +242,7 @@ func TestResource_Azure_VirtualNetwork_SimplePoll(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "create_method"},
+				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "create_method", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.vnetImportStateIdFunc(addr),
 			},
 		},
@@ -267,7 +267,7 @@ func TestResource_Azure_RouteTable_Precheck(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "precheck_create", "precheck_update", "precheck_delete", "create_method", "output.etag"},
+				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "precheck_create", "precheck_update", "precheck_delete", "create_method", "output.etag", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.routeImportStateIdFunc(addr),
 			},
 			{
@@ -280,7 +280,7 @@ func TestResource_Azure_RouteTable_Precheck(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "precheck_create", "precheck_update", "precheck_delete", "create_method", "output.etag"},
+				ImportStateVerifyIgnore: []string{"poll_create", "poll_update", "poll_delete", "precheck_create", "precheck_update", "precheck_delete", "create_method", "output.etag", "last_request_duration_ms"},
 				ImportStateIdFunc:       d.routeImportStateIdFunc(addr),
 			},
 		},
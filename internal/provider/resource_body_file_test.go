@@ -0,0 +1,99 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+	"github.com/stretchr/testify/require"
+)
+
+// blobServer is a blob store, which stores the raw body of the PUT request, and returns its metadata on GET.
+type blobServer struct {
+	mu    sync.Mutex
+	blobs map[string]map[string]any
+}
+
+func (s *blobServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		blob, ok := s.blobs[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(blob)
+	case http.MethodPut:
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.blobs[r.URL.Path] = map[string]any{
+			"content_type": r.Header.Get("Content-Type"),
+			"content":      string(b),
+		}
+		w.WriteHeader(http.StatusCreated)
+	case http.MethodDelete:
+		delete(s.blobs, r.URL.Path)
+	}
+}
+
+func TestResource_BodyFile(t *testing.T) {
+	ts := httptest.NewServer(&blobServer{blobs: map[string]map[string]any{}})
+	defer ts.Close()
+
+	dir := t.TempDir()
+	fileFoo, fileBar := filepath.Join(dir, "foo"), filepath.Join(dir, "bar")
+	require.NoError(t, os.WriteFile(fileFoo, []byte("foo content"), 0644))
+	require.NoError(t, os.WriteFile(fileBar, []byte("bar content"), 0644))
+
+	config := func(file string) string {
+		return fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path                   = "/blobs/1"
+  create_method          = "PUT"
+  update_method          = "PUT"
+  body_file              = %q
+  body_file_content_type = "text/plain"
+  body = {
+    content_type = "text/plain"
+  }
+}
+`, ts.URL, file)
+	}
+
+	addr := "restful_resource.test"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: config(fileFoo),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(addr, "output.content", "foo content"),
+					resource.TestCheckResourceAttr(addr, "output.content_type", "text/plain"),
+				),
+			},
+			{
+				Config: config(fileBar),
+				Check:  resource.TestCheckResourceAttr(addr, "output.content", "bar content"),
+			},
+		},
+	})
+}
@@ -66,7 +66,7 @@ func TestResource_CodeServer_ObjectArray(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"create_method"},
+				ImportStateVerifyIgnore: []string{"create_method", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return `{"id": "test", "path": "test", "body": [{"foo": null}]}`, nil
 				},
@@ -81,7 +81,7 @@ func TestResource_CodeServer_ObjectArray(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"create_method"},
+				ImportStateVerifyIgnore: []string{"create_method", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return `{"id": "test", "path": "test", "body": [{"foo": null}]}`, nil
 				},
@@ -136,7 +136,7 @@ func TestResource_CodeServer_CreateRetString(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"create_method", "read_path"},
+				ImportStateVerifyIgnore: []string{"create_method", "read_path", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return fmt.Sprintf(`{"path": "test", "id": "test/%s", "body": {}}`, id), nil
 				},
@@ -289,7 +289,7 @@ func TestResource_CodeServer_ReadResponseTemplate(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"create_method"},
+				ImportStateVerifyIgnore: []string{"create_method", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return `{"id": "/tests/1", "path": "/tests/1", "body": [{"properties": [{"property_name": null, "value": null}]}], "read_response_template": "{\"properties\": $(body)}"}`, nil
 				},
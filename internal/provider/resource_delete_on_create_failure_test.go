@@ -0,0 +1,107 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// failedProvisionServer is an API that creates the resources via PUT, whose provisioning always fails as reported
+// at a separate endpoint.
+type failedProvisionServer struct {
+	mu      sync.Mutex
+	deletes int
+	items   map[string]map[string]any
+}
+
+func (s *failedProvisionServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := strings.CutSuffix(r.URL.Path, "/ready"); ok {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{"state": "Failed"})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, ok := s.items[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodPut:
+		var item map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.items[r.URL.Path] = item
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodDelete:
+		s.deletes++
+		delete(s.items, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestResource_DeleteOnCreateFailure(t *testing.T) {
+	srv := &failedProvisionServer{items: map[string]map[string]any{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		CheckDestroy: func(*terraform.State) error {
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if len(srv.items) != 0 {
+				return fmt.Errorf("expect the half-created resource to be deleted, got %v", srv.items)
+			}
+			if srv.deletes != 1 {
+				return fmt.Errorf("expect 1 delete call, got %d", srv.deletes)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path                     = "/items/1"
+  create_method            = "PUT"
+  delete_on_create_failure = true
+  body = {
+    name = "foo"
+  }
+  wait_for_create = {
+    path           = "$(path)/ready"
+    status_locator = "body.state"
+    status = {
+      success = "Ready"
+      failure = ["Failed"]
+    }
+  }
+}
+`, ts.URL),
+				ExpectError: regexp.MustCompile("polling aborted"),
+			},
+		},
+	})
+}
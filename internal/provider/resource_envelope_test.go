@@ -0,0 +1,118 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// envelopeServer is an API that wraps the resource inside an envelope for both create and read, e.g. {"data": {...}, "meta": {...}}.
+type envelopeServer struct {
+	mu    sync.Mutex
+	seq   int
+	items map[string]map[string]any
+}
+
+func (s *envelopeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	write := func(item map[string]any) {
+		s.seq++
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]any{
+			"data": item,
+			"meta": map[string]any{"request_id": s.seq},
+		})
+	}
+
+	if r.URL.Path == "/items" && r.Method == http.MethodPost {
+		var item map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		id := strconv.Itoa(len(s.items) + 1)
+		item["id"] = id
+		s.items[id] = item
+		write(item)
+		return
+	}
+
+	id := r.URL.Path[len("/items/"):]
+	item, ok := s.items[id]
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		write(item)
+	case http.MethodPut:
+		var update map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		update["id"] = id
+		s.items[id] = update
+		write(update)
+	case http.MethodDelete:
+		delete(s.items, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestResource_Envelope(t *testing.T) {
+	addr := "restful_resource.test"
+	srv := httptest.NewServer(&envelopeServer{items: map[string]map[string]any{}})
+	defer srv.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: envelopeConfig(srv.URL, "foo"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("id"), knownvalue.StringExact("/items/1")),
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output").AtMapKey("name"), knownvalue.StringExact("foo")),
+				},
+			},
+			{
+				Config: envelopeConfig(srv.URL, "bar"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("id"), knownvalue.StringExact("/items/1")),
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output").AtMapKey("name"), knownvalue.StringExact("bar")),
+				},
+			},
+		},
+	})
+}
+
+func envelopeConfig(url, name string) string {
+	return fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path            = "/items"
+  read_path       = "$(path)/$(body.id)"
+  create_selector = "data"
+  read_selector   = "data"
+  body = {
+    name = %q
+  }
+}
+`, url, name)
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/terraform-provider-restful/internal/client"
+	"github.com/magodo/terraform-provider-restful/internal/dynamic"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadUntilSuccess(t *testing.T) {
+	cases := []struct {
+		name       string
+		notFounds  int
+		attempts   int
+		expectCode int
+		expectRead int
+	}{
+		{
+			name:       "readable at once",
+			notFounds:  0,
+			attempts:   3,
+			expectCode: http.StatusOK,
+			expectRead: 1,
+		},
+		{
+			name:       "readable after retries",
+			notFounds:  2,
+			attempts:   3,
+			expectCode: http.StatusOK,
+			expectRead: 3,
+		},
+		{
+			name:       "attempts exhausted",
+			notFounds:  3,
+			attempts:   2,
+			expectCode: http.StatusNotFound,
+			expectRead: 2,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var reads int
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reads++
+				if reads <= tt.notFounds {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Write([]byte(`{}`))
+			}))
+			defer srv.Close()
+
+			c, err := client.New(context.Background(), srv.URL, &client.BuildOption{})
+			require.NoError(t, err)
+			resp, err := readUntilSuccess(context.Background(), c, "/foo", client.ReadOption{}, tt.attempts, time.Millisecond)
+			require.NoError(t, err)
+			require.Equal(t, tt.expectCode, resp.StatusCode())
+			require.Equal(t, tt.expectRead, reads)
+		})
+	}
+}
+
+func TestBuildIdempotencyKey(t *testing.T) {
+	generated := idempotencyKeyData{Header: types.StringNull(), Value: types.StringNull()}
+
+	header, key := buildIdempotencyKey(generated)
+	require.Equal(t, "Idempotency-Key", header)
+	_, err := uuid.Parse(key)
+	require.NoError(t, err)
+
+	// Each create gets a new key.
+	_, key2 := buildIdempotencyKey(generated)
+	require.NotEqual(t, key, key2)
+
+	header, key = buildIdempotencyKey(idempotencyKeyData{
+		Header: types.StringValue("X-Request-Id"),
+		Value:  types.StringValue("my-key"),
+	})
+	require.Equal(t, "X-Request-Id", header)
+	require.Equal(t, "my-key", key)
+}
+
+// fakePrivateState is an in-memory private state.
+type fakePrivateState map[string][]byte
+
+func (s fakePrivateState) GetKey(_ context.Context, key string) ([]byte, diag.Diagnostics) {
+	return s[key], nil
+}
+
+func (s fakePrivateState) SetKey(_ context.Context, key string, value []byte) diag.Diagnostics {
+	if len(value) == 0 {
+		delete(s, key)
+		return nil
+	}
+	s[key] = value
+	return nil
+}
+
+func TestLastModified(t *testing.T) {
+	ctx := context.Background()
+	lastModified := "Wed, 21 Oct 2015 07:28:00 GMT"
+
+	cases := []struct {
+		name         string
+		respHeader   http.Header
+		header       client.Header
+		expectValue  string
+		expectHeader client.Header
+	}{
+		{
+			name:         "recorded",
+			respHeader:   http.Header{"Last-Modified": []string{lastModified}},
+			header:       client.Header{"foo": "bar"},
+			expectValue:  lastModified,
+			expectHeader: client.Header{"foo": "bar", "If-Unmodified-Since": lastModified},
+		},
+		{
+			name:         "absent",
+			respHeader:   http.Header{},
+			header:       client.Header{},
+			expectValue:  "",
+			expectHeader: client.Header{},
+		},
+		{
+			name:         "set explicitly",
+			respHeader:   http.Header{"Last-Modified": []string{lastModified}},
+			header:       client.Header{"if-unmodified-since": "foo"},
+			expectValue:  "",
+			expectHeader: client.Header{"if-unmodified-since": "foo"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			// The out of date value is removed if the response has no Last-Modified.
+			private := fakePrivateState{pkLastModified: []byte(`"Thu, 01 Jan 2015 00:00:00 GMT"`)}
+			require.False(t, recordLastModified(ctx, private, tt.respHeader).HasError())
+			v, diags := setIfUnmodifiedSince(ctx, private, tt.header)
+			require.False(t, diags.HasError())
+			require.Equal(t, tt.expectValue, v)
+			require.Equal(t, tt.expectHeader, tt.header)
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	cases := []struct {
+		name         string
+		updateMethod string
+		stateBody    string
+		planBody     string
+		expectMethod string
+		expectBody   string
+	}{
+		{
+			name:         "create",
+			planBody:     `{"a": 1, "b": 2}`,
+			expectMethod: "POST",
+			expectBody:   `{"a": 1, "b": 2}`,
+		},
+		{
+			name:         "update with put",
+			updateMethod: "PUT",
+			stateBody:    `{"a": 1, "b": 2}`,
+			planBody:     `{"a": 1, "b": 3}`,
+			expectMethod: "PUT",
+			expectBody:   `{"a": 1, "b": 3}`,
+		},
+		{
+			name:         "update with merge patch",
+			updateMethod: "PATCH",
+			stateBody:    `{"a": 1, "b": 2}`,
+			planBody:     `{"a": 1, "b": 3}`,
+			expectMethod: "PATCH",
+			expectBody:   `{"b": 3}`,
+		},
+		{
+			name:         "no change",
+			updateMethod: "PUT",
+			stateBody:    `{"a": 1}`,
+			planBody:     `{"a": 1}`,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var method, body string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("dryRun") != "All" {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				b, _ := io.ReadAll(r.Body)
+				method, body = r.Method, string(b)
+			}))
+			defer srv.Close()
+
+			c, err := client.New(context.Background(), srv.URL, &client.BuildOption{})
+			require.NoError(t, err)
+			p := &Provider{client: c, apiOpt: apiOption{CreateMethod: "POST", UpdateMethod: tt.updateMethod, Query: client.Query{}, Header: client.Header{}}}
+			r := &Resource{p: p}
+
+			dryRun := types.ObjectValueMust(
+				map[string]attr.Type{
+					"query":  types.MapType{ElemType: types.ListType{ElemType: types.StringType}},
+					"header": types.MapType{ElemType: types.StringType},
+				},
+				map[string]attr.Value{
+					"query": types.MapValueMust(types.ListType{ElemType: types.StringType}, map[string]attr.Value{
+						"dryRun": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("All")}),
+					}),
+					"header": types.MapNull(types.StringType),
+				},
+			)
+			planBody, err := dynamic.FromJSONImplied([]byte(tt.planBody))
+			require.NoError(t, err)
+			plan := resourceData{
+				Path:   types.StringValue("/items"),
+				Body:   planBody,
+				DryRun: dryRun,
+				UpdateBodyPatches: types.ListNull(types.ObjectType{AttrTypes: map[string]attr.Type{
+					"path":     types.StringType,
+					"raw_json": types.StringType,
+				}}),
+			}
+
+			var state *resourceData
+			if tt.stateBody != "" {
+				stateBody, err := dynamic.FromJSONImplied([]byte(tt.stateBody))
+				require.NoError(t, err)
+				state = &resourceData{ID: types.StringValue("/items/1"), Path: types.StringValue("/items"), Body: stateBody}
+			}
+
+			diags := r.dryRun(context.Background(), plan, state)
+			require.False(t, diags.HasError(), diags)
+			require.Equal(t, tt.expectMethod, method)
+			if tt.expectBody == "" {
+				require.Empty(t, body)
+			} else {
+				require.JSONEq(t, tt.expectBody, body)
+			}
+		})
+	}
+}
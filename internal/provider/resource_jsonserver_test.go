@@ -56,13 +56,14 @@ func TestResource_JSONServer_Basic(t *testing.T) {
 				Config: d.basic("foo"),
 				ConfigStateChecks: []statecheck.StateCheck{
 					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output").AtMapKey("id"), knownvalue.NotNull()),
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("last_request_duration_ms"), knownvalue.NotNull()),
 				},
 			},
 			{
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"read_path"},
+				ImportStateVerifyIgnore: []string{"read_path", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return fmt.Sprintf(`{"id": %q, "path": "posts", "body": {"foo": null}}`, s.RootModule().Resources[addr].Primary.Attributes["id"]), nil
 				},
@@ -77,7 +78,7 @@ func TestResource_JSONServer_Basic(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"read_path"},
+				ImportStateVerifyIgnore: []string{"read_path", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return fmt.Sprintf(`{"id": %q, "path": "posts", "body": {"foo": null}}`, s.RootModule().Resources[addr].Primary.Attributes["id"]), nil
 				},
@@ -104,7 +105,7 @@ func TestResource_JSONServer_PatchUpdate(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"read_path", "update_method"},
+				ImportStateVerifyIgnore: []string{"read_path", "update_method", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return fmt.Sprintf(`{"id": %q, "path": "posts", "update_method": "PATCH", "body": {"foo": null}}`, s.RootModule().Resources[addr].Primary.Attributes["id"]), nil
 				},
@@ -119,7 +120,7 @@ func TestResource_JSONServer_PatchUpdate(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"read_path", "update_method"},
+				ImportStateVerifyIgnore: []string{"read_path", "update_method", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return fmt.Sprintf(`{"id": %q, "path": "posts", "update_method": "PATCH", "body": {"foo": null}}`, s.RootModule().Resources[addr].Primary.Attributes["id"]), nil
 				},
@@ -146,7 +147,7 @@ func TestResource_JSONServer_FullPath(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"read_path", "update_path", "delete_path"},
+				ImportStateVerifyIgnore: []string{"read_path", "update_path", "delete_path", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return fmt.Sprintf(`{"id": %q, "path": "posts", "body": {"foo": null}}`, s.RootModule().Resources[addr].Primary.Attributes["id"]), nil
 				},
@@ -161,7 +162,7 @@ func TestResource_JSONServer_FullPath(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"read_path", "update_path", "delete_path"},
+				ImportStateVerifyIgnore: []string{"read_path", "update_path", "delete_path", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					return fmt.Sprintf(`{"id": %q, "path": "posts", "body": {"foo": null}}`, s.RootModule().Resources[addr].Primary.Attributes["id"]), nil
 				},
@@ -203,7 +204,7 @@ func TestResource_JSONServer_ReadSelectorParam(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"read_path", "update_path", "delete_path", "read_selector"},
+				ImportStateVerifyIgnore: []string{"read_path", "update_path", "delete_path", "read_selector", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					attrs := s.RootModule().Resources[addr].Primary.Attributes
 					return fmt.Sprintf(`{"id": "%s", "path": "posts", "body": {"foo": null}, "read_selector": "#(id == %s)"}`, attrs["id"], attrs["output.id"]), nil
@@ -216,7 +217,7 @@ func TestResource_JSONServer_ReadSelectorParam(t *testing.T) {
 				ResourceName:            addr,
 				ImportState:             true,
 				ImportStateVerify:       true,
-				ImportStateVerifyIgnore: []string{"read_path", "update_path", "delete_path", "read_selector"},
+				ImportStateVerifyIgnore: []string{"read_path", "update_path", "delete_path", "read_selector", "last_request_duration_ms"},
 				ImportStateIdFunc: func(s *terraform.State) (string, error) {
 					attrs := s.RootModule().Resources[addr].Primary.Attributes
 					return fmt.Sprintf(`{"id": "%s", "path": "posts", "body": {"foo": null}, "read_selector": "#(id == %s)"}`, attrs["id"], attrs["output.id"]), nil
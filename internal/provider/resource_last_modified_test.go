@@ -0,0 +1,127 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// lastModifiedServer is an API that requires the `If-Unmodified-Since` for the update and delete requests, which
+// must match the `Last-Modified` of the item. The read requests with a precondition are rejected, as the precondition
+// is only meant for the write.
+type lastModifiedServer struct {
+	mu       sync.Mutex
+	items    map[string]map[string]any
+	modified map[string]time.Time
+}
+
+func (s *lastModifiedServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if r.Method == http.MethodGet && r.Header.Get("If-Unmodified-Since") != "" {
+		w.WriteHeader(http.StatusPreconditionFailed)
+		return
+	}
+
+	item, ok := s.items[r.URL.Path]
+	if ok && r.Method != http.MethodGet {
+		since, err := http.ParseTime(r.Header.Get("If-Unmodified-Since"))
+		if err != nil {
+			w.WriteHeader(http.StatusPreconditionRequired)
+			return
+		}
+		if s.modified[r.URL.Path].After(since) {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Last-Modified", s.modified[r.URL.Path].Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodPut:
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.items[r.URL.Path] = body
+		// Make sure the modification time changes, as the HTTP date has the precision of seconds.
+		if !ok {
+			s.modified[r.URL.Path] = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		} else {
+			s.modified[r.URL.Path] = s.modified[r.URL.Path].Add(time.Minute)
+		}
+		w.Header().Set("Last-Modified", s.modified[r.URL.Path].Format(http.TimeFormat))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	case http.MethodDelete:
+		delete(s.items, r.URL.Path)
+		delete(s.modified, r.URL.Path)
+	}
+}
+
+func TestResource_UseLastModified(t *testing.T) {
+	srv := &lastModifiedServer{items: map[string]map[string]any{}, modified: map[string]time.Time{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	config := func(name string) string {
+		return fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path              = "/items/1"
+  create_method     = "PUT"
+  use_last_modified = true
+  body = {
+    name = %q
+  }
+  wait_for_update = {
+    status_locator = "code"
+    status = {
+      success = "200"
+    }
+  }
+  wait_for_delete = {
+    status_locator = "code"
+    status = {
+      success = "404"
+      pending = ["2xx"]
+    }
+  }
+}
+`, ts.URL, name)
+	}
+
+	addr := "restful_resource.test"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: config("foo"),
+				Check:  resource.TestCheckResourceAttr(addr, "output.name", "foo"),
+			},
+			{
+				Config: config("bar"),
+				Check:  resource.TestCheckResourceAttr(addr, "output.name", "bar"),
+			},
+		},
+	})
+}
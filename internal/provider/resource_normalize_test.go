@@ -0,0 +1,99 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// normalizingServer is an API that normalizes the inputs, i.e. lowercases the `host` and masks the `cidr`.
+type normalizingServer struct {
+	mu    sync.Mutex
+	items map[string]map[string]any
+}
+
+func (s *normalizingServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		item, ok := s.items[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodPut:
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if host, ok := body["host"].(string); ok {
+			body["host"] = strings.ToLower(host)
+		}
+		if cidr, ok := body["cidr"].(string); ok {
+			if prefix, err := netip.ParsePrefix(cidr); err == nil {
+				body["cidr"] = prefix.Masked().String()
+			}
+		}
+		s.items[r.URL.Path] = body
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(body)
+	case http.MethodDelete:
+		delete(s.items, r.URL.Path)
+	}
+}
+
+func TestResource_Normalize(t *testing.T) {
+	ts := httptest.NewServer(&normalizingServer{items: map[string]map[string]any{}})
+	defer ts.Close()
+
+	config := fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path          = "/items/1"
+  create_method = "PUT"
+  body = {
+    host = "Example.COM"
+    cidr = "10.0.0.1/24"
+  }
+  normalize = {
+    host = "lowercase"
+    cidr = "cidr"
+  }
+}
+`, ts.URL)
+
+	addr := "restful_resource.test"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(addr, "output.host", "example.com"),
+					resource.TestCheckResourceAttr(addr, "output.cidr", "10.0.0.0/24"),
+				),
+			},
+			{
+				// The refresh keeps the configured values, so there is no diff.
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,131 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// stringBoolServer is an API that returns the boolean attributes as strings, e.g. "yes" or "0".
+type stringBoolServer struct {
+	mu    sync.Mutex
+	items map[string]map[string]any
+}
+
+func (s *stringBoolServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		item, ok := s.items[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodPut:
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		item := map[string]any{}
+		for k, v := range body {
+			if b, ok := v.(bool); ok {
+				v = map[bool]string{true: "yes", false: "0"}[b]
+			}
+			item[k] = v
+		}
+		s.items[r.URL.Path] = item
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodDelete:
+		delete(s.items, r.URL.Path)
+	}
+}
+
+func TestResource_OutputTypeHintsStringBool(t *testing.T) {
+	ts := httptest.NewServer(&stringBoolServer{items: map[string]map[string]any{}})
+	defer ts.Close()
+
+	addr := "restful_resource.test"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path          = "/items/1"
+  create_method = "PUT"
+  body = {
+    enabled = true
+    locked  = false
+  }
+  output_type_hints = {
+    enabled = "bool"
+    locked  = "bool"
+  }
+}
+`, ts.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(addr, "output.enabled", "true"),
+					resource.TestCheckResourceAttr(addr, "output.locked", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestResource_OutputTypeHintsForceNewOutputAttrs(t *testing.T) {
+	ts := httptest.NewServer(&stringBoolServer{items: map[string]map[string]any{}})
+	defer ts.Close()
+
+	config := fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path          = "/items/1"
+  create_method = "PUT"
+  body = {
+    enabled = true
+  }
+  output_type_hints = {
+    enabled = "bool"
+  }
+  force_new_output_attrs = ["enabled"]
+}
+`, ts.URL)
+
+	addr := "restful_resource.test"
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr(addr, "output.enabled", "true"),
+			},
+			{
+				RefreshState: true,
+			},
+			{
+				// The coerced output is compared, so the refreshes don't record the attribute as changed.
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -0,0 +1,105 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/statecheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// readCountServer is an API that creates the resources via PUT, which records the number of the read calls.
+type readCountServer struct {
+	mu    sync.Mutex
+	reads int
+	items map[string]map[string]any
+}
+
+func (s *readCountServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		s.reads++
+		item, ok := s.items[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodPut:
+		var item map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.items[r.URL.Path] = item
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodDelete:
+		delete(s.items, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestResource_RefreshAfterWriteDisabled(t *testing.T) {
+	addr := "restful_resource.test"
+	srv := &readCountServer{items: map[string]map[string]any{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: refreshAfterWriteConfig(ts.URL, "foo"),
+				// No read is issued during the creation.
+				Check: func(*terraform.State) error {
+					srv.mu.Lock()
+					defer srv.mu.Unlock()
+					if srv.reads != 0 {
+						return fmt.Errorf("expect no read calls, got %d", srv.reads)
+					}
+					return nil
+				},
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output").AtMapKey("name"), knownvalue.StringExact("foo")),
+				},
+			},
+			{
+				Config: refreshAfterWriteConfig(ts.URL, "bar"),
+				ConfigStateChecks: []statecheck.StateCheck{
+					statecheck.ExpectKnownValue(addr, tfjsonpath.New("output").AtMapKey("name"), knownvalue.StringExact("bar")),
+				},
+			},
+		},
+	})
+}
+
+func refreshAfterWriteConfig(url, name string) string {
+	return fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path                = "/items/1"
+  create_method       = "PUT"
+  update_method       = "PUT"
+  refresh_after_write = false
+  body = {
+    name = %q
+  }
+}
+`, url, name)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/magodo/terraform-provider-restful/internal/dynamic"
 	"github.com/magodo/terraform-provider-restful/internal/provider/migrate"
 )
@@ -93,6 +96,16 @@ func (r *Resource) UpgradeState(context.Context) map[int64]resource.StateUpgrade
 					return
 				}
 
+				pollCreate, diags := upgradePollObject(ctx, pd.PollCreate)
+				resp.Diagnostics.Append(diags...)
+				pollUpdate, diags := upgradePollObject(ctx, pd.PollUpdate)
+				resp.Diagnostics.Append(diags...)
+				pollDelete, diags := upgradePollObject(ctx, pd.PollDelete)
+				resp.Diagnostics.Append(diags...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
 				upgradedStateData := resourceData{
 					ID:                  pd.ID,
 					Path:                pd.Path,
@@ -108,9 +121,9 @@ func (r *Resource) UpgradeState(context.Context) map[int64]resource.StateUpgrade
 					PrecheckUpdate:      pd.PrecheckUpdate,
 					PrecheckDelete:      pd.PrecheckDelete,
 					Body:                pd.Body,
-					PollCreate:          pd.PollCreate,
-					PollUpdate:          pd.PollUpdate,
-					PollDelete:          pd.PollDelete,
+					PollCreate:          pollCreate,
+					PollUpdate:          pollUpdate,
+					PollDelete:          pollDelete,
 					WriteOnlyAttributes: pd.WriteOnlyAttributes,
 					MergePatchDisabled:  pd.MergePatchDisabled,
 					Query:               pd.Query,
@@ -126,3 +139,31 @@ func (r *Resource) UpgradeState(context.Context) map[int64]resource.StateUpgrade
 		},
 	}
 }
+
+// upgradePollObject converts the poll object of the prior schema to the current schema, with the newly introduced attributes set as null.
+func upgradePollObject(ctx context.Context, obj types.Object) (types.Object, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	attrTypes := pollAttribute("").GetType().(types.ObjectType).AttrTypes
+	if obj.IsNull() {
+		return types.ObjectNull(attrTypes), nil
+	}
+
+	attrs := map[string]attr.Value{}
+	for k, t := range attrTypes {
+		if v, ok := obj.Attributes()[k]; ok {
+			attrs[k] = v
+			continue
+		}
+		v, err := t.ValueFromTerraform(ctx, tftypes.NewValue(t.TerraformType(ctx), nil))
+		if err != nil {
+			diags.AddError(
+				"Upgrade State Error",
+				fmt.Sprintf(`Building null value for the poll attribute %q: %v`, k, err),
+			)
+			return obj, diags
+		}
+		attrs[k] = v
+	}
+	return types.ObjectValue(attrTypes, attrs)
+}
@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradePollObject(t *testing.T) {
+	ctx := context.Background()
+	attrTypes := pollAttribute("").GetType().(types.ObjectType).AttrTypes
+
+	priorAttrTypes := map[string]attr.Type{}
+	for k, t := range attrTypes {
+		if k == "retry_status_codes" || k == "conditions" || k == "abort_if" {
+			continue
+		}
+		priorAttrTypes[k] = t
+	}
+
+	// null
+	obj, diags := upgradePollObject(ctx, types.ObjectNull(priorAttrTypes))
+	require.False(t, diags.HasError())
+	require.True(t, obj.IsNull())
+	require.Equal(t, attrTypes, obj.AttributeTypes(ctx))
+
+	// non-null
+	prior := types.ObjectValueMust(priorAttrTypes, map[string]attr.Value{
+		"status_locator": types.StringValue("code"),
+		"status": types.ObjectValueMust(
+			priorAttrTypes["status"].(types.ObjectType).AttrTypes,
+			map[string]attr.Value{
+				"success": types.StringValue("200"),
+				"pending": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("202")}),
+			},
+		),
+		"url_locator":       types.StringNull(),
+		"header":            types.MapNull(types.StringType),
+		"default_delay_sec": types.Int64Value(10),
+	})
+	obj, diags = upgradePollObject(ctx, prior)
+	require.False(t, diags.HasError())
+	require.Equal(t, attrTypes, obj.AttributeTypes(ctx))
+	require.Equal(t, types.ListNull(types.Int64Type), obj.Attributes()["retry_status_codes"])
+	require.True(t, obj.Attributes()["conditions"].IsNull())
+	require.True(t, obj.Attributes()["abort_if"].IsNull())
+	require.Equal(t, types.StringValue("code"), obj.Attributes()["status_locator"])
+}
@@ -0,0 +1,111 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// readinessServer is an API that creates the resources via PUT, whose readiness are reported at a separate endpoint,
+// which becomes ready after a number of polls.
+type readinessServer struct {
+	mu         sync.Mutex
+	pendings   int
+	readyPolls int
+	items      map[string]map[string]any
+}
+
+func (s *readinessServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if path, ok := strings.CutSuffix(r.URL.Path, "/ready"); ok {
+		if _, ok := s.items[path]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		s.readyPolls++
+		state := "Ready"
+		if s.readyPolls <= s.pendings {
+			state = "Pending"
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Retry-After", "0")
+		json.NewEncoder(w).Encode(map[string]any{"state": state})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		item, ok := s.items[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodPut:
+		var item map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.items[r.URL.Path] = item
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodDelete:
+		delete(s.items, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestResource_WaitForCreatePath(t *testing.T) {
+	srv := &readinessServer{pendings: 2, items: map[string]map[string]any{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path          = "/items/1"
+  create_method = "PUT"
+  body = {
+    name = "foo"
+  }
+  wait_for_create = {
+    path           = "$(path)/ready"
+    status_locator = "body.state"
+    status = {
+      success = "Ready"
+      pending = ["Pending"]
+    }
+  }
+}
+`, ts.URL),
+				Check: func(*terraform.State) error {
+					srv.mu.Lock()
+					defer srv.mu.Unlock()
+					if srv.readyPolls != 3 {
+						return fmt.Errorf("expect 3 readiness polls, got %d", srv.readyPolls)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -0,0 +1,150 @@
+package provider_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+// tombstoneServer is an API that tombstones the resources on deletion, which are still readable with the state
+// being "Deleting" for a number of reads, and "Deleted" afterwards. If gone is set, the tombstones are purged
+// afterwards instead.
+type tombstoneServer struct {
+	mu            sync.Mutex
+	deletingReads int
+	gone          bool
+	confirmReads  int
+	items         map[string]map[string]any
+	tombstones    map[string]bool
+}
+
+func (s *tombstoneServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch r.Method {
+	case http.MethodGet:
+		item, ok := s.items[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if s.tombstones[r.URL.Path] {
+			s.confirmReads++
+			state := "Deleted"
+			if s.confirmReads <= s.deletingReads {
+				state = "Deleting"
+			} else if s.gone {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			item = map[string]any{"state": state}
+			w.Header().Set("Retry-After", "0")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodPut:
+		var item map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&item); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		s.items[r.URL.Path] = item
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(item)
+	case http.MethodDelete:
+		s.tombstones[r.URL.Path] = true
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func TestResource_WaitForDelete(t *testing.T) {
+	srv := &tombstoneServer{deletingReads: 2, items: map[string]map[string]any{}, tombstones: map[string]bool{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		CheckDestroy: func(*terraform.State) error {
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if srv.confirmReads != 3 {
+				return fmt.Errorf("expect 3 confirmation reads, got %d", srv.confirmReads)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path          = "/items/1"
+  create_method = "PUT"
+  body = {
+    name = "foo"
+  }
+  wait_for_delete = {
+    status_locator = "body.state"
+    status = {
+      success = "Deleted"
+      pending = ["Deleting"]
+    }
+  }
+}
+`, ts.URL),
+			},
+		},
+	})
+}
+
+func TestResource_WaitForDeleteGone(t *testing.T) {
+	srv := &tombstoneServer{deletingReads: 2, gone: true, items: map[string]map[string]any{}, tombstones: map[string]bool{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		CheckDestroy: func(*terraform.State) error {
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if srv.confirmReads != 3 {
+				return fmt.Errorf("expect 3 confirmation reads, got %d", srv.confirmReads)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path          = "/items/1"
+  create_method = "PUT"
+  body = {
+    name = "foo"
+  }
+  wait_for_delete = {
+    status_locator = "body.state"
+    status = {
+      success = "Deleted"
+      pending = ["Deleting"]
+    }
+  }
+}
+`, ts.URL),
+			},
+		},
+	})
+}
@@ -0,0 +1,55 @@
+package provider_test
+
+import (
+	"fmt"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/magodo/terraform-provider-restful/internal/acceptance"
+)
+
+func TestResource_WaitForCreate(t *testing.T) {
+	srv := &readinessServer{pendings: 2, items: map[string]map[string]any{}}
+	ts := httptest.NewServer(srv)
+	defer ts.Close()
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: acceptance.ProviderFactory(),
+		CheckDestroy: func(*terraform.State) error {
+			srv.mu.Lock()
+			defer srv.mu.Unlock()
+			if srv.readyPolls != 3 {
+				return fmt.Errorf("expect 3 readiness polls, got %d", srv.readyPolls)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "restful" {
+  base_url = %q
+}
+
+resource "restful_resource" "test" {
+  path          = "/items/1"
+  create_method = "PUT"
+  body = {
+    name = "foo"
+  }
+  wait_for_create = {
+    path           = "$(path)/ready"
+    status_locator = "body.state"
+    status = {
+      success = "Ready"
+      pending = ["Pending"]
+      failure = ["Failed"]
+    }
+  }
+}
+`, ts.URL),
+			},
+		},
+	})
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"github.com/tidwall/gjson"
+)
+
+// CountSelectorMatches counts the number of values in the JSON document that match the gjson selector.
+// A query in the form of `#(...)` only selects the first match, the count is done by turning it into `#(...)#`,
+// which selects all the matches.
+func CountSelectorMatches(doc []byte, sel string) int {
+	allSel, queries := allMatchesSelector(sel)
+	result := gjson.GetBytes(doc, allSel)
+	if !result.Exists() {
+		return 0
+	}
+	if queries == 0 {
+		return 1
+	}
+	return countLeaves(result, queries)
+}
+
+// countLeaves counts the elements of the nested arrays at the specified depth.
+func countLeaves(result gjson.Result, depth int) int {
+	if depth == 0 {
+		return 1
+	}
+	if !result.IsArray() {
+		return 1
+	}
+	var n int
+	for _, elem := range result.Array() {
+		n += countLeaves(elem, depth-1)
+	}
+	return n
+}
+
+// allMatchesSelector turns each `#(...)` query in the selector into `#(...)#`. It also returns the number of the turned queries.
+func allMatchesSelector(sel string) (string, int) {
+	var out []byte
+	var queries int
+	for i := 0; i < len(sel); i++ {
+		out = append(out, sel[i])
+		if sel[i] == '\\' && i+1 < len(sel) {
+			i++
+			out = append(out, sel[i])
+			continue
+		}
+		if sel[i] != '#' || i+1 >= len(sel) || sel[i+1] != '(' {
+			continue
+		}
+		// Find the matching ")" of the query, skipping the quoted strings.
+		var depth int
+		var inQuote bool
+		j := i + 1
+		for ; j < len(sel); j++ {
+			c := sel[j]
+			if inQuote {
+				if c == '\\' {
+					j++
+				} else if c == '"' {
+					inQuote = false
+				}
+				continue
+			}
+			switch c {
+			case '"':
+				inQuote = true
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 {
+				break
+			}
+		}
+		if j >= len(sel) {
+			out = append(out, sel[i+1:]...)
+			return string(out), queries
+		}
+		out = append(out, sel[i+1:j+1]...)
+		i = j
+		if j+1 < len(sel) && sel[j+1] == '#' {
+			out = append(out, '#')
+			i++
+		} else {
+			out = append(out, '#')
+			queries++
+		}
+	}
+	return string(out), queries
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountSelectorMatches(t *testing.T) {
+	doc := []byte(`{
+	"items": [
+		{"name": "a", "props": {"id": 1}, "tags": [{"k": "x"}, {"k": "y"}]},
+		{"name": "b", "props": {"id": 2}, "tags": [{"k": "x"}]},
+		{"name": "b", "props": {"id": 3}, "tags": []}
+	]
+}`)
+
+	cases := []struct {
+		name   string
+		sel    string
+		expect int
+	}{
+		{
+			name:   "no query",
+			sel:    "items.0",
+			expect: 1,
+		},
+		{
+			name:   "no match",
+			sel:    `items.#(name=="c")`,
+			expect: 0,
+		},
+		{
+			name:   "single match",
+			sel:    `items.#(name=="a")`,
+			expect: 1,
+		},
+		{
+			name:   "multiple matches",
+			sel:    `items.#(name=="b")`,
+			expect: 2,
+		},
+		{
+			name:   "multiple matches with path",
+			sel:    `items.#(name=="b").props`,
+			expect: 2,
+		},
+		{
+			name:   "quoted parenthesis",
+			sel:    `items.#(name==")")`,
+			expect: 0,
+		},
+		{
+			name:   "nested queries",
+			sel:    `items.#(name=="a").tags.#(k=="x")`,
+			expect: 1,
+		},
+		{
+			name:   "nested queries with multiple matches",
+			sel:    `items.#(tags.#(k=="x")).name`,
+			expect: 2,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expect, CountSelectorMatches(doc, tt.sel))
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package provider
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/magodo/terraform-provider-restful/internal/client"
 	"github.com/magodo/terraform-provider-restful/internal/exparam"
 )
@@ -20,7 +25,7 @@ func validateLocator(locator string) error {
 		return fmt.Errorf("empty right hand value for locator: %s", locator)
 	}
 	switch l {
-	case "exact", "header", "body":
+	case "exact", "header", "body", "link":
 		return nil
 	default:
 		return fmt.Errorf("unknown locator key: %s", l)
@@ -41,6 +46,8 @@ func expandValueLocatorWithParam(locator string, body []byte) (client.ValueLocat
 	switch l {
 	case "exact":
 		return client.ExactLocator(r), nil
+	case "link":
+		return client.LinkLocator(r), nil
 	case "header":
 		rr, err := exparam.ExpandBody(r, body)
 		if err != nil {
@@ -69,6 +76,8 @@ func expandValueLocator(locator string) (client.ValueLocator, error) {
 	switch l {
 	case "exact":
 		return client.ExactLocator(r), nil
+	case "link":
+		return client.LinkLocator(r), nil
 	case "header":
 		return client.HeaderLocator(r), nil
 	case "body":
@@ -77,3 +86,61 @@ func expandValueLocator(locator string) (client.ValueLocator, error) {
 		return nil, fmt.Errorf("unknown locator key: %s", l)
 	}
 }
+
+// codeStatusValidator validates that the status sentinels are HTTP status code patterns when the `status_locator` is `code`,
+// which is applied to the object that contains both the `status_locator` and the `status`.
+type codeStatusValidator struct{}
+
+func (v codeStatusValidator) Description(ctx context.Context) string {
+	return "the status sentinels must be HTTP status code patterns when the `status_locator` is `code`"
+}
+
+func (v codeStatusValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v codeStatusValidator) ValidateObject(ctx context.Context, req validator.ObjectRequest, resp *validator.ObjectResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	attrs := req.ConfigValue.Attributes()
+	locator, ok := attrs["status_locator"].(types.String)
+	if !ok || locator.IsNull() || locator.IsUnknown() || locator.ValueString() != "code" {
+		return
+	}
+	status, ok := attrs["status"].(types.Object)
+	if !ok || status.IsNull() || status.IsUnknown() {
+		return
+	}
+
+	statusPath := req.Path.AtName("status")
+	check := func(p path.Path, v attr.Value) {
+		sv, ok := v.(types.String)
+		if !ok || sv.IsNull() || sv.IsUnknown() {
+			return
+		}
+		if err := validateStatusCode(sv.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				p,
+				"Invalid status sentinel",
+				fmt.Sprintf("The `status_locator` is `code`, but the status sentinel is not an HTTP status code pattern: %v", err),
+			)
+		}
+	}
+
+	statusAttrs := status.Attributes()
+	check(statusPath.AtName("success"), statusAttrs["success"])
+	for _, name := range []string{"pending", "failure"} {
+		if l, ok := statusAttrs[name].(types.List); ok && !l.IsNull() && !l.IsUnknown() {
+			for i, v := range l.Elements() {
+				check(statusPath.AtName(name).AtListIndex(i), v)
+			}
+		}
+	}
+}
+
+// validateStatusCode validates the value is an HTTP status code pattern, e.g. "200", "2xx", "200-299" or ">=500".
+func validateStatusCode(v string) error {
+	_, err := client.ParseStatusCodePattern(v)
+	return err
+}
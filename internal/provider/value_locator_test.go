@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCodeStatusValidator(t *testing.T) {
+	statusType := map[string]attr.Type{
+		"success": types.StringType,
+		"pending": types.ListType{ElemType: types.StringType},
+		"failure": types.ListType{ElemType: types.StringType},
+	}
+	list := func(vs []string) types.List {
+		var avs []attr.Value
+		for _, v := range vs {
+			avs = append(avs, types.StringValue(v))
+		}
+		return types.ListValueMust(types.StringType, avs)
+	}
+	buildWithFailure := func(locator, success string, pending, failure []string) types.Object {
+		status := types.ObjectValueMust(statusType, map[string]attr.Value{
+			"success": types.StringValue(success),
+			"pending": list(pending),
+			"failure": list(failure),
+		})
+		return types.ObjectValueMust(
+			map[string]attr.Type{
+				"status_locator": types.StringType,
+				"status":         types.ObjectType{AttrTypes: statusType},
+			},
+			map[string]attr.Value{
+				"status_locator": types.StringValue(locator),
+				"status":         status,
+			},
+		)
+	}
+	build := func(locator, success string, pending ...string) types.Object {
+		return buildWithFailure(locator, success, pending, nil)
+	}
+
+	cases := []struct {
+		name      string
+		input     types.Object
+		expectErr bool
+	}{
+		{
+			name:  "code with status codes",
+			input: build("code", "404", "202", "200"),
+		},
+		{
+			name:  "code with status code patterns",
+			input: build("code", "2xx", ">=500", "400-499"),
+		},
+		{
+			name:  "body with non status codes",
+			input: build("body.status", "Succeeded", "InProgress"),
+		},
+		{
+			name:      "code with non status code success",
+			input:     build("code", "Succeeded", "202"),
+			expectErr: true,
+		},
+		{
+			name:      "code with non status code pending",
+			input:     build("code", "200", "InProgress"),
+			expectErr: true,
+		},
+		{
+			name:  "code with status code pattern failure",
+			input: buildWithFailure("code", "200", []string{"202"}, []string{"5xx"}),
+		},
+		{
+			name:      "code with non status code failure",
+			input:     buildWithFailure("code", "200", []string{"202"}, []string{"Failed"}),
+			expectErr: true,
+		},
+		{
+			name:      "code with out of range status code",
+			input:     build("code", "2000"),
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp validator.ObjectResponse
+			codeStatusValidator{}.ValidateObject(context.Background(), validator.ObjectRequest{
+				Path:        path.Root("poll"),
+				ConfigValue: tt.input,
+			}, &resp)
+			require.Equal(t, tt.expectErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+const (
+	vaultRefPrefix = "vault:"
+
+	envVaultAddr  = "VAULT_ADDR"
+	envVaultToken = "VAULT_TOKEN"
+
+	// vaultTimeout is the timeout of reading a secret, which avoids hanging the provider configuration on an unreachable Vault.
+	vaultTimeout = 30 * time.Second
+)
+
+// vaultReader reads secrets from the KV secrets engine of a HashiCorp Vault.
+type vaultReader struct {
+	address string
+	token   string
+	client  *http.Client
+}
+
+func newVaultReader(address string) (*vaultReader, error) {
+	if address == "" {
+		address = os.Getenv(envVaultAddr)
+	}
+	if address == "" {
+		return nil, fmt.Errorf("the Vault address is neither specified nor set via %s", envVaultAddr)
+	}
+	token := os.Getenv(envVaultToken)
+	if token == "" {
+		return nil, fmt.Errorf("the Vault token is not set via %s", envVaultToken)
+	}
+	return &vaultReader{
+		address: strings.TrimSuffix(address, "/"),
+		token:   token,
+		client:  &http.Client{Timeout: vaultTimeout},
+	}, nil
+}
+
+// Resolve returns the secret referenced by v, which is in form of `vault:<path>#<key>`.
+// Values without the `vault:` prefix are returned as is.
+func (r *vaultReader) Resolve(ctx context.Context, v string) (string, error) {
+	ref, ok := strings.CutPrefix(v, vaultRefPrefix)
+	if !ok {
+		return v, nil
+	}
+	p, key, ok := strings.Cut(ref, "#")
+	if !ok || p == "" || key == "" {
+		return "", fmt.Errorf(`invalid Vault reference %q, expect "%s<path>#<key>"`, v, vaultRefPrefix)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.address+"/v1/"+strings.TrimPrefix(p, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", r.token)
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("reading %q from Vault: %v", p, err)
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("reading the Vault response of %q: %v", p, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("reading %q from Vault returns %d: %s", p, resp.StatusCode, string(b))
+	}
+
+	// The KV v2 engine nests the secret data under "data.data", while KV v1 puts it under "data".
+	for _, dataPath := range []string{"data.data", "data"} {
+		if result, ok := gjson.GetBytes(b, dataPath).Map()[key]; ok && result.Type == gjson.String {
+			return result.String(), nil
+		}
+	}
+	return "", fmt.Errorf("no string value of key %q found at Vault path %q", key, p)
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVaultReaderResolve(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/app":
+			w.Write([]byte(`{"data": {"data": {"client_secret": "v2secret"}, "metadata": {"version": 1}}}`))
+		case "/v1/kv/app":
+			w.Write([]byte(`{"data": {"password": "v1secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	t.Setenv(envVaultToken, "token")
+	reader, err := newVaultReader(srv.URL)
+	require.NoError(t, err)
+
+	cases := []struct {
+		name   string
+		input  string
+		expect string
+		err    bool
+	}{
+		{
+			name:   "not a reference",
+			input:  "plain",
+			expect: "plain",
+		},
+		{
+			name:   "kv v2",
+			input:  "vault:secret/data/app#client_secret",
+			expect: "v2secret",
+		},
+		{
+			name:   "kv v1",
+			input:  "vault:kv/app#password",
+			expect: "v1secret",
+		},
+		{
+			name:  "no key",
+			input: "vault:kv/app",
+			err:   true,
+		},
+		{
+			name:  "key not exist",
+			input: "vault:kv/app#foo",
+			err:   true,
+		},
+		{
+			name:  "path not exist",
+			input: "vault:kv/foo#password",
+			err:   true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := reader.Resolve(context.Background(), tt.input)
+			if tt.err {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expect, out)
+		})
+	}
+}
+
+func TestVaultReaderTimeout(t *testing.T) {
+	done := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer srv.Close()
+	defer close(done)
+
+	t.Setenv(envVaultToken, "token")
+	reader, err := newVaultReader(srv.URL)
+	require.NoError(t, err)
+	require.Equal(t, vaultTimeout, reader.client.Timeout)
+
+	reader.client.Timeout = 10 * time.Millisecond
+	_, err = reader.Resolve(context.Background(), "vault:secret/data/app#client_secret")
+	require.ErrorContains(t, err, "Timeout")
+}
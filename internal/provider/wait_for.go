@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/magodo/terraform-provider-restful/internal/client"
+	"github.com/magodo/terraform-provider-restful/internal/exparam"
+	myvalidator "github.com/magodo/terraform-provider-restful/internal/validator"
+)
+
+type waitForData struct {
+	StatusLocator types.String `tfsdk:"status_locator"`
+	Status        types.Object `tfsdk:"status"`
+	Path          types.String `tfsdk:"path"`
+	Query         types.Map    `tfsdk:"query"`
+	Header        types.Map    `tfsdk:"header"`
+	DefaultDelay  types.Int64  `tfsdk:"default_delay_sec"`
+	Timeout       types.Int64  `tfsdk:"timeout_sec"`
+}
+
+// precheckDataApi converts to the readiness API of the precheck, with the status of success and pending sentinels.
+func (d waitForData) precheckDataApi(status types.Object) precheckDataApi {
+	return precheckDataApi{
+		StatusLocator: d.StatusLocator,
+		Status:        status,
+		Path:          d.Path,
+		Query:         d.Query,
+		Header:        d.Header,
+		DefaultDelay:  d.DefaultDelay,
+		CacheTTL:      types.Int64Null(),
+	}
+}
+
+type waitForStatusData struct {
+	Success string   `tfsdk:"success"`
+	Pending []string `tfsdk:"pending"`
+	Failure []string `tfsdk:"failure"`
+}
+
+// waitForAttribute returns the schema of the `wait_for*` blocks, which wait for an API to be ready after the call of the
+// specified operation (e.g. "Create").
+func waitForAttribute(op string, defaultPathDesc string) schema.SingleNestedAttribute {
+	return schema.SingleNestedAttribute{
+		Description:         fmt.Sprintf("Keeps polling an API after the %[1]s call (including the polling of its LRO, if any), until it meets the success status, before the %[1]s is regarded as done. This is useful for APIs that return immediately, while the resource or a dependent subsystem becomes ready asynchronously, possibly at a separate endpoint.", op),
+		MarkdownDescription: fmt.Sprintf("Keeps polling an API after the %[1]s call (including the polling of its LRO, if any), until it meets the success status, before the %[1]s is regarded as done. This is useful for APIs that return immediately, while the resource or a dependent subsystem becomes ready asynchronously, possibly at a separate endpoint.", op),
+		Optional:            true,
+		Attributes: map[string]schema.Attribute{
+			"status_locator": schema.StringAttribute{
+				Description:         "Specifies how to discover the status property. The format is either `code` or `scope.path`, where `scope` can be either `header` or `body`, and the `path` is using the gjson syntax.",
+				MarkdownDescription: "Specifies how to discover the status property. The format is either `code` or `scope.path`, where `scope` can be either `header` or `body`, and the `path` is using the [gjson syntax](https://github.com/tidwall/gjson/blob/master/SYNTAX.md).",
+				Required:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsParsable("status_locator", func(s string) error {
+						return validateLocator(s)
+					}),
+				},
+			},
+			"status": schema.SingleNestedAttribute{
+				Description:         "The expected status sentinels for each polling state.",
+				MarkdownDescription: "The expected status sentinels for each polling state.",
+				Required:            true,
+				Attributes: map[string]schema.Attribute{
+					"success": schema.StringAttribute{
+						Description:         "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+						MarkdownDescription: "The expected status sentinel for suceess status. For the `code` locator, this can also be a status code pattern, e.g. `2xx`, `200-299` or `>=500`.",
+						Required:            true,
+					},
+					"pending": schema.ListAttribute{
+						Description:         "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+						MarkdownDescription: "The expected status sentinels for pending status. For the `code` locator, these can also be status code patterns, e.g. `2xx`, `200-299` or `>=500`.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"failure": schema.ListAttribute{
+						Description:         "The status sentinels that fail the waiting immediately, with the status reported in the error. Any other status that is neither success nor pending also fails the waiting. For the `code` locator, these can also be status code patterns, e.g. `5xx`.",
+						MarkdownDescription: "The status sentinels that fail the waiting immediately, with the status reported in the error. Any other status that is neither success nor pending also fails the waiting. For the `code` locator, these can also be status code patterns, e.g. `5xx`.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+				},
+			},
+			"path": schema.StringAttribute{
+				Description:         fmt.Sprintf("The path used to query readiness, relative to the `base_url` of the provider. %s The body and header params reference the response of the %s call. %s", defaultPathDesc, op, pathDescription+headerParamDescription),
+				MarkdownDescription: fmt.Sprintf("The path used to query readiness, relative to the `base_url` of the provider. %s The body and header params reference the response of the %s call. %s", defaultPathDesc, op, pathDescription+headerParamDescription),
+				Optional:            true,
+				Validators: []validator.String{
+					myvalidator.StringIsPathBuilder(),
+				},
+			},
+			"query": schema.MapAttribute{
+				Description:         "The query parameters. This overrides the `query` set in the resource block.",
+				MarkdownDescription: "The query parameters. This overrides the `query` set in the resource block.",
+				ElementType:         types.ListType{ElemType: types.StringType},
+				Optional:            true,
+			},
+			"header": schema.MapAttribute{
+				Description:         "The header parameters. This overrides the `header` set in the resource block.",
+				MarkdownDescription: "The header parameters. This overrides the `header` set in the resource block.",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"default_delay_sec": schema.Int64Attribute{
+				Description:         "The interval between two pollings if there is no `Retry-After` in the response header, in second. Defaults to `10`.",
+				MarkdownDescription: "The interval between two pollings if there is no `Retry-After` in the response header, in second. Defaults to `10`.",
+				Optional:            true,
+			},
+			"timeout_sec": schema.Int64Attribute{
+				Description:         "The maximum time to wait in seconds. Defaults to no timeout.",
+				MarkdownDescription: "The maximum time to wait in seconds. Defaults to no timeout.",
+				Optional:            true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+		},
+		Validators: []validator.Object{
+			codeStatusValidator{},
+		},
+	}
+}
+
+// waitForDeleteAttribute returns the schema of the `wait_for_delete` block, which regards a `404` response as success.
+func waitForDeleteAttribute() schema.SingleNestedAttribute {
+	attr := waitForAttribute("`Delete`", "By default, the `id` of this resource is used.")
+	attr.Description += " A `404` response is regarded as success, as the resource is gone. E.g. to wait until the resource is gone, set the `status_locator` to `code`, the `success` to `404` and the `pending` to `[\"2xx\"]`."
+	attr.MarkdownDescription += " A `404` response is regarded as success, as the resource is gone. E.g. to wait until the resource is gone, set the `status_locator` to `code`, the `success` to `404` and the `pending` to `[\"2xx\"]`."
+	return attr
+}
+
+// waitForOption is the context of a wait, besides the readiness API itself.
+type waitForOption struct {
+	// DefaultPath is polled if the readiness API has no path, which is also the `$(path)` of the path expression.
+	DefaultPath string
+	Header      client.Header
+	Query       client.Query
+	// RespBody and RespHeader are the response of the preceding call, which are referenced by the path expression.
+	RespBody   []byte
+	RespHeader http.Header
+	// Output is referenced by the params of the status locator.
+	Output basetypes.DynamicValue
+	// Failure is the status sentinels that fail the waiting immediately.
+	Failure []string
+	// GoneAsSuccess regards a 404 response of the readiness API as success.
+	GoneAsSuccess bool
+	// Timeout is the maximum time to wait, which is no timeout if zero.
+	Timeout time.Duration
+}
+
+// waitFor keeps polling the readiness API until it meets the success status. This is the readiness engine of the
+// `wait_for*` blocks, whose name is used in the diagnostics.
+func waitFor(ctx context.Context, c *client.Client, apiOpt apiOption, name string, d precheckDataApi, wopt waitForOption) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if wopt.Timeout != 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, wopt.Timeout)
+		defer cancel()
+	}
+	if !d.Path.IsNull() {
+		path, err := exparam.ExpandBodyOrPath(d.Path.ValueString(), wopt.DefaultPath, wopt.RespBody, wopt.RespHeader)
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("Failed to build the path of `%s`", name),
+				err.Error(),
+			)
+			return diags
+		}
+		d.Path = types.StringValue(path)
+	}
+	popt, odiags := apiOpt.ForPrecheck(ctx, wopt.DefaultPath, wopt.Header, wopt.Query, d, wopt.Output)
+	diags.Append(odiags...)
+	if diags.HasError() {
+		return diags
+	}
+	if len(wopt.Failure) != 0 {
+		popt.AbortIf = append(popt.AbortIf, client.PollAbortCondition{Locator: popt.StatusLocator, Values: wopt.Failure})
+	}
+	p, err := client.NewPollableForPrecheck(*popt)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("Failed to build poller for `%s`", name),
+			err.Error(),
+		)
+		return diags
+	}
+	p.GoneAsSuccess = wopt.GoneAsSuccess
+	if err := p.PollUntilDone(ctx, c); err != nil {
+		diags.AddError(
+			fmt.Sprintf("Polling failure of `%s`", name),
+			err.Error(),
+		)
+		return diags
+	}
+	return diags
+}
+
+// waitForBlock waits for the readiness API configured by the `wait_for*` block, if it is not null.
+func waitForBlock(ctx context.Context, c *client.Client, apiOpt apiOption, name string, obj types.Object, wopt waitForOption) diag.Diagnostics {
+	if obj.IsNull() {
+		return nil
+	}
+	var d waitForData
+	if diags := obj.As(ctx, &d, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return diags
+	}
+	var status waitForStatusData
+	if diags := d.Status.As(ctx, &status, basetypes.ObjectAsOptions{}); diags.HasError() {
+		return diags
+	}
+	precheckStatus, diags := types.ObjectValueFrom(ctx, map[string]attr.Type{
+		"success": types.StringType,
+		"pending": types.ListType{ElemType: types.StringType},
+	}, statusDataGo{Success: status.Success, Pending: status.Pending})
+	if diags.HasError() {
+		return diags
+	}
+	wopt.Failure = status.Failure
+	if !d.Timeout.IsNull() {
+		wopt.Timeout = time.Duration(d.Timeout.ValueInt64()) * time.Second
+	}
+	return waitFor(ctx, c, apiOpt, name, d.precheckDataApi(precheckStatus), wopt)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/magodo/terraform-provider-restful/internal/client"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaitForBlock(t *testing.T) {
+	cases := []struct {
+		name        string
+		states      []string
+		expectError string
+		expectReads int
+	}{
+		{
+			name:        "ready after pending",
+			states:      []string{"Provisioning", "Provisioning", "Ready"},
+			expectReads: 3,
+		},
+		{
+			name:        "failure status",
+			states:      []string{"Provisioning", "Failed"},
+			expectError: `polling aborted as body.state is "Failed"`,
+			expectReads: 2,
+		},
+		{
+			name:        "unexpected status",
+			states:      []string{"Unknown"},
+			expectError: `Unexpected status "Unknown"`,
+			expectReads: 1,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var reads int
+			var gotPath string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gotPath = r.URL.Path
+				w.Write([]byte(`{"state":"` + tt.states[reads] + `"}`))
+				reads++
+			}))
+			defer srv.Close()
+
+			c, err := client.New(context.Background(), srv.URL, &client.BuildOption{})
+			require.NoError(t, err)
+			baseURL, err := url.Parse(srv.URL)
+			require.NoError(t, err)
+
+			obj := types.ObjectValueMust(
+				map[string]attr.Type{
+					"status_locator": types.StringType,
+					"status": types.ObjectType{AttrTypes: map[string]attr.Type{
+						"success": types.StringType,
+						"pending": types.ListType{ElemType: types.StringType},
+						"failure": types.ListType{ElemType: types.StringType},
+					}},
+					"path":              types.StringType,
+					"query":             types.MapType{ElemType: types.ListType{ElemType: types.StringType}},
+					"header":            types.MapType{ElemType: types.StringType},
+					"default_delay_sec": types.Int64Type,
+					"timeout_sec":       types.Int64Type,
+				},
+				map[string]attr.Value{
+					"status_locator": types.StringValue("body.state"),
+					"status": types.ObjectValueMust(
+						map[string]attr.Type{
+							"success": types.StringType,
+							"pending": types.ListType{ElemType: types.StringType},
+							"failure": types.ListType{ElemType: types.StringType},
+						},
+						map[string]attr.Value{
+							"success": types.StringValue("Ready"),
+							"pending": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Provisioning")}),
+							"failure": types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Failed")}),
+						},
+					),
+					"path":              types.StringValue("/status/$(body.id)"),
+					"query":             types.MapNull(types.ListType{ElemType: types.StringType}),
+					"header":            types.MapNull(types.StringType),
+					"default_delay_sec": types.Int64Value(0),
+					"timeout_sec":       types.Int64Null(),
+				},
+			)
+
+			diags := waitForBlock(context.Background(), c, apiOption{BaseURL: *baseURL}, "wait_for_create", obj, waitForOption{
+				DefaultPath: "/foos/1",
+				RespBody:    []byte(`{"id":"1"}`),
+				Output:      types.DynamicNull(),
+			})
+			if tt.expectError != "" {
+				require.True(t, diags.HasError())
+				require.Contains(t, diags.Errors()[0].Detail(), tt.expectError)
+			} else {
+				require.False(t, diags.HasError(), diags)
+			}
+			require.Equal(t, tt.expectReads, reads)
+			require.Equal(t, "/status/1", gotPath)
+		})
+	}
+}
@@ -3,6 +3,7 @@ package validator
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/diag"
@@ -10,7 +11,13 @@ import (
 	"github.com/magodo/terraform-provider-restful/internal/exparam"
 )
 
-type stringsIsPathBuilder struct{}
+// malformedParamPattern matches the leftover param openings, after the well formed params are removed.
+var malformedParamPattern = regexp.MustCompile(`\$[\w.]*\(`)
+
+type stringsIsPathBuilder struct {
+	// noHeader disallows the header params, for the path builders that are not expanded with a response.
+	noHeader bool
+}
 
 func (v stringsIsPathBuilder) Description(ctx context.Context) string {
 	return "validate this is a path builder expression"
@@ -20,7 +27,7 @@ func (v stringsIsPathBuilder) MarkdownDescription(ctx context.Context) string {
 	return "validate this is a path builder expression"
 }
 
-func (_ stringsIsPathBuilder) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+func (v stringsIsPathBuilder) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
 	str := req.ConfigValue
 
 	if str.IsUnknown() || str.IsNull() {
@@ -31,6 +38,23 @@ func (_ stringsIsPathBuilder) ValidateString(ctx context.Context, req validator.
 	check := func(matches [][]string) diag.Diagnostic {
 		for _, match := range matches {
 			fnames, value := match[1], match[2]
+			switch {
+			case value == "path", value == "body", strings.HasPrefix(value, "body."):
+			case strings.HasPrefix(value, "header."):
+				if v.noHeader {
+					return diag.NewAttributeErrorDiagnostic(
+						req.Path,
+						"Invalid String",
+						fmt.Sprintf("header param isn't supported here: %s", match[0]),
+					)
+				}
+			default:
+				return diag.NewAttributeErrorDiagnostic(
+					req.Path,
+					"Invalid String",
+					fmt.Sprintf("unknown param: %s, expect one of `path`, `body` or `header`", match[0]),
+				)
+			}
 			for _, fname := range strings.Split(fnames, ".") {
 				if fname != "" {
 					if _, ok := pathFuncs[exparam.FuncName(fname)]; !ok {
@@ -40,11 +64,11 @@ func (_ stringsIsPathBuilder) ValidateString(ctx context.Context, req validator.
 							fmt.Sprintf("unknown function: %s", fname),
 						)
 					}
-					if !strings.HasPrefix(value, "body.") {
+					if !strings.HasPrefix(value, "body") && !strings.HasPrefix(value, "header.") {
 						return diag.NewAttributeErrorDiagnostic(
 							req.Path,
 							"Invalid String",
-							fmt.Sprintf("value isn't a body reference"),
+							fmt.Sprintf("value isn't a body or header reference"),
 						)
 					}
 				}
@@ -57,8 +81,21 @@ func (_ stringsIsPathBuilder) ValidateString(ctx context.Context, req validator.
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	if m := malformedParamPattern.FindString(exparam.Pattern.ReplaceAllString(str.ValueString(), "")); m != "" {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid String",
+			fmt.Sprintf("malformed param starting with %q", m),
+		)
+	}
 }
 
 func StringIsPathBuilder() stringsIsPathBuilder {
 	return stringsIsPathBuilder{}
 }
+
+// StringIsPathBuilderWithoutHeader is the same as StringIsPathBuilder, but disallows the header params.
+func StringIsPathBuilderWithoutHeader() stringsIsPathBuilder {
+	return stringsIsPathBuilder{noHeader: true}
+}
@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStringIsPathBuilder(t *testing.T) {
+	cases := []struct {
+		name      string
+		input     string
+		noHeader  bool
+		expectErr bool
+	}{
+		{
+			name:  "literal",
+			input: "/foos/foo",
+		},
+		{
+			name:  "path and body params",
+			input: "$(path)/$(body.id)",
+		},
+		{
+			name:  "functions on body",
+			input: "$(path)/$base.escape(body.id)",
+		},
+		{
+			name:  "functions on the whole body",
+			input: "$(path)/$base64encode(body)",
+		},
+		{
+			name:  "header param",
+			input: "$url_path(header.Location)",
+		},
+		{
+			name:      "header param not supported",
+			input:     "$url_path(header.Location)",
+			noHeader:  true,
+			expectErr: true,
+		},
+		{
+			name:      "unknown scope",
+			input:     "$(path)/$(bodyy.id)",
+			expectErr: true,
+		},
+		{
+			name:      "unknown function",
+			input:     "$(path)/$basee(body.id)",
+			expectErr: true,
+		},
+		{
+			name:      "function on path",
+			input:     "$escape(path)",
+			expectErr: true,
+		},
+		{
+			name:      "malformed param",
+			input:     "$(path)/$(body.id",
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			v := StringIsPathBuilder()
+			if tt.noHeader {
+				v = StringIsPathBuilderWithoutHeader()
+			}
+			var resp validator.StringResponse
+			v.ValidateString(context.Background(), validator.StringRequest{
+				Path:        path.Root("read_path"),
+				ConfigValue: types.StringValue(tt.input),
+			}, &resp)
+			require.Equal(t, tt.expectErr, resp.Diagnostics.HasError(), resp.Diagnostics)
+		})
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
+	"github.com/magodo/terraform-provider-restful/internal/client"
 	"github.com/magodo/terraform-provider-restful/internal/provider"
 
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
@@ -27,6 +29,13 @@ func main() {
 
 	err := providerserver.Serve(ctx, provider.New, serveOpts)
 
+	// Flush the spans of the API calls, if any, before exiting.
+	sctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+	defer cancel()
+	if err := client.ShutdownTracing(sctx); err != nil {
+		log.Printf("Error shutting down tracing: %s", err)
+	}
+
 	if err != nil {
 		log.Fatalf("Error serving provider: %s", err)
 	}